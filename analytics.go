@@ -0,0 +1,343 @@
+package appstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	//AnalyticsResourceTypeReportRequests const
+	AnalyticsResourceTypeReportRequests = "analyticsReportRequests"
+	//AnalyticsResourceTypeReports const
+	AnalyticsResourceTypeReports = "analyticsReports"
+	//AnalyticsResourceTypeReportInstances const
+	AnalyticsResourceTypeReportInstances = "analyticsReportInstances"
+	//AnalyticsResourceTypeReportSegments const
+	AnalyticsResourceTypeReportSegments = "analyticsReportSegments"
+)
+
+//AnalyticsReportRequest Request of analytics reports of app
+// .see https://developer.apple.com/documentation/appstoreconnectapi/analyticsreportrequest
+type AnalyticsReportRequest struct {
+	Type          string                               `json:"type"`
+	Id            string                               `json:"id"`
+	Attributes    *AnalyticsReportRequestAttributes    `json:"attributes,omitempty"`
+	Relationships *AnalyticsReportRequestRelationships `json:"relationships,omitempty"`
+	Links         *ResourceLinks                       `json:"links,omitempty"`
+}
+
+//AnalyticsReportRequestAttributes Attributes of analytics report request
+type AnalyticsReportRequestAttributes struct {
+	AccessType             AnalyticsReportAccessType `json:"accessType"`             //ONGOING or ONE_TIME_SNAPSHOT
+	StoppedDueToInactivity bool                      `json:"stoppedDueToInactivity"` //Apple stops generating ongoing reports which are not downloaded
+}
+
+//AnalyticsReportRequestRelationships Relationships of analytics report request
+type AnalyticsReportRequestRelationships struct {
+	Reports *Relationship `json:"reports,omitempty"`
+}
+
+//AnalyticsReport Report of analytics report request, e.g. App Sessions Standard
+// .see https://developer.apple.com/documentation/appstoreconnectapi/analyticsreport
+type AnalyticsReport struct {
+	Type       string                     `json:"type"`
+	Id         string                     `json:"id"`
+	Attributes *AnalyticsReportAttributes `json:"attributes,omitempty"`
+	Links      *ResourceLinks             `json:"links,omitempty"`
+}
+
+//AnalyticsReportAttributes Attributes of analytics report
+type AnalyticsReportAttributes struct {
+	Name     string                  `json:"name"`
+	Category AnalyticsReportCategory `json:"category"`
+}
+
+//AnalyticsReportInstance Instance of analytics report generated for granularity and processing date
+// .see https://developer.apple.com/documentation/appstoreconnectapi/analyticsreportinstance
+type AnalyticsReportInstance struct {
+	Type       string                             `json:"type"`
+	Id         string                             `json:"id"`
+	Attributes *AnalyticsReportInstanceAttributes `json:"attributes,omitempty"`
+	Links      *ResourceLinks                     `json:"links,omitempty"`
+}
+
+//AnalyticsReportInstanceAttributes Attributes of analytics report instance
+type AnalyticsReportInstanceAttributes struct {
+	Granularity    AnalyticsReportGranularity `json:"granularity"`
+	ProcessingDate CustomDate                 `json:"processingDate"`
+}
+
+//AnalyticsReportSegment Downloadable part of analytics report instance
+// .see https://developer.apple.com/documentation/appstoreconnectapi/analyticsreportsegment
+type AnalyticsReportSegment struct {
+	Type       string                            `json:"type"`
+	Id         string                            `json:"id"`
+	Attributes *AnalyticsReportSegmentAttributes `json:"attributes,omitempty"`
+	Links      *ResourceLinks                    `json:"links,omitempty"`
+}
+
+//AnalyticsReportSegmentAttributes Attributes of analytics report segment
+type AnalyticsReportSegmentAttributes struct {
+	Checksum    string `json:"checksum"`    //MD5 checksum of gzipped segment file
+	SizeInBytes int64  `json:"sizeInBytes"` //Size of gzipped segment file
+	Url         string `json:"url"`         //Pre-signed url of gzipped segment file
+}
+
+//AnalyticsReportRequestResponse struct
+type AnalyticsReportRequestResponse struct {
+	Document
+	Data *AnalyticsReportRequest `json:"data,omitempty"`
+}
+
+//AnalyticsReportRequestsResponse struct
+type AnalyticsReportRequestsResponse struct {
+	Document
+	Data []*AnalyticsReportRequest `json:"data,omitempty"`
+}
+
+//AnalyticsReportResponse struct
+type AnalyticsReportResponse struct {
+	Document
+	Data *AnalyticsReport `json:"data,omitempty"`
+}
+
+//AnalyticsReportsResponse struct
+type AnalyticsReportsResponse struct {
+	Document
+	Data []*AnalyticsReport `json:"data,omitempty"`
+}
+
+//AnalyticsReportInstanceResponse struct
+type AnalyticsReportInstanceResponse struct {
+	Document
+	Data *AnalyticsReportInstance `json:"data,omitempty"`
+}
+
+//AnalyticsReportInstancesResponse struct
+type AnalyticsReportInstancesResponse struct {
+	Document
+	Data []*AnalyticsReportInstance `json:"data,omitempty"`
+}
+
+//AnalyticsReportSegmentResponse struct
+type AnalyticsReportSegmentResponse struct {
+	Document
+	Data *AnalyticsReportSegment `json:"data,omitempty"`
+}
+
+//AnalyticsReportSegmentsResponse struct
+type AnalyticsReportSegmentsResponse struct {
+	Document
+	Data []*AnalyticsReportSegment `json:"data,omitempty"`
+}
+
+//AnalyticsReportsResource Analytics Reports API resource
+type AnalyticsReportsResource struct {
+	ResourceAbstract
+}
+
+//CreateReportRequest Request analytics reports of app, ongoing requests generate reports every day
+func (arr *AnalyticsReportsResource) CreateReportRequest(ctx context.Context, appId string, accessType AnalyticsReportAccessType) (*AnalyticsReportRequestResponse, *http.Response, error) {
+	if appId == "" {
+		return nil, nil, fmt.Errorf("AnalyticsReportsResource.CreateReportRequest: %v", "appId is required")
+	}
+	if accessType != AnalyticsReportAccessTypeOngoing && accessType != AnalyticsReportAccessTypeOneTimeSnapshot {
+		return nil, nil, fmt.Errorf("AnalyticsReportsResource.CreateReportRequest: AccessType %s is unknown", accessType)
+	}
+	attributes := map[string]interface{}{"accessType": string(accessType)}
+	relationships := map[string]*Relationship{"app": NewToOneRelationship("apps", appId)}
+	body := NewResourceRequestBody(AnalyticsResourceTypeReportRequests, "", attributes, relationships)
+	result := &AnalyticsReportRequestResponse{}
+	resp, err := arr.send(ctx, http.MethodPost, "v1/analyticsReportRequests", nil, body, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AnalyticsReportsResource.CreateReportRequest error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//GetReportRequests Get analytics report requests of app by filter
+func (arr *AnalyticsReportsResource) GetReportRequests(ctx context.Context, appId string, filter *AnalyticsReportRequestsFilter) (*AnalyticsReportRequestsResponse, *http.Response, error) {
+	if filter == nil {
+		filter = NewAnalyticsReportRequestsFilter()
+	}
+	if err := filter.IsValid(); err != nil {
+		return nil, nil, fmt.Errorf("AnalyticsReportsResource.GetReportRequests invalid filter: %v", err)
+	}
+	result := &AnalyticsReportRequestsResponse{}
+	resp, err := arr.send(ctx, http.MethodGet, "v1/apps/"+appId+"/analyticsReportRequests", filter.toQueryParamsMap(), nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AnalyticsReportsResource.GetReportRequests error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//IterateReportRequests Iterate pages of analytics report requests of app, pages are *AnalyticsReportRequestsResponse
+func (arr *AnalyticsReportsResource) IterateReportRequests(appId string, filter *AnalyticsReportRequestsFilter) *PageIterator {
+	if filter == nil {
+		filter = NewAnalyticsReportRequestsFilter()
+	}
+	return arr.newPageIterator("v1/apps/"+appId+"/analyticsReportRequests", filter.toQueryParamsMap())
+}
+
+//GetReportRequest Get analytics report request by id
+func (arr *AnalyticsReportsResource) GetReportRequest(ctx context.Context, id string) (*AnalyticsReportRequestResponse, *http.Response, error) {
+	result := &AnalyticsReportRequestResponse{}
+	resp, err := arr.send(ctx, http.MethodGet, "v1/analyticsReportRequests/"+id, nil, nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AnalyticsReportsResource.GetReportRequest error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//DeleteReportRequest Delete analytics report request, Apple stops generating its reports
+func (arr *AnalyticsReportsResource) DeleteReportRequest(ctx context.Context, id string) (*http.Response, error) {
+	result := &ResponseBody{}
+	resp, err := arr.send(ctx, http.MethodDelete, "v1/analyticsReportRequests/"+id, nil, nil, result)
+	if err != nil {
+		return resp, fmt.Errorf("AnalyticsReportsResource.DeleteReportRequest error: %v", err)
+	}
+	return resp, nil
+}
+
+//GetReports Get reports of analytics report request by filter, e.g. by category
+func (arr *AnalyticsReportsResource) GetReports(ctx context.Context, requestId string, filter *AnalyticsReportsFilter) (*AnalyticsReportsResponse, *http.Response, error) {
+	if filter == nil {
+		filter = NewAnalyticsReportsFilter()
+	}
+	if err := filter.IsValid(); err != nil {
+		return nil, nil, fmt.Errorf("AnalyticsReportsResource.GetReports invalid filter: %v", err)
+	}
+	result := &AnalyticsReportsResponse{}
+	resp, err := arr.send(ctx, http.MethodGet, "v1/analyticsReportRequests/"+requestId+"/reports", filter.toQueryParamsMap(), nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AnalyticsReportsResource.GetReports error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//IterateReports Iterate pages of reports of analytics report request, pages are *AnalyticsReportsResponse
+func (arr *AnalyticsReportsResource) IterateReports(requestId string, filter *AnalyticsReportsFilter) *PageIterator {
+	if filter == nil {
+		filter = NewAnalyticsReportsFilter()
+	}
+	return arr.newPageIterator("v1/analyticsReportRequests/"+requestId+"/reports", filter.toQueryParamsMap())
+}
+
+//GetReport Get analytics report by id
+func (arr *AnalyticsReportsResource) GetReport(ctx context.Context, id string) (*AnalyticsReportResponse, *http.Response, error) {
+	result := &AnalyticsReportResponse{}
+	resp, err := arr.send(ctx, http.MethodGet, "v1/analyticsReports/"+id, nil, nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AnalyticsReportsResource.GetReport error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//GetInstances Get instances of analytics report by filter, e.g. by granularity and processing date
+func (arr *AnalyticsReportsResource) GetInstances(ctx context.Context, reportId string, filter *AnalyticsReportInstancesFilter) (*AnalyticsReportInstancesResponse, *http.Response, error) {
+	if filter == nil {
+		filter = NewAnalyticsReportInstancesFilter()
+	}
+	if err := filter.IsValid(); err != nil {
+		return nil, nil, fmt.Errorf("AnalyticsReportsResource.GetInstances invalid filter: %v", err)
+	}
+	result := &AnalyticsReportInstancesResponse{}
+	resp, err := arr.send(ctx, http.MethodGet, "v1/analyticsReports/"+reportId+"/instances", filter.toQueryParamsMap(), nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AnalyticsReportsResource.GetInstances error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//IterateInstances Iterate pages of instances of analytics report, pages are *AnalyticsReportInstancesResponse
+func (arr *AnalyticsReportsResource) IterateInstances(reportId string, filter *AnalyticsReportInstancesFilter) *PageIterator {
+	if filter == nil {
+		filter = NewAnalyticsReportInstancesFilter()
+	}
+	return arr.newPageIterator("v1/analyticsReports/"+reportId+"/instances", filter.toQueryParamsMap())
+}
+
+//GetInstance Get analytics report instance by id
+func (arr *AnalyticsReportsResource) GetInstance(ctx context.Context, id string) (*AnalyticsReportInstanceResponse, *http.Response, error) {
+	result := &AnalyticsReportInstanceResponse{}
+	resp, err := arr.send(ctx, http.MethodGet, "v1/analyticsReportInstances/"+id, nil, nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AnalyticsReportsResource.GetInstance error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//GetSegments Get downloadable segments of analytics report instance
+func (arr *AnalyticsReportsResource) GetSegments(ctx context.Context, instanceId string) (*AnalyticsReportSegmentsResponse, *http.Response, error) {
+	result := &AnalyticsReportSegmentsResponse{}
+	resp, err := arr.send(ctx, http.MethodGet, "v1/analyticsReportInstances/"+instanceId+"/segments", nil, nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AnalyticsReportsResource.GetSegments error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//IterateSegments Iterate pages of segments of analytics report instance, pages are *AnalyticsReportSegmentsResponse
+func (arr *AnalyticsReportsResource) IterateSegments(instanceId string) *PageIterator {
+	return arr.newPageIterator("v1/analyticsReportInstances/"+instanceId+"/segments", nil)
+}
+
+//GetSegment Get analytics report segment by id
+func (arr *AnalyticsReportsResource) GetSegment(ctx context.Context, id string) (*AnalyticsReportSegmentResponse, *http.Response, error) {
+	result := &AnalyticsReportSegmentResponse{}
+	resp, err := arr.send(ctx, http.MethodGet, "v1/analyticsReportSegments/"+id, nil, nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AnalyticsReportsResource.GetSegment error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//DownloadSegment Download segment file, verify its size and checksum and decompress it, returns TSV data
+func (arr *AnalyticsReportsResource) DownloadSegment(ctx context.Context, segment *AnalyticsReportSegment) ([]byte, error) {
+	if segment == nil || segment.Attributes == nil || segment.Attributes.Url == "" {
+		return nil, fmt.Errorf("AnalyticsReportsResource.DownloadSegment: %v", "segment url is required")
+	}
+	attributes := segment.Attributes
+	resp, err := arr.transport.Download(ctx, attributes.Url)
+	if err != nil {
+		return nil, fmt.Errorf("AnalyticsReportsResource.DownloadSegment error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("AnalyticsReportsResource.DownloadSegment: unexpected status %d", resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("AnalyticsReportsResource.DownloadSegment read body: %v", err)
+	}
+	telemetry := arr.transport.getTelemetry()
+	attrs := []Attribute{{Key: "report.type", Value: "AnalyticsReportSegment"}}
+	telemetry.AddCounter(ctx, TelemetryMetricBytesDownloaded, int64(len(data)), attrs...)
+	if attributes.SizeInBytes > 0 && int64(len(data)) != attributes.SizeInBytes {
+		return nil, fmt.Errorf("AnalyticsReportsResource.DownloadSegment: size %d does not match expected %d", len(data), attributes.SizeInBytes)
+	}
+	if attributes.Checksum != "" {
+		sum := md5.Sum(data)
+		if checksum := hex.EncodeToString(sum[:]); checksum != strings.ToLower(attributes.Checksum) {
+			return nil, fmt.Errorf("AnalyticsReportsResource.DownloadSegment: checksum %s does not match expected %s", checksum, attributes.Checksum)
+		}
+	}
+	//size and checksum are of gzipped file, it is decompressed after verification
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("AnalyticsReportsResource.DownloadSegment decompress: %v", err)
+	}
+	defer zr.Close()
+	data, err = ioutil.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("AnalyticsReportsResource.DownloadSegment decompress: %v", err)
+	}
+	return data, nil
+}
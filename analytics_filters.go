@@ -0,0 +1,231 @@
+package appstore
+
+import (
+	"fmt"
+	"time"
+)
+
+//AnalyticsReportAccessType type
+type AnalyticsReportAccessType string
+
+const (
+	//AnalyticsReportAccessTypeOngoing const, reports are generated every day with new data
+	AnalyticsReportAccessTypeOngoing AnalyticsReportAccessType = "ONGOING"
+	//AnalyticsReportAccessTypeOneTimeSnapshot const, reports are generated once with all historical data
+	AnalyticsReportAccessTypeOneTimeSnapshot AnalyticsReportAccessType = "ONE_TIME_SNAPSHOT"
+)
+
+//AnalyticsReportCategory type
+type AnalyticsReportCategory string
+
+const (
+	//AnalyticsReportCategoryAppStoreEngagement const
+	AnalyticsReportCategoryAppStoreEngagement AnalyticsReportCategory = "APP_STORE_ENGAGEMENT"
+	//AnalyticsReportCategoryAppStoreCommerce const
+	AnalyticsReportCategoryAppStoreCommerce AnalyticsReportCategory = "APP_STORE_COMMERCE"
+	//AnalyticsReportCategoryAppUsage const
+	AnalyticsReportCategoryAppUsage AnalyticsReportCategory = "APP_USAGE"
+	//AnalyticsReportCategoryFrameworkUsage const
+	AnalyticsReportCategoryFrameworkUsage AnalyticsReportCategory = "FRAMEWORK_USAGE"
+	//AnalyticsReportCategoryPerformance const
+	AnalyticsReportCategoryPerformance AnalyticsReportCategory = "PERFORMANCE"
+)
+
+//AnalyticsReportGranularity type
+type AnalyticsReportGranularity string
+
+const (
+	//AnalyticsReportGranularityDaily const
+	AnalyticsReportGranularityDaily AnalyticsReportGranularity = "DAILY"
+	//AnalyticsReportGranularityWeekly const
+	AnalyticsReportGranularityWeekly AnalyticsReportGranularity = "WEEKLY"
+	//AnalyticsReportGranularityMonthly const
+	AnalyticsReportGranularityMonthly AnalyticsReportGranularity = "MONTHLY"
+)
+
+//AnalyticsReportRequestsFilter analytics report requests filter
+type AnalyticsReportRequestsFilter struct {
+	AccessType AnalyticsReportAccessType //Possible values: ONGOING, ONE_TIME_SNAPSHOT
+	Limit      int                       //Number of resources to return, maximum 200
+}
+
+//SetAccessType Set access type
+func (f *AnalyticsReportRequestsFilter) SetAccessType(value AnalyticsReportAccessType) *AnalyticsReportRequestsFilter {
+	f.AccessType = value
+	return f
+}
+
+//SetLimit Set number of resources to return
+func (f *AnalyticsReportRequestsFilter) SetLimit(value int) *AnalyticsReportRequestsFilter {
+	f.Limit = value
+	return f
+}
+
+//Ongoing Change access type to Ongoing
+func (f *AnalyticsReportRequestsFilter) Ongoing() *AnalyticsReportRequestsFilter {
+	return f.SetAccessType(AnalyticsReportAccessTypeOngoing)
+}
+
+//OneTimeSnapshot Change access type to OneTimeSnapshot
+func (f *AnalyticsReportRequestsFilter) OneTimeSnapshot() *AnalyticsReportRequestsFilter {
+	return f.SetAccessType(AnalyticsReportAccessTypeOneTimeSnapshot)
+}
+
+//toQueryParamsMap Convert filter to query params
+func (f *AnalyticsReportRequestsFilter) toQueryParamsMap() map[string]interface{} {
+	qs := make(map[string]interface{})
+	if f.AccessType != "" {
+		qs["filter[accessType]"] = string(f.AccessType)
+	}
+	if f.Limit > 0 {
+		qs["limit"] = f.Limit
+	}
+	return qs
+}
+
+//IsValid Validate analytics report requests filter params
+func (f *AnalyticsReportRequestsFilter) IsValid() error {
+	if f.AccessType != "" && f.AccessType != AnalyticsReportAccessTypeOngoing && f.AccessType != AnalyticsReportAccessTypeOneTimeSnapshot {
+		return fmt.Errorf("AnalyticsReportRequestsFilter.IsValid: AccessType %s is unknown", f.AccessType)
+	}
+	return validateAnalyticsLimit("AnalyticsReportRequestsFilter", f.Limit)
+}
+
+//NewAnalyticsReportRequestsFilter Create new analytics report requests filter
+func NewAnalyticsReportRequestsFilter() *AnalyticsReportRequestsFilter {
+	return &AnalyticsReportRequestsFilter{}
+}
+
+//AnalyticsReportsFilter analytics reports filter
+type AnalyticsReportsFilter struct {
+	Category AnalyticsReportCategory //Possible values: APP_STORE_ENGAGEMENT, APP_STORE_COMMERCE, APP_USAGE, FRAMEWORK_USAGE, PERFORMANCE
+	Name     string                  //Name of report, e.g. App Store Discovery and Engagement Detailed
+	Limit    int                     //Number of resources to return, maximum 200
+}
+
+//SetCategory Set report category
+func (f *AnalyticsReportsFilter) SetCategory(value AnalyticsReportCategory) *AnalyticsReportsFilter {
+	f.Category = value
+	return f
+}
+
+//SetName Set report name
+func (f *AnalyticsReportsFilter) SetName(value string) *AnalyticsReportsFilter {
+	f.Name = value
+	return f
+}
+
+//SetLimit Set number of resources to return
+func (f *AnalyticsReportsFilter) SetLimit(value int) *AnalyticsReportsFilter {
+	f.Limit = value
+	return f
+}
+
+//toQueryParamsMap Convert filter to query params
+func (f *AnalyticsReportsFilter) toQueryParamsMap() map[string]interface{} {
+	qs := make(map[string]interface{})
+	if f.Category != "" {
+		qs["filter[category]"] = string(f.Category)
+	}
+	if f.Name != "" {
+		qs["filter[name]"] = f.Name
+	}
+	if f.Limit > 0 {
+		qs["limit"] = f.Limit
+	}
+	return qs
+}
+
+//IsValid Validate analytics reports filter params
+func (f *AnalyticsReportsFilter) IsValid() error {
+	switch f.Category {
+	case "", AnalyticsReportCategoryAppStoreEngagement, AnalyticsReportCategoryAppStoreCommerce, AnalyticsReportCategoryAppUsage,
+		AnalyticsReportCategoryFrameworkUsage, AnalyticsReportCategoryPerformance:
+	default:
+		return fmt.Errorf("AnalyticsReportsFilter.IsValid: Category %s is unknown", f.Category)
+	}
+	return validateAnalyticsLimit("AnalyticsReportsFilter", f.Limit)
+}
+
+//NewAnalyticsReportsFilter Create new analytics reports filter
+func NewAnalyticsReportsFilter() *AnalyticsReportsFilter {
+	return &AnalyticsReportsFilter{}
+}
+
+//AnalyticsReportInstancesFilter analytics report instances filter
+type AnalyticsReportInstancesFilter struct {
+	Granularity    AnalyticsReportGranularity //Possible values: DAILY, WEEKLY, MONTHLY
+	ProcessingDate time.Time                  //Date when instance was processed, in YYYY-MM-DD format
+	Limit          int                        //Number of resources to return, maximum 200
+}
+
+//SetGranularity Set instance granularity
+func (f *AnalyticsReportInstancesFilter) SetGranularity(value AnalyticsReportGranularity) *AnalyticsReportInstancesFilter {
+	f.Granularity = value
+	return f
+}
+
+//SetProcessingDate Set processing date
+func (f *AnalyticsReportInstancesFilter) SetProcessingDate(value time.Time) *AnalyticsReportInstancesFilter {
+	f.ProcessingDate = value
+	return f
+}
+
+//SetLimit Set number of resources to return
+func (f *AnalyticsReportInstancesFilter) SetLimit(value int) *AnalyticsReportInstancesFilter {
+	f.Limit = value
+	return f
+}
+
+//Daily Change granularity to Daily
+func (f *AnalyticsReportInstancesFilter) Daily() *AnalyticsReportInstancesFilter {
+	return f.SetGranularity(AnalyticsReportGranularityDaily)
+}
+
+//Weekly Change granularity to Weekly
+func (f *AnalyticsReportInstancesFilter) Weekly() *AnalyticsReportInstancesFilter {
+	return f.SetGranularity(AnalyticsReportGranularityWeekly)
+}
+
+//Monthly Change granularity to Monthly
+func (f *AnalyticsReportInstancesFilter) Monthly() *AnalyticsReportInstancesFilter {
+	return f.SetGranularity(AnalyticsReportGranularityMonthly)
+}
+
+//toQueryParamsMap Convert filter to query params
+func (f *AnalyticsReportInstancesFilter) toQueryParamsMap() map[string]interface{} {
+	qs := make(map[string]interface{})
+	if f.Granularity != "" {
+		qs["filter[granularity]"] = string(f.Granularity)
+	}
+	if !f.ProcessingDate.IsZero() {
+		qs["filter[processingDate]"] = f.ProcessingDate.Format(CustomDateFormatDefault)
+	}
+	if f.Limit > 0 {
+		qs["limit"] = f.Limit
+	}
+	return qs
+}
+
+//IsValid Validate analytics report instances filter params
+func (f *AnalyticsReportInstancesFilter) IsValid() error {
+	switch f.Granularity {
+	case "", AnalyticsReportGranularityDaily, AnalyticsReportGranularityWeekly, AnalyticsReportGranularityMonthly:
+	default:
+		return fmt.Errorf("AnalyticsReportInstancesFilter.IsValid: Granularity %s is unknown", f.Granularity)
+	}
+	return validateAnalyticsLimit("AnalyticsReportInstancesFilter", f.Limit)
+}
+
+//NewAnalyticsReportInstancesFilter Create new analytics report instances filter
+func NewAnalyticsReportInstancesFilter() *AnalyticsReportInstancesFilter {
+	return &AnalyticsReportInstancesFilter{}
+}
+
+//validateAnalyticsLimit Validate number of resources to return
+func validateAnalyticsLimit(filter string, limit int) error {
+	if limit < 0 || limit > ResourceLimitMax {
+		return fmt.Errorf("%s.IsValid: Limit must be between 1 and %d", filter, ResourceLimitMax)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package appstore
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type AnalyticsFiltersTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AnalyticsFiltersTestSuite) TestReportRequestsFilter() {
+	filter := NewAnalyticsReportRequestsFilter()
+	assert.Empty(suite.T(), filter.toQueryParamsMap())
+	filter.OneTimeSnapshot().SetLimit(10)
+	assert.NoError(suite.T(), filter.IsValid())
+	assert.Equal(suite.T(), map[string]interface{}{"filter[accessType]": "ONE_TIME_SNAPSHOT", "limit": 10}, filter.toQueryParamsMap())
+	filter.SetAccessType("FOO")
+	assert.Equal(suite.T(), "AnalyticsReportRequestsFilter.IsValid: AccessType FOO is unknown", filter.IsValid().Error())
+	filter.Ongoing().SetLimit(201)
+	assert.Equal(suite.T(), "AnalyticsReportRequestsFilter.IsValid: Limit must be between 1 and 200", filter.IsValid().Error())
+}
+
+func (suite *AnalyticsFiltersTestSuite) TestReportsFilter() {
+	filter := NewAnalyticsReportsFilter().SetCategory(AnalyticsReportCategoryAppStoreCommerce).SetName("App Downloads Standard")
+	assert.NoError(suite.T(), filter.IsValid())
+	assert.Equal(suite.T(), map[string]interface{}{"filter[category]": "APP_STORE_COMMERCE", "filter[name]": "App Downloads Standard"}, filter.toQueryParamsMap())
+	filter.SetLimit(-1)
+	assert.Error(suite.T(), filter.IsValid())
+}
+
+func (suite *AnalyticsFiltersTestSuite) TestReportInstancesFilter() {
+	filter := NewAnalyticsReportInstancesFilter().Weekly().SetProcessingDate(time.Date(2023, 5, 7, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), filter.IsValid())
+	assert.Equal(suite.T(), map[string]interface{}{"filter[granularity]": "WEEKLY", "filter[processingDate]": "2023-05-07"}, filter.toQueryParamsMap())
+	assert.Equal(suite.T(), AnalyticsReportGranularityMonthly, filter.Monthly().Granularity)
+	assert.Equal(suite.T(), AnalyticsReportGranularityDaily, filter.Daily().Granularity)
+	filter.SetGranularity("HOURLY")
+	assert.Equal(suite.T(), "AnalyticsReportInstancesFilter.IsValid: Granularity HOURLY is unknown", filter.IsValid().Error())
+}
+
+func TestAnalyticsFiltersTestSuite(t *testing.T) {
+	suite.Run(t, new(AnalyticsFiltersTestSuite))
+}
@@ -0,0 +1,205 @@
+package appstore
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type AnalyticsReportsResourceTestSuite struct {
+	suite.Suite
+	cfg      *Config
+	ctx      context.Context
+	testable *AnalyticsReportsResource
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) SetupTest() {
+	suite.cfg = buildStubConfig()
+	suite.ctx = context.Background()
+	suite.testable = buildStubAnalyticsReportsResource()
+	httpmock.Activate()
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) registerJson(method string, path string, status int, stub string) {
+	resp := buildStubResponseFromFile(status, stub)
+	resp.Header.Set("Content-Type", ResponseContentTypeJson)
+	httpmock.RegisterResponder(method, suite.cfg.Uri+path, httpmock.ResponderFromResponse(resp))
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestCreateReportRequest() {
+	var body map[string]interface{}
+	httpmock.RegisterResponder("POST", suite.cfg.Uri+"/v1/analyticsReportRequests", func(req *http.Request) (*http.Response, error) {
+		assert.Equal(suite.T(), RequestContentTypeJson, req.Header.Get("Content-Type"))
+		data, _ := ioutil.ReadAll(req.Body)
+		_ = json.Unmarshal(data, &body)
+		resp := buildStubResponseFromFile(http.StatusCreated, "stubs/analytics/report_request.json")
+		resp.Header.Set("Content-Type", ResponseContentTypeJson)
+		return resp, nil
+	})
+	result, resp, err := suite.testable.CreateReportRequest(suite.ctx, "1234567890", AnalyticsReportAccessTypeOngoing)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	assert.True(suite.T(), result.IsSuccess())
+	assert.Equal(suite.T(), "d1f2a3b4-0000-4000-8000-000000000001", result.Data.Id)
+	assert.Equal(suite.T(), AnalyticsReportAccessTypeOngoing, result.Data.Attributes.AccessType)
+	assert.False(suite.T(), result.Data.Attributes.StoppedDueToInactivity)
+	expected := `{"data":{"attributes":{"accessType":"ONGOING"},"relationships":{"app":{"data":{"id":"1234567890","type":"apps"}}},"type":"analyticsReportRequests"}}`
+	data, _ := json.Marshal(body)
+	assert.Equal(suite.T(), expected, string(data))
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestCreateReportRequestInvalid() {
+	_, _, err := suite.testable.CreateReportRequest(suite.ctx, "", AnalyticsReportAccessTypeOngoing)
+	assert.Error(suite.T(), err)
+	_, _, err = suite.testable.CreateReportRequest(suite.ctx, "1234567890", "")
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "AnalyticsReportsResource.CreateReportRequest: AccessType  is unknown", err.Error())
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestGetReportRequests() {
+	suite.registerJson("GET", "/v1/apps/1234567890/analyticsReportRequests", http.StatusOK, "stubs/analytics/report_requests.json")
+	result, _, err := suite.testable.GetReportRequests(suite.ctx, "1234567890", NewAnalyticsReportRequestsFilter().Ongoing())
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Data, 1)
+	assert.Equal(suite.T(), AnalyticsResourceTypeReportRequests, result.Data[0].Type)
+	assert.Equal(suite.T(), "https://api.appstoreconnect.apple.com/v1/analyticsReportRequests/d1f2a3b4-0000-4000-8000-000000000001", result.Data[0].Links.Self)
+	info := httpmock.GetCallCountInfo()
+	assert.Equal(suite.T(), 1, info["GET "+suite.cfg.Uri+"/v1/apps/1234567890/analyticsReportRequests"])
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestGetReportRequest() {
+	suite.registerJson("GET", "/v1/analyticsReportRequests/d1f2a3b4-0000-4000-8000-000000000001", http.StatusOK, "stubs/analytics/report_request.json")
+	result, _, err := suite.testable.GetReportRequest(suite.ctx, "d1f2a3b4-0000-4000-8000-000000000001")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), AnalyticsReportAccessTypeOngoing, result.Data.Attributes.AccessType)
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestDeleteReportRequest() {
+	httpmock.RegisterResponder("DELETE", suite.cfg.Uri+"/v1/analyticsReportRequests/foo", httpmock.NewStringResponder(http.StatusNoContent, ""))
+	resp, err := suite.testable.DeleteReportRequest(suite.ctx, "foo")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNoContent, resp.StatusCode)
+	suite.registerJson("DELETE", "/v1/analyticsReportRequests/bar", http.StatusNotFound, "stubs/errors/invalid.parameter.json")
+	resp, err = suite.testable.DeleteReportRequest(suite.ctx, "bar")
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestGetReports() {
+	suite.registerJson("GET", "/v1/analyticsReportRequests/d1f2a3b4-0000-4000-8000-000000000001/reports", http.StatusOK, "stubs/analytics/reports.json")
+	filter := NewAnalyticsReportsFilter().SetCategory(AnalyticsReportCategoryAppUsage).SetLimit(2)
+	result, _, err := suite.testable.GetReports(suite.ctx, "d1f2a3b4-0000-4000-8000-000000000001", filter)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Data, 2)
+	assert.Equal(suite.T(), "App Sessions Standard", result.Data[0].Attributes.Name)
+	assert.Equal(suite.T(), AnalyticsReportCategoryAppUsage, result.Data[1].Attributes.Category)
+	assert.Contains(suite.T(), result.Links.Next, "cursor=Ag.AKM")
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestGetReportsInvalidFilter() {
+	_, resp, err := suite.testable.GetReports(suite.ctx, "foo", NewAnalyticsReportsFilter().SetCategory("FOO"))
+	assert.Nil(suite.T(), resp)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "AnalyticsReportsResource.GetReports invalid filter: AnalyticsReportsFilter.IsValid: Category FOO is unknown", err.Error())
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestGetReportsError() {
+	suite.registerJson("GET", "/v1/analyticsReportRequests/foo/reports", http.StatusBadRequest, "stubs/errors/invalid.parameter.json")
+	result, resp, err := suite.testable.GetReports(suite.ctx, "foo", nil)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	assert.False(suite.T(), result.IsSuccess())
+	assert.Equal(suite.T(), "PARAMETER_ERROR.INVALID", result.Errors[0].Code)
+	assert.Equal(suite.T(), "AnalyticsReportsResource.GetReports error: "+result.GetError(), err.Error())
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestGetInstances() {
+	suite.registerJson("GET", "/v1/analyticsReports/r-app-sessions-standard/instances", http.StatusOK, "stubs/analytics/instances.json")
+	filter := NewAnalyticsReportInstancesFilter().Daily().SetProcessingDate(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC))
+	result, _, err := suite.testable.GetInstances(suite.ctx, "r-app-sessions-standard", filter)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Data, 1)
+	assert.Equal(suite.T(), AnalyticsReportGranularityDaily, result.Data[0].Attributes.Granularity)
+	assert.Equal(suite.T(), "2023-05-01", result.Data[0].Attributes.ProcessingDate.Value().Format(CustomDateFormatDefault))
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestGetSegments() {
+	suite.registerJson("GET", "/v1/analyticsReportInstances/i-2023-05-01-daily/segments", http.StatusOK, "stubs/analytics/segments.json")
+	result, _, err := suite.testable.GetSegments(suite.ctx, "i-2023-05-01-daily")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Data, 1)
+	assert.Equal(suite.T(), int64(221), result.Data[0].Attributes.SizeInBytes)
+	assert.Equal(suite.T(), "abc990431becc19998fed67b20ba4d46", result.Data[0].Attributes.Checksum)
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestDownloadSegment() {
+	segment := suite.loadSegment()
+	gzipped, _ := ioutil.ReadFile("stubs/analytics/sessions.tsv.gz")
+	httpmock.RegisterResponder("GET", "https://analytics-segments.example.com/s-2023-05-01-1.tsv.gz", func(req *http.Request) (*http.Response, error) {
+		assert.Empty(suite.T(), req.Header.Get("Authorization"))
+		assert.Equal(suite.T(), "foo", req.URL.Query().Get("signature"))
+		assert.Equal(suite.T(), "identity", req.Header.Get("Accept-Encoding"))
+		return httpmock.NewBytesResponse(http.StatusOK, gzipped), nil
+	})
+	data, err := suite.testable.DownloadSegment(suite.ctx, segment)
+	assert.NoError(suite.T(), err)
+	expected, _ := ioutil.ReadFile("stubs/analytics/sessions.tsv")
+	assert.Equal(suite.T(), expected, data)
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestDownloadSegmentChecksumMismatch() {
+	segment := suite.loadSegment()
+	segment.Attributes.SizeInBytes = 0
+	httpmock.RegisterResponder("GET", "https://analytics-segments.example.com/s-2023-05-01-1.tsv.gz", httpmock.NewBytesResponder(http.StatusOK, []byte("foo")))
+	_, err := suite.testable.DownloadSegment(suite.ctx, segment)
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "does not match expected abc990431becc19998fed67b20ba4d46")
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestDownloadSegmentSizeMismatch() {
+	segment := suite.loadSegment()
+	httpmock.RegisterResponder("GET", "https://analytics-segments.example.com/s-2023-05-01-1.tsv.gz", httpmock.NewBytesResponder(http.StatusOK, []byte("foo")))
+	_, err := suite.testable.DownloadSegment(suite.ctx, segment)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "AnalyticsReportsResource.DownloadSegment: size 3 does not match expected 221", err.Error())
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestDownloadSegmentNotGzipped() {
+	segment := suite.loadSegment()
+	segment.Attributes.SizeInBytes = 0
+	segment.Attributes.Checksum = ""
+	httpmock.RegisterResponder("GET", "https://analytics-segments.example.com/s-2023-05-01-1.tsv.gz", httpmock.NewBytesResponder(http.StatusOK, []byte("foo")))
+	_, err := suite.testable.DownloadSegment(suite.ctx, segment)
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "AnalyticsReportsResource.DownloadSegment decompress")
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) TestDownloadSegmentError() {
+	httpmock.RegisterResponder("GET", "https://analytics-segments.example.com/s-2023-05-01-1.tsv.gz", httpmock.NewStringResponder(http.StatusForbidden, "AccessDenied"))
+	_, err := suite.testable.DownloadSegment(suite.ctx, suite.loadSegment())
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "AnalyticsReportsResource.DownloadSegment: unexpected status 403", err.Error())
+	_, err = suite.testable.DownloadSegment(suite.ctx, &AnalyticsReportSegment{})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *AnalyticsReportsResourceTestSuite) loadSegment() *AnalyticsReportSegment {
+	data, _ := ioutil.ReadFile("stubs/analytics/segments.json")
+	result := &AnalyticsReportSegmentsResponse{}
+	_ = json.Unmarshal(data, result)
+	return result.Data[0]
+}
+
+func TestAnalyticsReportsResourceTestSuite(t *testing.T) {
+	suite.Run(t, new(AnalyticsReportsResourceTestSuite))
+}
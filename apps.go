@@ -0,0 +1,452 @@
+package appstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	//AppsResourceTypeApps const
+	AppsResourceTypeApps = "apps"
+	//AppsResourceTypeAppInfos const
+	AppsResourceTypeAppInfos = "appInfos"
+	//AppsResourceTypeAppInfoLocalizations const
+	AppsResourceTypeAppInfoLocalizations = "appInfoLocalizations"
+	//AppsResourceTypeAppStoreVersions const
+	AppsResourceTypeAppStoreVersions = "appStoreVersions"
+	//AppsResourceTypePreReleaseVersions const
+	AppsResourceTypePreReleaseVersions = "preReleaseVersions"
+)
+
+//App App of App Store Connect account
+// .see https://developer.apple.com/documentation/appstoreconnectapi/app
+type App struct {
+	Type          string            `json:"type"`
+	Id            string            `json:"id"` //Apple identifier of app, Apple Identifier column of reports
+	Attributes    *AppAttributes    `json:"attributes,omitempty"`
+	Relationships *AppRelationships `json:"relationships,omitempty"`
+	Links         *ResourceLinks    `json:"links,omitempty"`
+}
+
+//AppAttributes Attributes of app
+type AppAttributes struct {
+	Name                     string `json:"name"`
+	BundleId                 string `json:"bundleId"`
+	Sku                      string `json:"sku"` //SKU column of reports
+	PrimaryLocale            string `json:"primaryLocale"`
+	IsOrEverWasMadeForKids   bool   `json:"isOrEverWasMadeForKids"`
+	SubscriptionStatusUrl    string `json:"subscriptionStatusUrl,omitempty"`
+	ContentRightsDeclaration string `json:"contentRightsDeclaration,omitempty"` //DOES_NOT_USE_THIRD_PARTY_CONTENT or USES_THIRD_PARTY_CONTENT
+}
+
+//AppRelationships Relationships of app
+type AppRelationships struct {
+	AppInfos           *Relationship `json:"appInfos,omitempty"`
+	AppStoreVersions   *Relationship `json:"appStoreVersions,omitempty"`
+	PreReleaseVersions *Relationship `json:"preReleaseVersions,omitempty"`
+}
+
+//AppleIdentifier Get Apple identifier of app as number, 0 when id is not numeric
+func (a *App) AppleIdentifier() int {
+	id, _ := strconv.Atoi(a.Id)
+	return id
+}
+
+//AppInfo App information, e.g. age rating and localizations, one per app state
+// .see https://developer.apple.com/documentation/appstoreconnectapi/appinfo
+type AppInfo struct {
+	Type          string                `json:"type"`
+	Id            string                `json:"id"`
+	Attributes    *AppInfoAttributes    `json:"attributes,omitempty"`
+	Relationships *AppInfoRelationships `json:"relationships,omitempty"`
+	Links         *ResourceLinks        `json:"links,omitempty"`
+}
+
+//AppInfoAttributes Attributes of app info
+type AppInfoAttributes struct {
+	AppStoreState     string `json:"appStoreState"`
+	State             string `json:"state,omitempty"`
+	AppStoreAgeRating string `json:"appStoreAgeRating,omitempty"`
+	KidsAgeBand       string `json:"kidsAgeBand,omitempty"`
+}
+
+//AppInfoRelationships Relationships of app info
+type AppInfoRelationships struct {
+	App                  *Relationship `json:"app,omitempty"`
+	AppInfoLocalizations *Relationship `json:"appInfoLocalizations,omitempty"`
+}
+
+//AppInfoLocalization Localized app information
+// .see https://developer.apple.com/documentation/appstoreconnectapi/appinfolocalization
+type AppInfoLocalization struct {
+	Type          string                            `json:"type"`
+	Id            string                            `json:"id"`
+	Attributes    *AppInfoLocalizationAttributes    `json:"attributes,omitempty"`
+	Relationships *AppInfoLocalizationRelationships `json:"relationships,omitempty"`
+	Links         *ResourceLinks                    `json:"links,omitempty"`
+}
+
+//AppInfoLocalizationAttributes Attributes of app info localization
+type AppInfoLocalizationAttributes struct {
+	Locale            string `json:"locale"`
+	Name              string `json:"name"`
+	Subtitle          string `json:"subtitle,omitempty"`
+	PrivacyPolicyUrl  string `json:"privacyPolicyUrl,omitempty"`
+	PrivacyChoicesUrl string `json:"privacyChoicesUrl,omitempty"`
+	PrivacyPolicyText string `json:"privacyPolicyText,omitempty"`
+}
+
+//AppInfoLocalizationRelationships Relationships of app info localization
+type AppInfoLocalizationRelationships struct {
+	AppInfo *Relationship `json:"appInfo,omitempty"`
+}
+
+//AppInfoLocalizationUpdate Changed attributes of app info localization, nil fields are not changed
+type AppInfoLocalizationUpdate struct {
+	Name              *string `json:"name,omitempty"`
+	Subtitle          *string `json:"subtitle,omitempty"`
+	PrivacyPolicyUrl  *string `json:"privacyPolicyUrl,omitempty"`
+	PrivacyChoicesUrl *string `json:"privacyChoicesUrl,omitempty"`
+	PrivacyPolicyText *string `json:"privacyPolicyText,omitempty"`
+}
+
+//AppStoreVersion Version of app on App Store
+// .see https://developer.apple.com/documentation/appstoreconnectapi/appstoreversion
+type AppStoreVersion struct {
+	Type       string                     `json:"type"`
+	Id         string                     `json:"id"`
+	Attributes *AppStoreVersionAttributes `json:"attributes,omitempty"`
+	Links      *ResourceLinks             `json:"links,omitempty"`
+}
+
+//AppStoreVersionAttributes Attributes of App Store version
+type AppStoreVersionAttributes struct {
+	Platform            string `json:"platform"` //IOS, MAC_OS, TV_OS or VISION_OS
+	VersionString       string `json:"versionString"`
+	AppStoreState       string `json:"appStoreState"`
+	AppVersionState     string `json:"appVersionState,omitempty"`
+	Copyright           string `json:"copyright,omitempty"`
+	ReleaseType         string `json:"releaseType,omitempty"`
+	EarliestReleaseDate string `json:"earliestReleaseDate,omitempty"`
+	CreatedDate         string `json:"createdDate,omitempty"`
+}
+
+//PreReleaseVersion Version of app available for beta testing
+// .see https://developer.apple.com/documentation/appstoreconnectapi/prereleaseversion
+type PreReleaseVersion struct {
+	Type       string                       `json:"type"`
+	Id         string                       `json:"id"`
+	Attributes *PreReleaseVersionAttributes `json:"attributes,omitempty"`
+	Links      *ResourceLinks               `json:"links,omitempty"`
+}
+
+//PreReleaseVersionAttributes Attributes of pre-release version
+type PreReleaseVersionAttributes struct {
+	Version  string `json:"version"`
+	Platform string `json:"platform"`
+}
+
+//AppsResponse struct
+type AppsResponse struct {
+	Document
+	Data []*App `json:"data,omitempty"`
+}
+
+//AppResponse struct, related resources are included by query
+type AppResponse struct {
+	Document
+	Data *App `json:"data,omitempty"`
+}
+
+//AppInfos Get included app infos of app
+func (r *AppResponse) AppInfos() ([]*AppInfo, error) {
+	infos := make([]*AppInfo, 0)
+	if r.Data == nil || r.Data.Relationships == nil {
+		return infos, nil
+	}
+	return infos, r.DecodeIncluded(r.Data.Relationships.AppInfos, &infos)
+}
+
+//AppStoreVersions Get included App Store versions of app
+func (r *AppResponse) AppStoreVersions() ([]*AppStoreVersion, error) {
+	versions := make([]*AppStoreVersion, 0)
+	if r.Data == nil || r.Data.Relationships == nil {
+		return versions, nil
+	}
+	return versions, r.DecodeIncluded(r.Data.Relationships.AppStoreVersions, &versions)
+}
+
+//PreReleaseVersions Get included pre-release versions of app
+func (r *AppResponse) PreReleaseVersions() ([]*PreReleaseVersion, error) {
+	versions := make([]*PreReleaseVersion, 0)
+	if r.Data == nil || r.Data.Relationships == nil {
+		return versions, nil
+	}
+	return versions, r.DecodeIncluded(r.Data.Relationships.PreReleaseVersions, &versions)
+}
+
+//AppInfosResponse struct
+type AppInfosResponse struct {
+	Document
+	Data []*AppInfo `json:"data,omitempty"`
+}
+
+//AppInfoResponse struct, localizations are included by query
+type AppInfoResponse struct {
+	Document
+	Data *AppInfo `json:"data,omitempty"`
+}
+
+//AppInfoLocalizations Get included localizations of app info
+func (r *AppInfoResponse) AppInfoLocalizations() ([]*AppInfoLocalization, error) {
+	localizations := make([]*AppInfoLocalization, 0)
+	if r.Data == nil || r.Data.Relationships == nil {
+		return localizations, nil
+	}
+	return localizations, r.DecodeIncluded(r.Data.Relationships.AppInfoLocalizations, &localizations)
+}
+
+//AppInfoLocalizationsResponse struct
+type AppInfoLocalizationsResponse struct {
+	Document
+	Data []*AppInfoLocalization `json:"data,omitempty"`
+}
+
+//AppInfoLocalizationResponse struct
+type AppInfoLocalizationResponse struct {
+	Document
+	Data *AppInfoLocalization `json:"data,omitempty"`
+}
+
+//AppStoreVersionsResponse struct
+type AppStoreVersionsResponse struct {
+	Document
+	Data []*AppStoreVersion `json:"data,omitempty"`
+}
+
+//PreReleaseVersionsResponse struct
+type PreReleaseVersionsResponse struct {
+	Document
+	Data []*PreReleaseVersion `json:"data,omitempty"`
+}
+
+//AppIndex Lookup of apps by Apple identifier and SKU, joins report rows to app metadata
+type AppIndex struct {
+	byId  map[int]*App
+	bySku map[string]*App
+}
+
+//Add Add apps to index
+func (i *AppIndex) Add(apps ...*App) *AppIndex {
+	for _, app := range apps {
+		if id := app.AppleIdentifier(); id != 0 {
+			i.byId[id] = app
+		}
+		if app.Attributes != nil && app.Attributes.Sku != "" {
+			i.bySku[app.Attributes.Sku] = app
+		}
+	}
+	return i
+}
+
+//ByAppleIdentifier Find app by Apple Identifier column of report
+func (i *AppIndex) ByAppleIdentifier(id int) (*App, bool) {
+	app, ok := i.byId[id]
+	return app, ok
+}
+
+//BySku Find app by SKU column of report
+func (i *AppIndex) BySku(sku string) (*App, bool) {
+	app, ok := i.bySku[sku]
+	return app, ok
+}
+
+//Lookup Find app by Apple identifier, by SKU when identifier is unknown
+func (i *AppIndex) Lookup(appleIdentifier int, sku string) (*App, bool) {
+	if app, ok := i.ByAppleIdentifier(appleIdentifier); ok {
+		return app, true
+	}
+	return i.BySku(sku)
+}
+
+//NewAppIndex Create new index of apps
+func NewAppIndex(apps ...*App) *AppIndex {
+	index := &AppIndex{byId: make(map[int]*App), bySku: make(map[string]*App)}
+	return index.Add(apps...)
+}
+
+//AppsResource Apps resource
+type AppsResource struct {
+	ResourceAbstract
+}
+
+//GetApps Get apps by filter, query sets included relationships, fields, limit and sort
+func (ar *AppsResource) GetApps(ctx context.Context, filter *AppsFilter, query *ResourceQuery) (*AppsResponse, *http.Response, error) {
+	params, err := ar.buildAppsQueryParams(filter, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("AppsResource.GetApps invalid filter: %v", err)
+	}
+	result := &AppsResponse{}
+	resp, err := ar.send(ctx, http.MethodGet, "v1/apps", params, nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AppsResource.GetApps error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//IterateApps Iterate pages of apps by filter, pages are *AppsResponse, invalid filter is reported by iterator error
+func (ar *AppsResource) IterateApps(filter *AppsFilter, query *ResourceQuery) *PageIterator {
+	params, err := ar.buildAppsQueryParams(filter, query)
+	iterator := ar.newPageIterator("v1/apps", params)
+	if err != nil {
+		iterator.err = fmt.Errorf("AppsResource.IterateApps invalid filter: %v", err)
+	}
+	return iterator
+}
+
+//GetApp Get app by Apple identifier, e.g. with query including appInfos, appStoreVersions and preReleaseVersions
+func (ar *AppsResource) GetApp(ctx context.Context, id string, query *ResourceQuery) (*AppResponse, *http.Response, error) {
+	params, err := ar.buildQueryParams(query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("AppsResource.GetApp invalid query: %v", err)
+	}
+	result := &AppResponse{}
+	resp, err := ar.send(ctx, http.MethodGet, "v1/apps/"+id, params, nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AppsResource.GetApp error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//GetAppInfos Get app infos of app
+func (ar *AppsResource) GetAppInfos(ctx context.Context, appId string, query *ResourceQuery) (*AppInfosResponse, *http.Response, error) {
+	params, err := ar.buildQueryParams(query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("AppsResource.GetAppInfos invalid query: %v", err)
+	}
+	result := &AppInfosResponse{}
+	resp, err := ar.send(ctx, http.MethodGet, "v1/apps/"+appId+"/appInfos", params, nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AppsResource.GetAppInfos error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//GetAppInfo Get app info by id, e.g. with query including appInfoLocalizations
+func (ar *AppsResource) GetAppInfo(ctx context.Context, id string, query *ResourceQuery) (*AppInfoResponse, *http.Response, error) {
+	params, err := ar.buildQueryParams(query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("AppsResource.GetAppInfo invalid query: %v", err)
+	}
+	result := &AppInfoResponse{}
+	resp, err := ar.send(ctx, http.MethodGet, "v1/appInfos/"+id, params, nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AppsResource.GetAppInfo error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//GetAppInfoLocalizations Get localizations of app info, e.g. filtered by locale
+func (ar *AppsResource) GetAppInfoLocalizations(ctx context.Context, appInfoId string, query *ResourceQuery) (*AppInfoLocalizationsResponse, *http.Response, error) {
+	params, err := ar.buildQueryParams(query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("AppsResource.GetAppInfoLocalizations invalid query: %v", err)
+	}
+	result := &AppInfoLocalizationsResponse{}
+	resp, err := ar.send(ctx, http.MethodGet, "v1/appInfos/"+appInfoId+"/appInfoLocalizations", params, nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AppsResource.GetAppInfoLocalizations error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//GetAppInfoLocalization Get app info localization by id
+func (ar *AppsResource) GetAppInfoLocalization(ctx context.Context, id string) (*AppInfoLocalizationResponse, *http.Response, error) {
+	result := &AppInfoLocalizationResponse{}
+	resp, err := ar.send(ctx, http.MethodGet, "v1/appInfoLocalizations/"+id, nil, nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AppsResource.GetAppInfoLocalization error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//UpdateAppInfoLocalization Update app info localization, e.g. name or subtitle
+func (ar *AppsResource) UpdateAppInfoLocalization(ctx context.Context, id string, update *AppInfoLocalizationUpdate) (*AppInfoLocalizationResponse, *http.Response, error) {
+	if id == "" {
+		return nil, nil, fmt.Errorf("AppsResource.UpdateAppInfoLocalization: %v", "id is required")
+	}
+	attributes := make(map[string]interface{})
+	if update != nil {
+		data, err := json.Marshal(update)
+		if err != nil {
+			return nil, nil, fmt.Errorf("AppsResource.UpdateAppInfoLocalization encode: %v", err)
+		}
+		if err = json.Unmarshal(data, &attributes); err != nil {
+			return nil, nil, fmt.Errorf("AppsResource.UpdateAppInfoLocalization encode: %v", err)
+		}
+	}
+	body := NewResourceRequestBody(AppsResourceTypeAppInfoLocalizations, id, attributes, nil)
+	result := &AppInfoLocalizationResponse{}
+	resp, err := ar.send(ctx, http.MethodPatch, "v1/appInfoLocalizations/"+id, nil, body, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AppsResource.UpdateAppInfoLocalization error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//GetAppStoreVersions Get App Store versions of app, e.g. filtered by platform or versionString
+func (ar *AppsResource) GetAppStoreVersions(ctx context.Context, appId string, query *ResourceQuery) (*AppStoreVersionsResponse, *http.Response, error) {
+	params, err := ar.buildQueryParams(query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("AppsResource.GetAppStoreVersions invalid query: %v", err)
+	}
+	result := &AppStoreVersionsResponse{}
+	resp, err := ar.send(ctx, http.MethodGet, "v1/apps/"+appId+"/appStoreVersions", params, nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AppsResource.GetAppStoreVersions error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//GetPreReleaseVersions Get pre-release versions of app
+func (ar *AppsResource) GetPreReleaseVersions(ctx context.Context, appId string, query *ResourceQuery) (*PreReleaseVersionsResponse, *http.Response, error) {
+	params, err := ar.buildQueryParams(query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("AppsResource.GetPreReleaseVersions invalid query: %v", err)
+	}
+	result := &PreReleaseVersionsResponse{}
+	resp, err := ar.send(ctx, http.MethodGet, "v1/apps/"+appId+"/preReleaseVersions", params, nil, result)
+	if err != nil {
+		return result, resp, fmt.Errorf("AppsResource.GetPreReleaseVersions error: %v", err)
+	}
+	return result, resp, nil
+}
+
+//buildAppsQueryParams
+func (ar *AppsResource) buildAppsQueryParams(filter *AppsFilter, query *ResourceQuery) (map[string]interface{}, error) {
+	if filter == nil {
+		filter = NewAppsFilter()
+	}
+	if err := filter.IsValid(); err != nil {
+		return nil, err
+	}
+	if query != nil {
+		if err := query.IsValid(); err != nil {
+			return nil, err
+		}
+	}
+	return query.merge(filter.toQueryParamsMap()), nil
+}
+
+//buildQueryParams
+func (ar *AppsResource) buildQueryParams(query *ResourceQuery) (map[string]interface{}, error) {
+	if query != nil {
+		if err := query.IsValid(); err != nil {
+			return nil, err
+		}
+	}
+	return query.merge(nil), nil
+}
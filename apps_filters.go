@@ -0,0 +1,78 @@
+package appstore
+
+import (
+	"fmt"
+	"strings"
+)
+
+//AppsFilter apps filter, every field matches any of its values
+type AppsFilter struct {
+	Ids       []string //Apple identifiers of apps
+	BundleIds []string //Bundle identifiers, e.g. foo.bar.baz
+	Skus      []string //SKUs provided during app setup
+	Names     []string //App names
+}
+
+//SetIds Set apple identifiers
+func (f *AppsFilter) SetIds(values ...string) *AppsFilter {
+	f.Ids = values
+	return f
+}
+
+//SetBundleIds Set bundle identifiers
+func (f *AppsFilter) SetBundleIds(values ...string) *AppsFilter {
+	f.BundleIds = values
+	return f
+}
+
+//SetSkus Set SKUs
+func (f *AppsFilter) SetSkus(values ...string) *AppsFilter {
+	f.Skus = values
+	return f
+}
+
+//SetNames Set app names
+func (f *AppsFilter) SetNames(values ...string) *AppsFilter {
+	f.Names = values
+	return f
+}
+
+//toQueryParamsMap Convert filter to query params
+func (f *AppsFilter) toQueryParamsMap() map[string]interface{} {
+	qs := make(map[string]interface{})
+	for param, values := range map[string][]string{
+		"filter[id]":       f.Ids,
+		"filter[bundleId]": f.BundleIds,
+		"filter[sku]":      f.Skus,
+		"filter[name]":     f.Names,
+	} {
+		if len(values) > 0 {
+			qs[param] = strings.Join(values, ",")
+		}
+	}
+	return qs
+}
+
+//IsValid Validate apps filter params
+func (f *AppsFilter) IsValid() error {
+	fields := []struct {
+		name   string
+		values []string
+	}{{"Ids", f.Ids}, {"BundleIds", f.BundleIds}, {"Skus", f.Skus}, {"Names", f.Names}}
+	for _, field := range fields {
+		for _, value := range field.values {
+			if strings.TrimSpace(value) == "" {
+				return fmt.Errorf("AppsFilter.IsValid: %s must not contain empty values", field.name)
+			}
+			if strings.Contains(value, ",") {
+				return fmt.Errorf("AppsFilter.IsValid: %s value %s must not contain comma", field.name, value)
+			}
+		}
+	}
+	return nil
+}
+
+//NewAppsFilter Create new apps filter
+func NewAppsFilter() *AppsFilter {
+	return &AppsFilter{}
+}
@@ -0,0 +1,29 @@
+package appstore
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type AppsFilterTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AppsFilterTestSuite) TestToQueryParamsMap() {
+	filter := NewAppsFilter()
+	assert.Empty(suite.T(), filter.toQueryParamsMap())
+	filter.SetIds("1", "2").SetBundleIds("foo.bar").SetSkus("FOO").SetNames("Foo Bar")
+	expected := map[string]interface{}{"filter[id]": "1,2", "filter[bundleId]": "foo.bar", "filter[sku]": "FOO", "filter[name]": "Foo Bar"}
+	assert.Equal(suite.T(), expected, filter.toQueryParamsMap())
+	assert.NoError(suite.T(), filter.IsValid())
+}
+
+func (suite *AppsFilterTestSuite) TestIsValid() {
+	assert.Equal(suite.T(), "AppsFilter.IsValid: Ids must not contain empty values", NewAppsFilter().SetIds(" ").IsValid().Error())
+	assert.Equal(suite.T(), "AppsFilter.IsValid: BundleIds value a,b must not contain comma", NewAppsFilter().SetBundleIds("a,b").IsValid().Error())
+}
+
+func TestAppsFilterTestSuite(t *testing.T) {
+	suite.Run(t, new(AppsFilterTestSuite))
+}
@@ -0,0 +1,207 @@
+package appstore
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+type AppsResourceTestSuite struct {
+	suite.Suite
+	cfg      *Config
+	ctx      context.Context
+	testable *AppsResource
+}
+
+func (suite *AppsResourceTestSuite) SetupTest() {
+	suite.cfg = buildStubConfig()
+	suite.ctx = context.Background()
+	suite.testable = buildStubAppsResource()
+	httpmock.Activate()
+}
+
+func (suite *AppsResourceTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+}
+
+func (suite *AppsResourceTestSuite) registerJson(method string, path string, query string, status int, stub string) {
+	resp := buildStubResponseFromFile(status, stub)
+	resp.Header.Set("Content-Type", ResponseContentTypeJson)
+	httpmock.RegisterResponderWithQuery(method, suite.cfg.Uri+path, query, httpmock.ResponderFromResponse(resp))
+}
+
+func (suite *AppsResourceTestSuite) TestGetApps() {
+	suite.registerJson("GET", "/v1/apps", "fields%5Bapps%5D=name%2CbundleId%2Csku&filter%5BbundleId%5D=foo.bar.baz%2Cbar.baz.foo&sort=name", http.StatusOK, "stubs/apps/apps.json")
+	filter := NewAppsFilter().SetBundleIds("foo.bar.baz", "bar.baz.foo")
+	query := NewResourceQuery().Fields("apps", "name", "bundleId", "sku").Sort("name")
+	result, resp, err := suite.testable.GetApps(suite.ctx, filter, query)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	assert.Equal(suite.T(), 2, result.Total())
+	assert.Len(suite.T(), result.Data, 2)
+	assert.Equal(suite.T(), AppsResourceTypeApps, result.Data[0].Type)
+	assert.Equal(suite.T(), 1234567890, result.Data[0].AppleIdentifier())
+	assert.Equal(suite.T(), "FooBarApp", result.Data[0].Attributes.Name)
+	assert.Equal(suite.T(), "DOES_NOT_USE_THIRD_PARTY_CONTENT", result.Data[0].Attributes.ContentRightsDeclaration)
+	assert.True(suite.T(), result.Data[1].Attributes.IsOrEverWasMadeForKids)
+	assert.NotEmpty(suite.T(), result.Data[0].Relationships.AppInfos.Links.Related)
+}
+
+func (suite *AppsResourceTestSuite) TestGetAppsInvalidFilter() {
+	_, resp, err := suite.testable.GetApps(suite.ctx, NewAppsFilter().SetSkus("foo,bar"), nil)
+	assert.Nil(suite.T(), resp)
+	assert.Equal(suite.T(), "AppsResource.GetApps invalid filter: AppsFilter.IsValid: Skus value foo,bar must not contain comma", err.Error())
+	_, _, err = suite.testable.GetApps(suite.ctx, nil, NewResourceQuery().Limit(500))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *AppsResourceTestSuite) TestIterateApps() {
+	suite.registerJson("GET", "/v1/apps", "filter%5Bsku%5D=BARBAZFOO", http.StatusOK, "stubs/apps/apps.json")
+	iterator := suite.testable.IterateApps(NewAppsFilter().SetSkus("BARBAZFOO"), nil)
+	page := &AppsResponse{}
+	pages := 0
+	for iterator.Next(suite.ctx, page) {
+		pages++
+	}
+	assert.NoError(suite.T(), iterator.Err())
+	assert.Equal(suite.T(), 1, pages)
+	iterator = suite.testable.IterateApps(NewAppsFilter().SetNames(""), nil)
+	assert.False(suite.T(), iterator.Next(suite.ctx, page))
+	assert.Equal(suite.T(), "AppsResource.IterateApps invalid filter: AppsFilter.IsValid: Names must not contain empty values", iterator.Err().Error())
+}
+
+func (suite *AppsResourceTestSuite) TestGetApp() {
+	suite.registerJson("GET", "/v1/apps/1234567890", "include=appInfos%2CappStoreVersions%2CpreReleaseVersions", http.StatusOK, "stubs/apps/app.json")
+	query := NewResourceQuery().Include("appInfos", "appStoreVersions", "preReleaseVersions")
+	result, _, err := suite.testable.GetApp(suite.ctx, "1234567890", query)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "foo.bar.baz", result.Data.Attributes.BundleId)
+	infos, err := result.AppInfos()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), infos, 1)
+	assert.Equal(suite.T(), "FOUR_PLUS", infos[0].Attributes.AppStoreAgeRating)
+	assert.NotEmpty(suite.T(), infos[0].Relationships.AppInfoLocalizations.Links.Related)
+	versions, err := result.AppStoreVersions()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), versions, 2)
+	assert.Equal(suite.T(), "1.2.0", versions[0].Attributes.VersionString)
+	assert.Equal(suite.T(), "PREPARE_FOR_SUBMISSION", versions[1].Attributes.AppStoreState)
+	preReleases, err := result.PreReleaseVersions()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1.3.0", preReleases[0].Attributes.Version)
+	empty, err := (&AppResponse{}).AppInfos()
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), empty)
+}
+
+func (suite *AppsResourceTestSuite) TestGetAppNotFound() {
+	suite.registerJson("GET", "/v1/apps/foo", "", http.StatusNotFound, "stubs/errors/invalid.parameter.json")
+	result, resp, err := suite.testable.GetApp(suite.ctx, "foo", nil)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	assert.False(suite.T(), result.IsSuccess())
+	assert.Nil(suite.T(), result.Data)
+}
+
+func (suite *AppsResourceTestSuite) TestGetAppInfo() {
+	suite.registerJson("GET", "/v1/appInfos/info-1", "include=appInfoLocalizations", http.StatusOK, "stubs/apps/app_info.json")
+	result, _, err := suite.testable.GetAppInfo(suite.ctx, "info-1", NewResourceQuery().Include("appInfoLocalizations"))
+	assert.NoError(suite.T(), err)
+	localizations, err := result.AppInfoLocalizations()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), localizations, 2)
+	assert.Equal(suite.T(), "Foo your bar", localizations[0].Attributes.Subtitle)
+	assert.Equal(suite.T(), "ru", localizations[1].Attributes.Locale)
+}
+
+func (suite *AppsResourceTestSuite) TestGetAppInfoLocalization() {
+	suite.registerJson("GET", "/v1/appInfoLocalizations/localization-en", "", http.StatusOK, "stubs/apps/app_info_localization.json")
+	result, _, err := suite.testable.GetAppInfoLocalization(suite.ctx, "localization-en")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "info-1", result.Data.Relationships.AppInfo.Identifier().Id)
+}
+
+func (suite *AppsResourceTestSuite) TestUpdateAppInfoLocalization() {
+	var body string
+	httpmock.RegisterResponder("PATCH", suite.cfg.Uri+"/v1/appInfoLocalizations/localization-en", func(req *http.Request) (*http.Response, error) {
+		data, _ := ioutil.ReadAll(req.Body)
+		body = string(data)
+		resp := buildStubResponseFromFile(http.StatusOK, "stubs/apps/app_info_localization.json")
+		resp.Header.Set("Content-Type", ResponseContentTypeJson)
+		return resp, nil
+	})
+	subtitle, privacyChoicesUrl := "Bar your foo", ""
+	update := &AppInfoLocalizationUpdate{Subtitle: &subtitle, PrivacyChoicesUrl: &privacyChoicesUrl}
+	result, _, err := suite.testable.UpdateAppInfoLocalization(suite.ctx, "localization-en", update)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Bar your foo", result.Data.Attributes.Subtitle)
+	expected := `{"data":{"attributes":{"privacyChoicesUrl":"","subtitle":"Bar your foo"},"id":"localization-en","type":"appInfoLocalizations"}}`
+	assert.Equal(suite.T(), expected, body)
+	_, _, err = suite.testable.UpdateAppInfoLocalization(suite.ctx, "", update)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *AppsResourceTestSuite) TestGetAppStoreVersions() {
+	body := `{"data":[{"type":"appStoreVersions","id":"1","attributes":{"platform":"IOS","versionString":"1.2.0","appStoreState":"READY_FOR_SALE"}}]}`
+	httpmock.RegisterResponderWithQuery("GET", suite.cfg.Uri+"/v1/apps/1234567890/appStoreVersions", "filter%5Bplatform%5D=IOS", httpmock.NewStringResponder(http.StatusOK, body))
+	versions, _, err := suite.testable.GetAppStoreVersions(suite.ctx, "1234567890", NewResourceQuery().Filter("platform", "IOS"))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1.2.0", versions.Data[0].Attributes.VersionString)
+}
+
+func (suite *AppsResourceTestSuite) TestGetPreReleaseVersions() {
+	body := `{"data":[{"type":"preReleaseVersions","id":"1","attributes":{"version":"1.3.0","platform":"IOS"}}]}`
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/v1/apps/1234567890/preReleaseVersions", httpmock.NewStringResponder(http.StatusOK, body))
+	versions, _, err := suite.testable.GetPreReleaseVersions(suite.ctx, "1234567890", nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1.3.0", versions.Data[0].Attributes.Version)
+}
+
+func (suite *AppsResourceTestSuite) TestGetAppInfos() {
+	body := `{"data":[{"type":"appInfos","id":"info-1","attributes":{"appStoreState":"READY_FOR_SALE"}}]}`
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/v1/apps/1234567890/appInfos", httpmock.NewStringResponder(http.StatusOK, body))
+	infos, _, err := suite.testable.GetAppInfos(suite.ctx, "1234567890", nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "info-1", infos.Data[0].Id)
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/v1/appInfos/info-1/appInfoLocalizations", httpmock.NewStringResponder(http.StatusOK, `{"data":[{"type":"appInfoLocalizations","id":"l-1","attributes":{"locale":"en-US","name":"Foo"}}]}`))
+	localizations, _, err := suite.testable.GetAppInfoLocalizations(suite.ctx, "info-1", nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "en-US", localizations.Data[0].Attributes.Locale)
+}
+
+func TestAppsResourceTestSuite(t *testing.T) {
+	suite.Run(t, new(AppsResourceTestSuite))
+}
+
+type AppIndexTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AppIndexTestSuite) TestLookup() {
+	data, _ := ioutil.ReadFile("stubs/apps/apps.json")
+	apps := &AppsResponse{}
+	_ = json.Unmarshal(data, apps)
+	index := NewAppIndex(apps.Data...)
+	reportData, _ := ioutil.ReadFile("stubs/reports/sales/sales.tsv")
+	reports := []*SalesReport{}
+	_ = UnmarshalCSV(reportData, &reports)
+	app, ok := index.Lookup(reports[0].AppleIdentifier.Value(), reports[0].SKU)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "FooBarApp", app.Attributes.Name)
+	app, ok = index.Lookup(0, "BARBAZFOO")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "1234567891", app.Id)
+	_, ok = index.Lookup(1, "foo")
+	assert.False(suite.T(), ok)
+	_, ok = index.BySku("")
+	assert.False(suite.T(), ok)
+}
+
+func TestAppIndexTestSuite(t *testing.T) {
+	suite.Run(t, new(AppIndexTestSuite))
+}
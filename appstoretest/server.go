@@ -0,0 +1,365 @@
+package appstoretest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	//SalesReportsPath const
+	SalesReportsPath = "/v1/salesReports"
+	//FinanceReportsPath const
+	FinanceReportsPath = "/v1/financeReports"
+)
+
+//Server fake App Store Connect API server for sales and finance reports
+type Server struct {
+	*httptest.Server
+	PublicKey *ecdsa.PublicKey
+	VendorNo  string
+	mu        sync.Mutex
+	reports   map[string][]byte
+	failures  map[string][]int
+}
+
+//AddSalesReport Seed sales report TSV data served for filter
+func (s *Server) AddSalesReport(filter appstore.SalesReportsFilterInterface, data []byte) *Server {
+	return s.addReport(SalesReportsPath, buildQuery(filter.ToQueryParamsMap()), data)
+}
+
+//AddFinancesReport Seed finances report TSV data served for filter
+func (s *Server) AddFinancesReport(filter *appstore.FinancesReportsFilter, data []byte) *Server {
+	return s.addReport(FinanceReportsPath, buildFinancesQuery(filter), data)
+}
+
+//SimulateStatus Respond with error status to the next requests of path, times < 1 means once
+func (s *Server) SimulateStatus(path string, status int, times int) *Server {
+	if times < 1 {
+		times = 1
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < times; i++ {
+		s.failures[path] = append(s.failures[path], status)
+	}
+	return s
+}
+
+//SimulateNotAvailable Respond with 404 report is not available yet
+func (s *Server) SimulateNotAvailable(path string, times int) *Server {
+	return s.SimulateStatus(path, http.StatusNotFound, times)
+}
+
+//SimulateRateLimit Respond with 429 rate limit exceeded
+func (s *Server) SimulateRateLimit(path string, times int) *Server {
+	return s.SimulateStatus(path, http.StatusTooManyRequests, times)
+}
+
+//SimulateServerError Respond with 500 unexpected error
+func (s *Server) SimulateServerError(path string, times int) *Server {
+	return s.SimulateStatus(path, http.StatusInternalServerError, times)
+}
+
+//addReport method
+func (s *Server) addReport(path string, query url.Values, data []byte) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports[path+"?"+query.Encode()] = data
+	return s
+}
+
+//nextFailure Pop simulated failure status for path
+func (s *Server) nextFailure(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := s.failures[path]
+	if len(statuses) == 0 {
+		return 0
+	}
+	s.failures[path] = statuses[1:]
+	return statuses[0]
+}
+
+//findReport method
+func (s *Server) findReport(path string, query url.Values) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.reports[path+"?"+query.Encode()]
+	return data, ok
+}
+
+//ServeHTTP implementation of http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != SalesReportsPath && r.URL.Path != FinanceReportsPath {
+		writeError(w, NewError(http.StatusNotFound, "NOT_FOUND", "The specified resource does not exist", "The path provided does not match a defined resource type.", ""))
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, NewError(http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "The request method is not valid for the resource path.", "The request method is not valid for the resource path.", ""))
+		return
+	}
+	if err := s.validateToken(r); err != nil {
+		writeError(w, NewError(http.StatusUnauthorized, "NOT_AUTHORIZED", "Authentication credentials are missing or invalid.", err.Error(), ""))
+		return
+	}
+	if status := s.nextFailure(r.URL.Path); status != 0 {
+		writeError(w, buildStatusError(status))
+		return
+	}
+	query := r.URL.Query()
+	if query.Get("filter[vendorNumber]") != s.VendorNo {
+		writeError(w, NewError(http.StatusForbidden, "FORBIDDEN_ERROR", "This request is forbidden for security reasons", "You are not authorized to access this vendor number.", "filter[vendorNumber]"))
+		return
+	}
+	var key url.Values
+	if r.URL.Path == SalesReportsPath {
+		filter, e := parseSalesReportsFilter(query)
+		if e != nil {
+			writeError(w, e)
+			return
+		}
+		key = buildQuery(filter.ToQueryParamsMap())
+	} else {
+		filter, e := parseFinancesReportsFilter(query)
+		if e != nil {
+			writeError(w, e)
+			return
+		}
+		key = buildFinancesQuery(filter)
+	}
+	data, ok := s.findReport(r.URL.Path, key)
+	if !ok {
+		writeError(w, buildStatusError(http.StatusNotFound))
+		return
+	}
+	writeReport(w, data)
+}
+
+//validateToken Validate bearer JWT token against the public key
+func (s *Server) validateToken(r *http.Request) error {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return fmt.Errorf("Provide a properly configured and signed bearer token, and make sure that it has not expired.")
+	}
+	claims := &jwt.StandardClaims{}
+	_, err := jwt.ParseWithClaims(strings.TrimPrefix(header, "Bearer "), claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodES256 {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		if kid, _ := token.Header["kid"].(string); kid == "" {
+			return nil, fmt.Errorf("kid header is required")
+		}
+		return s.PublicKey, nil
+	})
+	if err != nil {
+		return fmt.Errorf("Invalid bearer token: %v", err)
+	}
+	if !claims.VerifyAudience(appstore.AppStoreConnectAPIAudience, true) {
+		return fmt.Errorf("Invalid bearer token: audience is not valid")
+	}
+	if claims.Issuer == "" {
+		return fmt.Errorf("Invalid bearer token: issuer is required")
+	}
+	return nil
+}
+
+//Config Create SDK config pointing to the server
+func (s *Server) Config(privateKey string) *appstore.Config {
+	cfg := appstore.NewConfig("issuer-id", "key-id", s.VendorNo, privateKey)
+	cfg.Uri = s.URL
+	return cfg
+}
+
+//NewError Create API error response body
+func NewError(status int, code string, title string, detail string, parameter string) *appstore.ResponseBody {
+	e := &appstore.Error{Id: strconv.FormatInt(time.Now().UnixNano(), 36), Status: strconv.Itoa(status), Code: code, Title: title, Detail: detail}
+	if parameter != "" {
+		e.Source = &appstore.ErrorSource{Parameter: parameter}
+	}
+	return &appstore.ResponseBody{Errors: []*appstore.Error{e}}
+}
+
+//buildStatusError Build API error for simulated status
+func buildStatusError(status int) *appstore.ResponseBody {
+	switch status {
+	case http.StatusNotFound:
+		return NewError(status, "NOT_FOUND", "The specified resource does not exist", "There were no sales for the date specified.", "")
+	case http.StatusTooManyRequests:
+		return NewError(status, "RATE_LIMIT_EXCEEDED", "The request rate limit has been reached.", "We've received too many requests for this API. Please wait and try again or slow down your request rate.", "")
+	case http.StatusInternalServerError:
+		return NewError(status, "UNEXPECTED_ERROR", "An unexpected error occurred.", "An unexpected error occurred on the server side. If this issue continues, contact us at https://developer.apple.com/contact/.", "")
+	}
+	return NewError(status, "UNEXPECTED_ERROR", http.StatusText(status), http.StatusText(status), "")
+}
+
+//parameterError Build API invalid parameter error
+func parameterError(parameter string, detail string) *appstore.ResponseBody {
+	return NewError(http.StatusBadRequest, "PARAMETER_ERROR.INVALID", "A parameter has an invalid value", detail, parameter)
+}
+
+//writeError Write API error response
+func writeError(w http.ResponseWriter, body *appstore.ResponseBody) {
+	status, _ := strconv.Atoi(body.Errors[0].Status)
+	w.Header().Set("Content-Type", appstore.ResponseContentTypeJson)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+//writeReport Write gzipped TSV report
+func writeReport(w http.ResponseWriter, data []byte) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write(data)
+	_ = zw.Close()
+	w.Header().Set("Content-Type", appstore.ResponseContentTypeGzip)
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+//buildQuery Convert query params map to url values
+func buildQuery(params map[string]interface{}) url.Values {
+	query := url.Values{}
+	for k, v := range params {
+		if k != "filter[vendorNumber]" {
+			query.Set(k, fmt.Sprintf("%v", v))
+		}
+	}
+	return query
+}
+
+//buildFinancesQuery Convert finances filter to url values
+func buildFinancesQuery(filter *appstore.FinancesReportsFilter) url.Values {
+	query := url.Values{}
+	query.Set("filter[reportType]", string(filter.ReportType))
+	query.Set("filter[regionCode]", filter.RegionCode)
+	query.Set("filter[reportDate]", filter.ReportDate.Format("2006-01"))
+	return query
+}
+
+//parseDate Parse report date in any of YYYY-MM-DD, YYYY-MM, YYYY formats
+func parseDate(value string) (time.Time, error) {
+	var err error
+	for _, format := range []string{"2006-01-02", "2006-01", "2006"} {
+		var date time.Time
+		date, err = time.Parse(format, value)
+		if err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+//parseSalesReportsFilter Build and validate sales filter from query
+func parseSalesReportsFilter(query url.Values) (appstore.SalesReportsFilterInterface, *appstore.ResponseBody) {
+	base := appstore.SalesReportsBaseFilter{
+		ReportType:    appstore.SalesReportType(query.Get("filter[reportType]")),
+		ReportSubType: appstore.SalesReportSubType(query.Get("filter[reportSubType]")),
+		Frequency:     appstore.SalesReportFrequency(query.Get("filter[frequency]")),
+		Version:       appstore.SalesReportVersion(query.Get("filter[version]")),
+	}
+	if value := query.Get("filter[reportDate]"); value != "" {
+		date, err := parseDate(value)
+		if err != nil {
+			return nil, parameterError("filter[reportDate]", "The report date you have specified is invalid.")
+		}
+		base.ReportDate = date
+	}
+	var filter appstore.SalesReportsFilterInterface
+	switch base.ReportType {
+	case appstore.SalesReportTypeSales:
+		filter = &appstore.SalesReportsFilter{SalesReportsBaseFilter: base}
+	case appstore.SalesReportTypePreorder:
+		filter = &appstore.PreOrdersReportsFilter{SalesReportsBaseFilter: base}
+	case appstore.SalesReportTypeNewsStand:
+		filter = &appstore.NewsstandReportsFilter{SalesReportsBaseFilter: base}
+	case appstore.SalesReportTypeSubscription:
+		filter = &appstore.SubscriptionsReportsFilter{SalesReportsBaseFilter: base}
+	case appstore.SalesReportTypeSubscriptionEvent:
+		filter = &appstore.SubscriptionsEventsReportsFilter{SalesReportsBaseFilter: base}
+	case appstore.SalesReportTypeSubscriber:
+		filter = &appstore.SubscribersReportsFilter{SalesReportsBaseFilter: base}
+	case appstore.SalesReportTypeSubscriptionOfferCodeRedemption:
+		filter = &appstore.SubscriptionsOffersCodesRedemptionReportsFilter{SalesReportsBaseFilter: base}
+	default:
+		return nil, parameterError("filter[reportType]", "The report type you have specified is invalid.")
+	}
+	if err := filter.IsValid(); err != nil {
+		return nil, parameterError(invalidParameter(err), err.Error())
+	}
+	return filter, nil
+}
+
+//parseFinancesReportsFilter Build and validate finances filter from query
+func parseFinancesReportsFilter(query url.Values) (*appstore.FinancesReportsFilter, *appstore.ResponseBody) {
+	filter := &appstore.FinancesReportsFilter{
+		ReportType: appstore.FinancesReportType(query.Get("filter[reportType]")),
+		RegionCode: query.Get("filter[regionCode]"),
+	}
+	if value := query.Get("filter[reportDate]"); value != "" {
+		date, err := time.Parse("2006-01", value)
+		if err != nil {
+			return nil, parameterError("filter[reportDate]", "The report date you have specified is invalid.")
+		}
+		filter.ReportDate = date
+	}
+	if filter.ReportType != appstore.FinancesReportTypeFinancial && filter.ReportType != appstore.FinancesReportTypeFinanceDetail {
+		return nil, parameterError("filter[reportType]", "The report type you have specified is invalid.")
+	}
+	if err := filter.IsValid(); err != nil {
+		return nil, parameterError(invalidParameter(err), err.Error())
+	}
+	return filter, nil
+}
+
+//invalidParameter Get query parameter of filter validation error
+func invalidParameter(err error) string {
+	var filterErr *appstore.FilterError
+	if errors.As(err, &filterErr) {
+		return filterErr.Parameter
+	}
+	return ""
+}
+
+//GenerateKey Generate ES256 private key, returns key and its PKCS8 PEM content
+func GenerateKey() (*ecdsa.PrivateKey, string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("GenerateKey generate: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, "", fmt.Errorf("GenerateKey marshal: %v", err)
+	}
+	block := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	return key, string(block), nil
+}
+
+//NewServer Create and start new fake server
+func NewServer(publicKey *ecdsa.PublicKey, vendorNo string) *Server {
+	s := &Server{
+		PublicKey: publicKey,
+		VendorNo:  vendorNo,
+		reports:   make(map[string][]byte),
+		failures:  make(map[string][]int),
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
@@ -0,0 +1,122 @@
+package appstoretest
+
+import (
+	"context"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type ServerTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	client   *appstore.Client
+	testable *Server
+}
+
+func (suite *ServerTestSuite) SetupTest() {
+	key, pemKey, _ := GenerateKey()
+	suite.ctx = context.Background()
+	suite.testable = NewServer(&key.PublicKey, "12345678")
+	suite.client = appstore.NewClientFromConfig(suite.testable.Config(pemKey), nil)
+	_ = suite.client.Init()
+}
+
+func (suite *ServerTestSuite) TearDownTest() {
+	suite.testable.Close()
+}
+
+func (suite *ServerTestSuite) buildSalesFilter() *appstore.SalesReportsFilter {
+	filter := appstore.NewSalesReportsFilter()
+	filter.SubTypeSummary().Version10().Daily()
+	return filter
+}
+
+func (suite *ServerTestSuite) TestGetSalesReportsSuccess() {
+	data, _ := ioutil.ReadFile("../stubs/reports/sales/sales.tsv")
+	filter := suite.buildSalesFilter()
+	suite.testable.AddSalesReport(filter, data)
+
+	result, resp, err := suite.client.SalesReports().GetSalesReports(suite.ctx, filter)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	assert.Equal(suite.T(), "foo.bar.baz", result.Data[0].SKU)
+}
+
+func (suite *ServerTestSuite) TestGetFinancialReportsSuccess() {
+	data, _ := ioutil.ReadFile("../stubs/reports/finances/financial.tsv")
+	date, _ := time.Parse("2006-01-02", "2020-05-05")
+	filter := appstore.NewFinancesReportsFilter()
+	filter.SetReportDate(date).SetRegionCode("US")
+	suite.testable.AddFinancesReport(filter, data)
+
+	result, _, err := suite.client.FinancesReports().GetFinancialReports(suite.ctx, filter)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Data, 2)
+}
+
+func (suite *ServerTestSuite) TestNotAvailable() {
+	result, resp, err := suite.client.SalesReports().GetSalesReports(suite.ctx, suite.buildSalesFilter())
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	assert.Equal(suite.T(), "NOT_FOUND", result.Errors[0].Code)
+}
+
+func (suite *ServerTestSuite) TestSimulatedStatuses() {
+	data, _ := ioutil.ReadFile("../stubs/reports/sales/sales.tsv")
+	filter := suite.buildSalesFilter()
+	suite.testable.AddSalesReport(filter, data)
+	suite.testable.SimulateRateLimit(SalesReportsPath, 1).SimulateServerError(SalesReportsPath, 1)
+
+	result, resp, err := suite.client.SalesReports().GetSalesReports(suite.ctx, filter)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(suite.T(), "RATE_LIMIT_EXCEEDED", result.Errors[0].Code)
+
+	result, resp, err = suite.client.SalesReports().GetSalesReports(suite.ctx, filter)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(suite.T(), "UNEXPECTED_ERROR", result.Errors[0].Code)
+
+	_, resp, err = suite.client.SalesReports().GetSalesReports(suite.ctx, filter)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+}
+
+func (suite *ServerTestSuite) TestInvalidFilterCombination() {
+	filter := &appstore.SalesReportsBaseFilter{}
+	filter.TypeSubscription().SubTypeSummary().Weekly().Version12()
+	resp, err := suite.client.SalesReports().GetReports(suite.ctx, filter)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	body := &appstore.ResponseBody{}
+	data, _ := ioutil.ReadAll(resp.Body)
+	_ = (&appstore.ResponseHandlerJson{}).UnmarshalBody(data, body)
+	assert.Equal(suite.T(), "PARAMETER_ERROR.INVALID", body.Errors[0].Code)
+	assert.Equal(suite.T(), "filter[frequency]", body.Errors[0].Source.Parameter)
+}
+
+func (suite *ServerTestSuite) TestInvalidToken() {
+	_, pemKey, _ := GenerateKey()
+	client := appstore.NewClientFromConfig(suite.testable.Config(pemKey), nil)
+	_ = client.Init()
+	result, resp, err := client.SalesReports().GetSalesReports(suite.ctx, suite.buildSalesFilter())
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(suite.T(), "NOT_AUTHORIZED", result.Errors[0].Code)
+}
+
+func (suite *ServerTestSuite) TestInvalidVendor() {
+	suite.client.Cfg.VendorNo = "foo"
+	_, resp, err := suite.client.SalesReports().GetSalesReports(suite.ctx, suite.buildSalesFilter())
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+}
+
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
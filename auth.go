@@ -2,7 +2,6 @@ package appstore
 
 import (
 	"github.com/dgrijalva/jwt-go"
-	"time"
 )
 
 //AuthToken auth token structure
@@ -18,8 +17,12 @@ func (t *AuthToken) IsValid() bool {
 
 //IsNotExpired Check token is not expired
 func (t *AuthToken) IsNotExpired() bool {
-	ts := time.Now().Unix()
-	return t.ExpiresAt > ts
+	return t.IsNotExpiredFor(0)
+}
+
+//IsNotExpiredFor Check token does not expire in next seconds. Time is taken from jwt.TimeFunc, so expiry follows the clock of token validation
+func (t *AuthToken) IsNotExpiredFor(seconds int64) bool {
+	return t.ExpiresAt > jwt.TimeFunc().Unix()+seconds
 }
 
 //TokenBuilder token builder
@@ -38,7 +41,7 @@ func (tb *TokenBuilder) BuildPayload() *jwt.StandardClaims {
 	return &jwt.StandardClaims{
 		Audience:  tb.cfg.Token.Audience,
 		Issuer:    tb.cfg.IssuerId,
-		ExpiresAt: time.Now().Unix() + int64(tb.cfg.Token.Ttl),
+		ExpiresAt: jwt.TimeFunc().Unix() + int64(tb.cfg.Token.Ttl),
 	}
 }
 
@@ -22,6 +22,12 @@ func (suite *AuthTokenTestSuite) TestIsNotExpiredSuccess() {
 	assert.True(suite.T(), suite.testable.IsNotExpired())
 }
 
+func (suite *AuthTokenTestSuite) TestIsNotExpiredFor() {
+	suite.testable.ExpiresAt = time.Now().Unix() + 30
+	assert.True(suite.T(), suite.testable.IsNotExpiredFor(10))
+	assert.False(suite.T(), suite.testable.IsNotExpiredFor(60))
+}
+
 func (suite *AuthTokenTestSuite) TestIsNotExpiredFail() {
 	suite.testable.ExpiresAt = time.Now().Unix() - 1000
 	assert.False(suite.T(), suite.testable.IsNotExpired())
@@ -7,10 +7,35 @@ import (
 
 //Client common
 type Client struct {
-	transport *Transport
-	auth      *TokenBuilder
-	http      *http.Client
-	Cfg       *Config
+	transport   *Transport
+	auth        *TokenBuilder
+	http        *http.Client
+	middlewares []Middleware
+	telemetry   Telemetry
+	Cfg         *Config
+}
+
+//Use Append middlewares to the http requests chain
+func (cl *Client) Use(middlewares ...Middleware) *Client {
+	cl.middlewares = append(cl.middlewares, middlewares...)
+	if cl.transport != nil {
+		cl.transport.Use(middlewares...)
+	}
+	return cl
+}
+
+//UseLogger Log every http request with logger
+func (cl *Client) UseLogger(logger Logger) *Client {
+	return cl.Use(NewLoggerMiddleware(logger))
+}
+
+//UseTelemetry Set tracing and metrics hooks
+func (cl *Client) UseTelemetry(telemetry Telemetry) *Client {
+	cl.telemetry = telemetry
+	if cl.transport != nil {
+		cl.transport.SetTelemetry(telemetry)
+	}
+	return cl
 }
 
 //Init of client
@@ -19,7 +44,9 @@ func (cl *Client) Init() error {
 	if err != nil {
 		return fmt.Errorf("client.init error: %v", err)
 	}
-	cl.transport = NewHttpTransport(cl.Cfg, token, cl.http)
+	cl.transport = NewHttpTransport(cl.Cfg, token, cl.http, cl.middlewares...)
+	cl.transport.SetTokenBuilder(cl.auth)
+	cl.transport.SetTelemetry(cl.telemetry)
 	return nil
 }
 
@@ -33,6 +60,16 @@ func (cl *Client) FinancesReports() *FinancesReportsResource {
 	return &FinancesReportsResource{newResourceAbstract(cl.transport, cl.Cfg)}
 }
 
+//AnalyticsReports resource
+func (cl *Client) AnalyticsReports() *AnalyticsReportsResource {
+	return &AnalyticsReportsResource{newResourceAbstract(cl.transport, cl.Cfg)}
+}
+
+//Apps resource
+func (cl *Client) Apps() *AppsResource {
+	return &AppsResource{newResourceAbstract(cl.transport, cl.Cfg)}
+}
+
 //NewClientFromConfig Create new client from config
 func NewClientFromConfig(cfg *Config, cl *http.Client) *Client {
 	if cl == nil {
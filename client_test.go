@@ -56,6 +56,22 @@ func (suite *ClientTestSuite) TestFinancialReports() {
 	assert.NotEmpty(suite.T(), result.transport)
 }
 
+func (suite *ClientTestSuite) TestAnalyticsReports() {
+	_ = suite.testable.Init()
+	result := suite.testable.AnalyticsReports()
+	assert.NotEmpty(suite.T(), result)
+	assert.NotEmpty(suite.T(), result.config)
+	assert.NotEmpty(suite.T(), result.transport)
+}
+
+func (suite *ClientTestSuite) TestApps() {
+	_ = suite.testable.Init()
+	result := suite.testable.Apps()
+	assert.NotEmpty(suite.T(), result)
+	assert.NotEmpty(suite.T(), result.config)
+	assert.NotEmpty(suite.T(), result.transport)
+}
+
 func TestClientTestSuite(t *testing.T) {
 	suite.Run(t, new(ClientTestSuite))
 }
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+//envPrefix environment variables prefix
+const envPrefix = "APPSTORE_"
+
+//config credentials of App Store Connect API
+type config struct {
+	Uri        string `json:"uri"`
+	IssuerId   string `json:"issuer_id"`
+	KeyId      string `json:"key_id"`
+	VendorNo   string `json:"vendor_no"`
+	PrivateKey string `json:"private_key"` //path to .p8 file or its content
+}
+
+//configFlags credentials flags of command
+type configFlags struct {
+	path string
+	config
+}
+
+//register Register credentials flags
+func (f *configFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.path, "config", "", "path to JSON config file with uri, issuer_id, key_id, vendor_no and private_key ("+envPrefix+"CONFIG)")
+	fs.StringVar(&f.Uri, "uri", "", "App Store Connect API uri ("+envPrefix+"URI)")
+	fs.StringVar(&f.IssuerId, "issuer-id", "", "API key issuer ID ("+envPrefix+"ISSUER_ID)")
+	fs.StringVar(&f.KeyId, "key-id", "", "API key ID ("+envPrefix+"KEY_ID)")
+	fs.StringVar(&f.VendorNo, "vendor-no", "", "vendor number ("+envPrefix+"VENDOR_NO)")
+	fs.StringVar(&f.PrivateKey, "private-key", "", "path to API private key .p8 file or its content ("+envPrefix+"PRIVATE_KEY)")
+}
+
+//load Build SDK config, flags override environment variables, environment variables override config file
+func (f *configFlags) load(env func(string) string) (*appstore.Config, error) {
+	cfg := config{}
+	path := firstNonEmpty(f.path, env(envPrefix+"CONFIG"))
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read config: %v", err)
+		}
+		if err = json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parse config %s: %v", path, err)
+		}
+	}
+	cfg.Uri = firstNonEmpty(f.Uri, env(envPrefix+"URI"), cfg.Uri)
+	cfg.IssuerId = firstNonEmpty(f.IssuerId, env(envPrefix+"ISSUER_ID"), cfg.IssuerId)
+	cfg.KeyId = firstNonEmpty(f.KeyId, env(envPrefix+"KEY_ID"), cfg.KeyId)
+	cfg.VendorNo = firstNonEmpty(f.VendorNo, env(envPrefix+"VENDOR_NO"), cfg.VendorNo)
+	cfg.PrivateKey = firstNonEmpty(f.PrivateKey, env(envPrefix+"PRIVATE_KEY"), cfg.PrivateKey)
+	missing := make([]string, 0)
+	for name, value := range map[string]string{"issuer-id": cfg.IssuerId, "key-id": cfg.KeyId, "vendor-no": cfg.VendorNo, "private-key": cfg.PrivateKey} {
+		if value == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, &usageError{fmt.Sprintf("missing credentials: %s", strings.Join(missing, ", "))}
+	}
+	result := appstore.NewConfig(cfg.IssuerId, cfg.KeyId, cfg.VendorNo, cfg.PrivateKey)
+	if cfg.Uri != "" {
+		result.Uri = cfg.Uri
+	}
+	return result, nil
+}
+
+//firstNonEmpty Get first non empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+//client Create initialized API client
+func (f *configFlags) client(env func(string) string) (*appstore.Client, error) {
+	cfg, err := f.load(env)
+	if err != nil {
+		return nil, err
+	}
+	client := appstore.NewClientFromConfig(cfg, nil)
+	if err = client.Init(); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type ConfigFlagsTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (suite *ConfigFlagsTestSuite) SetupTest() {
+	suite.dir, _ = ioutil.TempDir("", "appstore")
+}
+
+func (suite *ConfigFlagsTestSuite) TearDownTest() {
+	_ = os.RemoveAll(suite.dir)
+}
+
+func (suite *ConfigFlagsTestSuite) parse(args ...string) *configFlags {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	f := &configFlags{}
+	f.register(fs)
+	_ = fs.Parse(args)
+	return f
+}
+
+func (suite *ConfigFlagsTestSuite) TestLoadPrecedence() {
+	path := filepath.Join(suite.dir, "config.json")
+	_ = ioutil.WriteFile(path, []byte(`{"uri":"http://file","issuer_id":"file-issuer","key_id":"file-key","vendor_no":"1","private_key":"file.p8"}`), 0600)
+	env := map[string]string{"APPSTORE_CONFIG": path, "APPSTORE_KEY_ID": "env-key", "APPSTORE_VENDOR_NO": "2"}
+	f := suite.parse("--vendor-no", "3")
+
+	cfg, err := f.load(func(name string) string { return env[name] })
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "http://file", cfg.Uri)
+	assert.Equal(suite.T(), "file-issuer", cfg.IssuerId)
+	assert.Equal(suite.T(), "env-key", cfg.KeyId)
+	assert.Equal(suite.T(), "3", cfg.VendorNo)
+	assert.Equal(suite.T(), "file.p8", cfg.PrivateKey)
+}
+
+func (suite *ConfigFlagsTestSuite) TestLoadDefaultUri() {
+	f := suite.parse("--issuer-id", "foo", "--key-id", "bar", "--vendor-no", "1", "--private-key", "key.p8")
+	cfg, err := f.load(func(string) string { return "" })
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), appstore.AppStoreConnectAPIProductionUri, cfg.Uri)
+}
+
+func (suite *ConfigFlagsTestSuite) TestLoadMissingCredentials() {
+	f := suite.parse("--issuer-id", "foo")
+	cfg, err := f.load(func(string) string { return "" })
+	assert.Nil(suite.T(), cfg)
+	assert.IsType(suite.T(), &usageError{}, err)
+	assert.Equal(suite.T(), "missing credentials: key-id, private-key, vendor-no", err.Error())
+}
+
+func (suite *ConfigFlagsTestSuite) TestLoadInvalidFile() {
+	path := filepath.Join(suite.dir, "config.json")
+	_ = ioutil.WriteFile(path, []byte(`{`), 0600)
+	f := suite.parse("--config", path)
+	_, err := f.load(func(string) string { return "" })
+	assert.Error(suite.T(), err)
+	_, err = suite.parse("--config", filepath.Join(suite.dir, "foo.json")).load(func(string) string { return "" })
+	assert.Error(suite.T(), err)
+}
+
+func TestConfigFlagsTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigFlagsTestSuite))
+}
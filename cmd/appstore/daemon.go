@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/matisiekpl/appstore-sdk-go/ingest"
+	"github.com/matisiekpl/appstore-sdk-go/sqlstore"
+	_ "github.com/mattn/go-sqlite3"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+//daemonCommand Sync reports periodically into sinks until interrupted
+func daemonCommand(ctx context.Context, args []string, env func(string) string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("daemon", stderr)
+	cfgFlags := &configFlags{}
+	cfgFlags.register(fs)
+	names := fs.String("reports", "sales", fmt.Sprintf("comma separated reports %v", reportNames()))
+	from := fs.String("from", "", "first report date to sync (required)")
+	state := fs.String("state", "checkpoints.json", "checkpoint file of ingested report dates")
+	dbPath := fs.String("db", "", "SQLite database sink")
+	dir := fs.String("dir", "", "directory sink, one file per report")
+	formatName := fs.String("format", string(formatTSV), "file format of directory sink")
+	listen := fs.String("listen", "127.0.0.1:8080", "address of health and status endpoint, empty to disable")
+	jitter := fs.Duration("jitter", ingest.JitterDefault, "max random delay of scheduled syncs")
+	hour := fs.Int("publication-hour", ingest.PublicationHourDefault, "UTC hour when reports of previous day are published")
+	options := &reportOptions{}
+	fs.StringVar(&options.frequency, "frequency", "daily", "report frequency of sales and preorders: daily, weekly, monthly or yearly")
+	fs.StringVar(&options.region, "region", "", "financial report region code (default ZZ, Z1 for finance_detail)")
+	fs.StringVar(&options.financeType, "type", "financial", "financial report type: financial or finance_detail")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *from == "" {
+		return &usageError{"from is required"}
+	}
+	start, err := parseDate(*from)
+	if err != nil {
+		return err
+	}
+	if *dbPath == "" && *dir == "" {
+		return &usageError{"at least one sink is required: db or dir"}
+	}
+	f, err := parseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	if f == formatRaw {
+		return &usageError{"format raw is not supported by daemon"}
+	}
+	streams := make([]*ingest.Stream, 0)
+	byType := make(map[string]*report)
+	for _, name := range strings.Split(*names, ",") {
+		r, ok := findReport(strings.TrimSpace(name))
+		if !ok {
+			return &usageError{fmt.Sprintf("unknown report %s, expected one of %v", name, reportNames())}
+		}
+		stream, err := r.stream(start, options)
+		if err != nil {
+			return err
+		}
+		streams = append(streams, stream)
+		byType[stream.ReportType] = r
+	}
+	client, err := cfgFlags.client(env)
+	if err != nil {
+		return err
+	}
+
+	handlers := make([]ingest.Handler, 0, 2)
+	if *dbPath != "" {
+		db, err := sql.Open("sqlite3", *dbPath)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		store := sqlstore.NewStore(db, sqlstore.DialectSQLite)
+		if err = store.Migrate(ctx); err != nil {
+			return err
+		}
+		handlers = append(handlers, ingest.StoreHandler(store))
+	}
+	if *dir != "" {
+		if err = os.MkdirAll(*dir, 0755); err != nil {
+			return err
+		}
+		handlers = append(handlers, fileHandler(*dir, f, byType, options))
+	}
+	scheduler := ingest.NewScheduler(ingest.NewSyncer(client, ingest.NewFileCheckpointStore(*state), ingest.Handlers(handlers...)), streams...)
+	scheduler.Jitter = *jitter
+	scheduler.PublicationHour = *hour
+	scheduler.Logger = &textLogger{out: stderr}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	if *listen != "" {
+		listener, err := net.Listen("tcp", *listen)
+		if err != nil {
+			return err
+		}
+		server := &http.Server{Handler: scheduler}
+		go func() {
+			_ = server.Serve(listener)
+		}()
+		defer server.Close()
+		_, _ = fmt.Fprintf(stdout, "serving health and status on http://%s\n", listener.Addr())
+	}
+	return scheduler.Run(ctx)
+}
+
+//fileHandler Create handler writing report rows to file of directory
+func fileHandler(dir string, f format, byType map[string]*report, o *reportOptions) ingest.Handler {
+	return func(ctx context.Context, rpt *ingest.Report) error {
+		r := byType[rpt.ReportType]
+		options := *o
+		options.frequency = rpt.Frequency
+		path := filepath.Join(dir, fmt.Sprintf("%s_%s.%s", r.name, r.label(rpt.Date, &options), f.extension()))
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		err = writeRows(file, f, rpt.Rows)
+		closeErr := file.Close()
+		if err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(path)
+		}
+		return err
+	}
+}
+
+//textLogger logger writing text lines
+type textLogger struct {
+	out io.Writer
+	mu  sync.Mutex
+}
+
+//Log Write log line with fields as key=value pairs
+func (l *textLogger) Log(ctx context.Context, level appstore.LogLevel, msg string, fields ...appstore.LogField) {
+	line := fmt.Sprintf("%s %s %s", time.Now().UTC().Format(time.RFC3339), level, msg)
+	for _, field := range fields {
+		value := field.Value
+		if t, ok := value.(time.Time); ok {
+			value = t.UTC().Format(time.RFC3339)
+		}
+		line += fmt.Sprintf(" %s=%q", field.Key, fmt.Sprint(value))
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = fmt.Fprintln(l.out, line)
+}
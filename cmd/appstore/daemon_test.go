@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func (suite *MainTestSuite) TestDaemon() {
+	data, _ := ioutil.ReadFile("../../stubs/reports/sales/sales.tsv")
+	filter := appstore.NewSalesReportsFilter()
+	filter.SubTypeSummary().Version10().Yearly().SetReportDate(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	suite.server.AddSalesReport(filter, data)
+	dir := filepath.Join(suite.dir, "reports")
+	state := filepath.Join(suite.dir, "checkpoints.json")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- daemonCommand(ctx, []string{"--from", "2020", "--frequency", "yearly", "--dir", dir, "--state", state, "--listen", "", "--jitter", "0s"},
+			func(name string) string { return suite.env[name] }, &bytes.Buffer{}, &bytes.Buffer{})
+	}()
+	path := filepath.Join(dir, "sales_2020.tsv")
+	for i := 0; i < 100; i++ {
+		if _, err := os.Stat(state); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	cancel()
+	assert.NoError(suite.T(), <-done)
+	_, err := os.Stat(path)
+	assert.NoError(suite.T(), err)
+	checkpoints, _ := ioutil.ReadFile(state)
+	assert.Contains(suite.T(), string(checkpoints), "2020-01-01")
+	assert.Contains(suite.T(), string(checkpoints), "YEARLY")
+}
+
+func (suite *MainTestSuite) TestDaemonInvalidUsage() {
+	assert.Equal(suite.T(), exitUsage, suite.run("daemon", "--dir", suite.dir))
+	assert.Equal(suite.T(), exitUsage, suite.run("daemon", "--from", "2020-05-05"))
+	assert.Contains(suite.T(), suite.stderr.String(), "at least one sink is required")
+	assert.Equal(suite.T(), exitUsage, suite.run("daemon", "--from", "2020-05-05", "--dir", suite.dir, "--reports", "sales,foo"))
+	assert.Equal(suite.T(), exitUsage, suite.run("daemon", "--from", "2020-05-05", "--dir", suite.dir, "--format", "raw"))
+	assert.Equal(suite.T(), exitUsage, suite.run("daemon", "--from", "2020-05-05", "--dir", suite.dir, "--frequency", "hourly"))
+	assert.Equal(suite.T(), exitUsage, suite.run("daemon", "--from", "2020-05", "--dir", suite.dir, "--reports", "finance", "--region", "XX"))
+}
+
+func (suite *MainTestSuite) TestTextLogger() {
+	out := &bytes.Buffer{}
+	logger := &textLogger{out: out}
+	logger.Log(context.Background(), appstore.LogLevelInfo, "sync finished", appstore.LogField{Key: "stream", Value: "123 SALES"},
+		appstore.LogField{Key: "next_run", Value: time.Date(2020, 5, 8, 15, 0, 0, 0, time.UTC)})
+	assert.True(suite.T(), strings.HasSuffix(out.String(), ` INFO sync finished stream="123 SALES" next_run="2020-05-08T15:00:00Z"`+"\n"))
+}
@@ -0,0 +1,376 @@
+//Command appstore downloads and inspects App Store Connect sales and financial reports.
+//
+//Usage:
+//
+//	appstore <command> [flags]
+//
+//Exit codes: 0 success, 1 error, 2 invalid usage, 3 report is not available yet or has no data.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+)
+
+const (
+	exitOK           = 0
+	exitError        = 1
+	exitUsage        = 2
+	exitNotAvailable = 3
+)
+
+//usageError invalid command line usage
+type usageError struct {
+	msg string
+}
+
+//Error implementation of error
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+//notAvailableError report is not available, Apple responds with 404 when report is not generated yet or has no data
+type notAvailableError struct {
+	err error
+}
+
+//Error implementation of error
+func (e *notAvailableError) Error() string {
+	return fmt.Sprintf("report is not available: %v", e.err)
+}
+
+//command CLI subcommand
+type command struct {
+	name        string
+	description string
+	run         func(ctx context.Context, args []string, env func(string) string, stdout io.Writer, stderr io.Writer) error
+}
+
+//commands Get CLI subcommands
+func commands() []*command {
+	result := make([]*command, 0, len(reports)+3)
+	for _, r := range reports {
+		result = append(result, &command{name: r.name, description: r.description, run: reportCommand(r)})
+	}
+	result = append(result,
+		&command{name: "backfill", description: "Download reports of date range into directory", run: backfillCommand},
+		&command{name: "daemon", description: "Sync new reports periodically into database or directory", run: daemonCommand},
+		&command{name: "verify-credentials", description: "Check API credentials", run: verifyCredentialsCommand},
+	)
+	return result
+}
+
+//globalFlags flags of every command
+type globalFlags struct {
+	configFlags
+	timeout time.Duration
+}
+
+//register Register global flags
+func (f *globalFlags) register(fs *flag.FlagSet) {
+	f.configFlags.register(fs)
+	fs.DurationVar(&f.timeout, "timeout", 5*time.Minute, "timeout of command, of every report request for backfill")
+}
+
+//newFlagSet Create flag set of command
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("appstore "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+//parseFlags Parse command flags, invalid flags are usage error
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return &usageError{err.Error()}
+	}
+	if fs.NArg() > 0 {
+		return &usageError{fmt.Sprintf("unexpected arguments %v", fs.Args())}
+	}
+	return nil
+}
+
+//reportCommand Create command downloading single report
+func reportCommand(r *report) func(ctx context.Context, args []string, env func(string) string, stdout io.Writer, stderr io.Writer) error {
+	return func(ctx context.Context, args []string, env func(string) string, stdout io.Writer, stderr io.Writer) error {
+		fs := newFlagSet(r.name, stderr)
+		global := &globalFlags{}
+		global.register(fs)
+		options := &reportOptions{}
+		options.register(fs, r)
+		fs.StringVar(&options.date, "date", "", "report date YYYY-MM-DD, YYYY-MM or YYYY (default yesterday, previous month for finance)")
+		formatName := fs.String("format", string(formatTable), fmt.Sprintf("output format %v", formats))
+		output := fs.String("output", "", "output file (default stdout)")
+		if err := parseFlags(fs, args); err != nil {
+			return err
+		}
+		f, err := parseFormat(*formatName)
+		if err != nil {
+			return err
+		}
+		date := r.defaultDate(time.Now())
+		if options.date != "" {
+			if date, err = parseDate(options.date); err != nil {
+				return err
+			}
+		}
+		req, err := r.request(date, options)
+		if err != nil {
+			return err
+		}
+		client, err := global.client(env)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(ctx, global.timeout)
+		defer cancel()
+		if *output != "" {
+			_, err = downloadFile(ctx, client, req, f, *output)
+			return err
+		}
+		_, err = download(ctx, client, req, f, stdout)
+		return err
+	}
+}
+
+//download Download report and write it in format, returns number of rows, -1 for raw format
+func download(ctx context.Context, client *appstore.Client, req *request, f format, out io.Writer) (int, error) {
+	if f == formatRaw {
+		resp, err := req.raw(ctx, client)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= http.StatusMultipleChoices {
+			body, _ := ioutil.ReadAll(resp.Body)
+			err = fmt.Errorf("%s: %s", resp.Status, body)
+			if resp.StatusCode == http.StatusNotFound {
+				return 0, &notAvailableError{err}
+			}
+			return 0, err
+		}
+		_, err = io.Copy(out, resp.Body)
+		return -1, err
+	}
+	rows, resp, err := req.rows(ctx, client)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return 0, &notAvailableError{err}
+		}
+		return 0, err
+	}
+	if err = writeRows(out, f, rows); err != nil {
+		return 0, err
+	}
+	return reflect.Indirect(reflect.ValueOf(rows)).Len(), nil
+}
+
+//backfillCommand Download reports of every date in range into directory, one file per report
+func backfillCommand(ctx context.Context, args []string, env func(string) string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("backfill", stderr)
+	global := &globalFlags{}
+	global.register(fs)
+	name := fs.String("report", "sales", fmt.Sprintf("report %v", reportNames()))
+	from := fs.String("from", "", "first report date (required)")
+	to := fs.String("to", "", "last report date (default from)")
+	dir := fs.String("dir", ".", "output directory")
+	formatName := fs.String("format", string(formatTSV), fmt.Sprintf("output format %v", formats))
+	options := &reportOptions{}
+	fs.StringVar(&options.frequency, "frequency", "daily", "report frequency of sales and preorders: daily, weekly, monthly or yearly")
+	fs.StringVar(&options.version, "version", "", "report version (default latest supported by report)")
+	fs.StringVar(&options.region, "region", "", "financial report region code (default ZZ, Z1 for finance_detail)")
+	fs.StringVar(&options.financeType, "type", "financial", "financial report type: financial or finance_detail")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	r, ok := findReport(*name)
+	if !ok {
+		return &usageError{fmt.Sprintf("unknown report %s, expected one of %v", *name, reportNames())}
+	}
+	if !r.frequencies {
+		options.frequency = "daily"
+	}
+	options.version = firstNonEmpty(options.version, r.version)
+	f, err := parseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	if *from == "" {
+		return &usageError{"from is required"}
+	}
+	first, err := parseDate(*from)
+	if err != nil {
+		return err
+	}
+	last := first
+	if *to != "" {
+		if last, err = parseDate(*to); err != nil {
+			return err
+		}
+	}
+	if last.Before(first) {
+		return &usageError{"to is before from"}
+	}
+	client, err := global.client(env)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(*dir, 0755); err != nil {
+		return err
+	}
+	downloaded, failed := 0, 0
+	for date := first; !date.After(last); date = r.next(date, options) {
+		label := r.label(date, options)
+		req, err := r.request(date, options)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(*dir, fmt.Sprintf("%s_%s.%s", r.name, label, f.extension()))
+		//timeout applies to every report, long ranges would not fit into single timeout
+		reqCtx, cancel := context.WithTimeout(ctx, global.timeout)
+		rows, err := downloadFile(reqCtx, client, req, f, path)
+		cancel()
+		var notAvailable *notAvailableError
+		switch {
+		case errors.As(err, &notAvailable):
+			_, _ = fmt.Fprintf(stderr, "%s %s: not available\n", r.name, label)
+		case err != nil:
+			failed++
+			_, _ = fmt.Fprintf(stderr, "%s %s: error: %v\n", r.name, label, err)
+		case rows < 0:
+			downloaded++
+			_, _ = fmt.Fprintf(stdout, "%s %s: saved %s\n", r.name, label, path)
+		default:
+			downloaded++
+			_, _ = fmt.Fprintf(stdout, "%s %s: saved %s (%d rows)\n", r.name, label, path, rows)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d reports failed", failed)
+	}
+	if downloaded == 0 {
+		return &notAvailableError{fmt.Errorf("no reports in range")}
+	}
+	return nil
+}
+
+//downloadFile Download report into file, file is removed on error
+func downloadFile(ctx context.Context, client *appstore.Client, req *request, f format, path string) (int, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := download(ctx, client, req, f, file)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+	}
+	return rows, err
+}
+
+//verifyCredentialsCommand Check credentials by requesting sales report, missing report means valid credentials
+func verifyCredentialsCommand(ctx context.Context, args []string, env func(string) string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("verify-credentials", stderr)
+	global := &globalFlags{}
+	global.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	client, err := global.client(env)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, global.timeout)
+	defer cancel()
+	r, _ := findReport("sales")
+	req, err := r.request(r.defaultDate(time.Now()), &reportOptions{frequency: "daily", version: r.version})
+	if err != nil {
+		return err
+	}
+	resp, err := req.raw(ctx, client)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices && resp.StatusCode != http.StatusNotFound {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("credentials are not valid: %s: %s", resp.Status, body)
+	}
+	_, _ = fmt.Fprintf(stdout, "credentials are valid for vendor %s\n", client.Cfg.VendorNo)
+	return nil
+}
+
+//usage Write usage
+func usage(out io.Writer) {
+	_, _ = fmt.Fprintln(out, "Usage: appstore <command> [flags]")
+	_, _ = fmt.Fprintln(out)
+	_, _ = fmt.Fprintln(out, "Commands:")
+	for _, c := range commands() {
+		_, _ = fmt.Fprintf(out, "  %-20s %s\n", c.name, c.description)
+	}
+	_, _ = fmt.Fprintln(out)
+	_, _ = fmt.Fprintln(out, "Run appstore <command> -h for command flags.")
+	_, _ = fmt.Fprintln(out, "Exit codes: 0 success, 1 error, 2 invalid usage, 3 report is not available.")
+}
+
+//run Run CLI with arguments and return exit code
+func run(args []string, env func(string) string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stdout)
+		return exitOK
+	}
+	for _, c := range commands() {
+		if c.name != args[0] {
+			continue
+		}
+		err := c.run(context.Background(), args[1:], env, stdout, stderr)
+		return exitCode(err, stderr)
+	}
+	_, _ = fmt.Fprintf(stderr, "appstore: unknown command %s\n\n", args[0])
+	usage(stderr)
+	return exitUsage
+}
+
+//exitCode Report error and get exit code
+func exitCode(err error, stderr io.Writer) int {
+	if err == nil {
+		return exitOK
+	}
+	if err == flag.ErrHelp {
+		return exitOK
+	}
+	_, _ = fmt.Fprintf(stderr, "appstore: %v\n", err)
+	var usageErr *usageError
+	var notAvailable *notAvailableError
+	switch {
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.As(err, &notAvailable):
+		return exitNotAvailable
+	}
+	return exitError
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Getenv, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/matisiekpl/appstore-sdk-go/appstoretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MainTestSuite struct {
+	suite.Suite
+	server *appstoretest.Server
+	env    map[string]string
+	dir    string
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+}
+
+func (suite *MainTestSuite) SetupTest() {
+	key, pemKey, _ := appstoretest.GenerateKey()
+	suite.server = appstoretest.NewServer(&key.PublicKey, "12345678")
+	cfg := suite.server.Config(pemKey)
+	suite.dir, _ = ioutil.TempDir("", "appstore")
+	suite.env = map[string]string{
+		"APPSTORE_URI":         cfg.Uri,
+		"APPSTORE_ISSUER_ID":   cfg.IssuerId,
+		"APPSTORE_KEY_ID":      cfg.KeyId,
+		"APPSTORE_VENDOR_NO":   cfg.VendorNo,
+		"APPSTORE_PRIVATE_KEY": pemKey,
+	}
+	suite.stdout = &bytes.Buffer{}
+	suite.stderr = &bytes.Buffer{}
+}
+
+func (suite *MainTestSuite) TearDownTest() {
+	suite.server.Close()
+	_ = os.RemoveAll(suite.dir)
+}
+
+func (suite *MainTestSuite) run(args ...string) int {
+	suite.stdout.Reset()
+	suite.stderr.Reset()
+	return run(args, func(name string) string { return suite.env[name] }, suite.stdout, suite.stderr)
+}
+
+func (suite *MainTestSuite) addSalesReport(date string) {
+	data, _ := ioutil.ReadFile("../../stubs/reports/sales/sales.tsv")
+	reportDate, _ := time.Parse("2006-01-02", date)
+	filter := appstore.NewSalesReportsFilter()
+	filter.SubTypeSummary().Version10().Daily().SetReportDate(reportDate)
+	suite.server.AddSalesReport(filter, data)
+}
+
+func (suite *MainTestSuite) TestUsage() {
+	assert.Equal(suite.T(), exitUsage, suite.run())
+	assert.Contains(suite.T(), suite.stderr.String(), "verify-credentials")
+	assert.Equal(suite.T(), exitOK, suite.run("help"))
+	assert.Contains(suite.T(), suite.stdout.String(), "backfill")
+	assert.Equal(suite.T(), exitUsage, suite.run("foo"))
+	assert.Contains(suite.T(), suite.stderr.String(), "unknown command foo")
+	assert.Equal(suite.T(), exitOK, suite.run("sales", "-h"))
+	assert.Contains(suite.T(), suite.stderr.String(), "-frequency")
+}
+
+func (suite *MainTestSuite) TestInvalidUsage() {
+	assert.Equal(suite.T(), exitUsage, suite.run("sales", "--foo"))
+	assert.Equal(suite.T(), exitUsage, suite.run("sales", "--format", "xml"))
+	assert.Equal(suite.T(), exitUsage, suite.run("sales", "--date", "05/05/2020"))
+	assert.Equal(suite.T(), exitUsage, suite.run("sales", "--frequency", "hourly"))
+	assert.Equal(suite.T(), exitUsage, suite.run("finance", "--region", "XX"))
+	assert.Equal(suite.T(), exitUsage, suite.run("backfill", "--report", "foo", "--from", "2020-05-05"))
+	assert.Equal(suite.T(), exitUsage, suite.run("backfill", "--from", "2020-05-05", "--to", "2020-05-01"))
+	delete(suite.env, "APPSTORE_KEY_ID")
+	assert.Equal(suite.T(), exitUsage, suite.run("sales"))
+	assert.Contains(suite.T(), suite.stderr.String(), "missing credentials: key-id")
+}
+
+func (suite *MainTestSuite) TestSalesTable() {
+	suite.addSalesReport("2020-05-05")
+	assert.Equal(suite.T(), exitOK, suite.run("sales", "--date", "2020-05-05"))
+	lines := strings.Split(strings.TrimSpace(suite.stdout.String()), "\n")
+	assert.True(suite.T(), strings.HasPrefix(lines[0], "Provider  "))
+	assert.Contains(suite.T(), lines[1], "foo.bar.baz")
+}
+
+func (suite *MainTestSuite) TestSalesFormats() {
+	suite.addSalesReport("2020-05-05")
+	assert.Equal(suite.T(), exitOK, suite.run("sales", "--date", "2020-05-05", "--format", "json"))
+	rows := []*appstore.SalesReport{}
+	assert.NoError(suite.T(), json.Unmarshal(suite.stdout.Bytes(), &rows))
+	assert.Equal(suite.T(), "foo.bar.baz", rows[0].SKU)
+
+	assert.Equal(suite.T(), exitOK, suite.run("sales", "--date", "2020-05-05", "--format", "ndjson"))
+	lines := strings.Split(strings.TrimSpace(suite.stdout.String()), "\n")
+	assert.Len(suite.T(), lines, len(rows))
+	row := &appstore.SalesReport{}
+	assert.NoError(suite.T(), json.Unmarshal([]byte(lines[0]), row))
+	assert.Equal(suite.T(), "foo.bar.baz", row.SKU)
+
+	assert.Equal(suite.T(), exitOK, suite.run("sales", "--date", "2020-05-05", "--format", "csv"))
+	assert.True(suite.T(), strings.HasPrefix(suite.stdout.String(), "Provider,Provider Country,SKU,"))
+
+	assert.Equal(suite.T(), exitOK, suite.run("sales", "--date", "2020-05-05", "--format", "raw"))
+	assert.Equal(suite.T(), []byte{0x1f, 0x8b}, suite.stdout.Bytes()[:2])
+}
+
+func (suite *MainTestSuite) TestOutputFile() {
+	suite.addSalesReport("2020-05-05")
+	path := filepath.Join(suite.dir, "sales.tsv")
+	assert.Equal(suite.T(), exitOK, suite.run("sales", "--date", "2020-05-05", "--format", "tsv", "--output", path))
+	assert.Empty(suite.T(), suite.stdout.String())
+	data, _ := ioutil.ReadFile(path)
+	assert.True(suite.T(), strings.HasPrefix(string(data), "Provider\tProvider Country\tSKU\t"))
+}
+
+func (suite *MainTestSuite) TestOutputFileRemovedOnError() {
+	path := filepath.Join(suite.dir, "sales.tsv")
+	assert.Equal(suite.T(), exitNotAvailable, suite.run("sales", "--date", "2020-05-05", "--format", "tsv", "--output", path))
+	_, err := os.Stat(path)
+	assert.True(suite.T(), os.IsNotExist(err))
+	suite.addSalesReport("2020-05-05")
+	suite.server.SimulateServerError(appstoretest.SalesReportsPath, 1)
+	assert.Equal(suite.T(), exitError, suite.run("sales", "--date", "2020-05-05", "--format", "raw", "--output", path))
+	_, err = os.Stat(path)
+	assert.True(suite.T(), os.IsNotExist(err))
+}
+
+func (suite *MainTestSuite) TestNotAvailable() {
+	assert.Equal(suite.T(), exitNotAvailable, suite.run("sales", "--date", "2020-05-05"))
+	assert.Contains(suite.T(), suite.stderr.String(), "report is not available")
+	assert.Equal(suite.T(), exitNotAvailable, suite.run("sales", "--date", "2020-05-05", "--format", "raw"))
+	assert.Equal(suite.T(), exitNotAvailable, suite.run("finance", "--date", "2020-05"))
+}
+
+func (suite *MainTestSuite) TestServerError() {
+	suite.addSalesReport("2020-05-05")
+	suite.server.SimulateServerError(appstoretest.SalesReportsPath, 1)
+	assert.Equal(suite.T(), exitError, suite.run("sales", "--date", "2020-05-05"))
+}
+
+func (suite *MainTestSuite) TestFinance() {
+	data, _ := ioutil.ReadFile("../../stubs/reports/finances/financial.tsv")
+	date, _ := time.Parse("2006-01", "2020-05")
+	filter := appstore.NewFinancesReportsFilter()
+	filter.SetReportDate(date).SetRegionCode("US")
+	suite.server.AddFinancesReport(filter, data)
+
+	assert.Equal(suite.T(), exitOK, suite.run("finance", "--date", "2020-05", "--region", "US", "--format", "json"))
+	rows := []*appstore.FinancialReport{}
+	assert.NoError(suite.T(), json.Unmarshal(suite.stdout.Bytes(), &rows))
+	assert.Len(suite.T(), rows, 2)
+}
+
+func (suite *MainTestSuite) TestBackfill() {
+	suite.addSalesReport("2020-05-05")
+	dir := filepath.Join(suite.dir, "reports")
+	assert.Equal(suite.T(), exitOK, suite.run("backfill", "--report", "sales", "--from", "2020-05-05", "--to", "2020-05-06", "--dir", dir))
+	assert.Contains(suite.T(), suite.stdout.String(), "sales 2020-05-05: saved")
+	assert.Contains(suite.T(), suite.stdout.String(), "sales 2020-05-06: saved")
+	_, err := os.Stat(filepath.Join(dir, "sales_2020-05-05.tsv"))
+	assert.NoError(suite.T(), err)
+	_, err = os.Stat(filepath.Join(dir, "sales_2020-05-06.tsv"))
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *MainTestSuite) TestBackfillNotAvailable() {
+	assert.Equal(suite.T(), exitNotAvailable, suite.run("backfill", "--report", "finance", "--from", "2020-05", "--to", "2020-06", "--dir", suite.dir))
+	assert.Contains(suite.T(), suite.stderr.String(), "finance 2020-05: not available")
+	assert.Contains(suite.T(), suite.stderr.String(), "finance 2020-06: not available")
+	files, _ := ioutil.ReadDir(suite.dir)
+	assert.Empty(suite.T(), files)
+}
+
+func (suite *MainTestSuite) TestBackfillError() {
+	suite.addSalesReport("2020-05-05")
+	suite.server.SimulateServerError(appstoretest.SalesReportsPath, 1)
+	assert.Equal(suite.T(), exitError, suite.run("backfill", "--from", "2020-05-05", "--to", "2020-05-06", "--dir", suite.dir))
+	assert.Contains(suite.T(), suite.stderr.String(), "sales 2020-05-05: error")
+	assert.Contains(suite.T(), suite.stdout.String(), "sales 2020-05-06: saved")
+}
+
+func (suite *MainTestSuite) TestBackfillTimeoutPerReport() {
+	suite.addSalesReport("2020-05-05")
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(150 * time.Millisecond)
+		suite.server.ServeHTTP(w, r)
+	}))
+	defer slow.Close()
+	suite.env["APPSTORE_URI"] = slow.URL
+	args := []string{"backfill", "--from", "2020-05-05", "--to", "2020-05-07", "--dir", suite.dir, "--timeout", "400ms"}
+	assert.Equal(suite.T(), exitOK, suite.run(args...))
+	assert.Empty(suite.T(), suite.stderr.String())
+	assert.Contains(suite.T(), suite.stdout.String(), "sales 2020-05-07: saved")
+}
+
+func (suite *MainTestSuite) TestVerifyCredentials() {
+	assert.Equal(suite.T(), exitOK, suite.run("verify-credentials"))
+	assert.Contains(suite.T(), suite.stdout.String(), "credentials are valid for vendor 12345678")
+}
+
+func (suite *MainTestSuite) TestVerifyCredentialsInvalid() {
+	key, _, _ := appstoretest.GenerateKey()
+	server := appstoretest.NewServer(&key.PublicKey, "12345678")
+	defer server.Close()
+	suite.env["APPSTORE_URI"] = server.Config("").Uri
+	assert.Equal(suite.T(), exitError, suite.run("verify-credentials"))
+	assert.Contains(suite.T(), suite.stderr.String(), "credentials are not valid")
+}
+
+func TestMainTestSuite(t *testing.T) {
+	suite.Run(t, new(MainTestSuite))
+}
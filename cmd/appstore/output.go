@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/matisiekpl/appstore-sdk-go/columnar"
+	"io"
+	"text/tabwriter"
+)
+
+//format output format
+type format string
+
+const (
+	formatTable   format = "table"
+	formatJSON    format = "json"
+	formatNDJSON  format = "ndjson"
+	formatCSV     format = "csv"
+	formatTSV     format = "tsv"
+	formatParquet format = "parquet"
+	formatArrow   format = "arrow"
+	formatRaw     format = "raw"
+)
+
+//formats supported output formats
+var formats = []format{formatTable, formatJSON, formatNDJSON, formatCSV, formatTSV, formatParquet, formatArrow, formatRaw}
+
+//parseFormat Parse output format
+func parseFormat(value string) (format, error) {
+	for _, f := range formats {
+		if string(f) == value {
+			return f, nil
+		}
+	}
+	return "", &usageError{fmt.Sprintf("unknown format %s, expected one of %v", value, formats)}
+}
+
+//extension Get file extension of format
+func (f format) extension() string {
+	switch f {
+	case formatTable:
+		return "txt"
+	case formatRaw:
+		return "tsv.gz"
+	}
+	return string(f)
+}
+
+//writeRows Write report rows slice in format, raw format is written by caller
+func writeRows(out io.Writer, f format, rows interface{}) error {
+	switch f {
+	case formatJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	case formatNDJSON:
+		return appstore.WriteNDJSON(out, rows)
+	case formatTSV:
+		return appstore.WriteCSV(out, rows)
+	case formatParquet:
+		return columnar.WriteParquet(out, rows)
+	case formatArrow:
+		return columnar.WriteArrow(out, rows)
+	case formatCSV, formatTable:
+		records, err := records(rows)
+		if err != nil {
+			return err
+		}
+		if f == formatCSV {
+			w := csv.NewWriter(out)
+			if err = w.WriteAll(records); err != nil {
+				return err
+			}
+			return w.Error()
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, record := range records {
+			for i, value := range record {
+				if i > 0 {
+					_, _ = fmt.Fprint(w, "\t")
+				}
+				_, _ = fmt.Fprint(w, value)
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+		return w.Flush()
+	}
+	return fmt.Errorf("format %s is not supported for report rows", f)
+}
+
+//records Get report rows as text records in Apple's format, header first
+func records(rows interface{}) ([][]string, error) {
+	data, err := appstore.MarshalCSV(rows)
+	if err != nil {
+		return nil, err
+	}
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = '\t'
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	return r.ReadAll()
+}
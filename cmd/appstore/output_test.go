@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type OutputTestSuite struct {
+	suite.Suite
+	rows []*appstore.PreOrdersReport
+}
+
+func (suite *OutputTestSuite) SetupTest() {
+	suite.rows = []*appstore.PreOrdersReport{
+		{Provider: "APPLE", SKU: "foo, bar", Title: "Foo"},
+		{Provider: "APPLE", SKU: "baz", Title: "Baz"},
+	}
+}
+
+func (suite *OutputTestSuite) write(f format) string {
+	buf := &bytes.Buffer{}
+	assert.NoError(suite.T(), writeRows(buf, f, suite.rows))
+	return buf.String()
+}
+
+func (suite *OutputTestSuite) TestParseFormat() {
+	f, err := parseFormat("ndjson")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), formatNDJSON, f)
+	_, err = parseFormat("xml")
+	assert.IsType(suite.T(), &usageError{}, err)
+}
+
+func (suite *OutputTestSuite) TestExtension() {
+	assert.Equal(suite.T(), "txt", formatTable.extension())
+	assert.Equal(suite.T(), "tsv.gz", formatRaw.extension())
+	assert.Equal(suite.T(), "csv", formatCSV.extension())
+}
+
+func (suite *OutputTestSuite) TestWriteNDJSON() {
+	lines := strings.Split(strings.TrimSpace(suite.write(formatNDJSON)), "\n")
+	assert.Len(suite.T(), lines, 2)
+	assert.Contains(suite.T(), lines[1], `"sku":"baz"`)
+}
+
+func (suite *OutputTestSuite) TestWriteCSV() {
+	lines := strings.Split(strings.TrimSpace(suite.write(formatCSV)), "\n")
+	assert.Len(suite.T(), lines, 3)
+	assert.True(suite.T(), strings.HasPrefix(lines[0], "Provider,Provider Country,SKU,Developer,Title,"))
+	assert.True(suite.T(), strings.HasPrefix(lines[1], `APPLE,,"foo, bar",,Foo,`))
+}
+
+func (suite *OutputTestSuite) TestWriteTable() {
+	lines := strings.Split(suite.write(formatTable), "\n")
+	assert.True(suite.T(), strings.HasPrefix(lines[0], "Provider  Provider Country  SKU       Developer  Title"))
+	assert.True(suite.T(), strings.HasPrefix(lines[2], "APPLE                       baz                  Baz"))
+}
+
+func (suite *OutputTestSuite) TestWriteColumnar() {
+	output := suite.write(formatParquet)
+	assert.True(suite.T(), strings.HasPrefix(output, "PAR1"))
+	assert.True(suite.T(), strings.HasSuffix(output, "PAR1"))
+	output = suite.write(formatArrow)
+	assert.True(suite.T(), strings.HasPrefix(output, "ARROW1"))
+	assert.Equal(suite.T(), "parquet", formatParquet.extension())
+}
+
+func (suite *OutputTestSuite) TestWriteRawUnsupported() {
+	assert.Error(suite.T(), writeRows(&bytes.Buffer{}, formatRaw, suite.rows))
+}
+
+func TestOutputTestSuite(t *testing.T) {
+	suite.Run(t, new(OutputTestSuite))
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/matisiekpl/appstore-sdk-go/ingest"
+	"net/http"
+	"strings"
+	"time"
+)
+
+//reportOptions report request options
+type reportOptions struct {
+	date        string
+	frequency   string
+	version     string
+	region      string
+	financeType string
+}
+
+//register Register report options flags used by report
+func (o *reportOptions) register(fs *flag.FlagSet, r *report) {
+	if r.finance {
+		fs.StringVar(&o.region, "region", "", "financial report region code, e.g. US, EU, WW (default ZZ, Z1 for finance_detail)")
+		fs.StringVar(&o.financeType, "type", "financial", "financial report type: financial or finance_detail")
+		return
+	}
+	if r.frequencies {
+		fs.StringVar(&o.frequency, "frequency", "daily", "report frequency: daily, weekly, monthly or yearly")
+	}
+	fs.StringVar(&o.version, "version", r.version, "report version")
+}
+
+//request report request
+type request struct {
+	rows func(ctx context.Context, client *appstore.Client) (interface{}, *http.Response, error)
+	raw  func(ctx context.Context, client *appstore.Client) (*http.Response, error)
+}
+
+//report downloadable report kind
+type report struct {
+	name        string
+	description string
+	version     string //default version
+	frequencies bool   //report supports frequencies other than daily
+	finance     bool   //monthly financial report
+	reportType  appstore.SalesReportType
+	subType     appstore.SalesReportSubType
+	build       func(base appstore.SalesReportsBaseFilter, o *reportOptions) *request
+}
+
+//reports downloadable reports by command name
+var reports = []*report{
+	{name: "sales", description: "Download sales report", version: string(appstore.SalesReportVersion10), frequencies: true, reportType: appstore.SalesReportTypeSales, subType: appstore.SalesReportSubTypeSummary, build: buildSalesRequest},
+	{name: "subscriptions", description: "Download subscriptions report", version: string(appstore.SalesReportVersion13), reportType: appstore.SalesReportTypeSubscription, subType: appstore.SalesReportSubTypeSummary, build: buildSubscriptionsRequest},
+	{name: "subscription-events", description: "Download subscription events report", version: string(appstore.SalesReportVersion13), reportType: appstore.SalesReportTypeSubscriptionEvent, subType: appstore.SalesReportSubTypeSummary, build: buildSubscriptionsEventsRequest},
+	{name: "subscribers", description: "Download subscribers report", version: string(appstore.SalesReportVersion13), reportType: appstore.SalesReportTypeSubscriber, subType: appstore.SalesReportSubTypeDetailed, build: buildSubscribersRequest},
+	{name: "preorders", description: "Download pre-orders report", version: string(appstore.SalesReportVersion10), frequencies: true, reportType: appstore.SalesReportTypePreorder, subType: appstore.SalesReportSubTypeSummary, build: buildPreOrdersRequest},
+	{name: "finance", description: "Download financial report", finance: true},
+}
+
+//findReport Get report by name
+func findReport(name string) (*report, bool) {
+	for _, r := range reports {
+		if r.name == name {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+//reportNames Get names of reports
+func reportNames() []string {
+	names := make([]string, 0, len(reports))
+	for _, r := range reports {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+//defaultDate Get default report date, yesterday or previous month for financial report
+func (r *report) defaultDate(now time.Time) time.Time {
+	now = now.UTC()
+	if r.finance {
+		return time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC)
+}
+
+//next Get date of next report of frequency
+func (r *report) next(date time.Time, o *reportOptions) time.Time {
+	if r.finance {
+		return date.AddDate(0, 1, 0)
+	}
+	switch appstore.SalesReportFrequency(strings.ToUpper(o.frequency)) {
+	case appstore.SalesReportFrequencyWeekly:
+		return date.AddDate(0, 0, 7)
+	case appstore.SalesReportFrequencyMonthly:
+		return date.AddDate(0, 1, 0)
+	case appstore.SalesReportFrequencyYearly:
+		return date.AddDate(1, 0, 0)
+	}
+	return date.AddDate(0, 0, 1)
+}
+
+//label Get report date label
+func (r *report) label(date time.Time, o *reportOptions) string {
+	if r.finance {
+		return date.Format("2006-01")
+	}
+	switch appstore.SalesReportFrequency(strings.ToUpper(o.frequency)) {
+	case appstore.SalesReportFrequencyMonthly:
+		return date.Format("2006-01")
+	case appstore.SalesReportFrequencyYearly:
+		return date.Format("2006")
+	}
+	return date.Format("2006-01-02")
+}
+
+//request Build report request of date
+func (r *report) request(date time.Time, o *reportOptions) (*request, error) {
+	if r.finance {
+		return buildFinanceRequest(date, o)
+	}
+	base := appstore.SalesReportsBaseFilter{}
+	base.SetReportDate(date).SetVersion(appstore.SalesReportVersion(o.version)).Daily()
+	if r.frequencies {
+		frequency := appstore.SalesReportFrequency(strings.ToUpper(o.frequency))
+		switch frequency {
+		case appstore.SalesReportFrequencyDaily, appstore.SalesReportFrequencyWeekly, appstore.SalesReportFrequencyMonthly, appstore.SalesReportFrequencyYearly:
+			base.SetFrequency(frequency)
+		default:
+			return nil, &usageError{fmt.Sprintf("unknown frequency %s", o.frequency)}
+		}
+	}
+	return r.build(base, o), nil
+}
+
+//stream Build sync stream of report from start date
+func (r *report) stream(start time.Time, o *reportOptions) (*ingest.Stream, error) {
+	if r.finance {
+		filter, err := financeFilter(start, o)
+		if err != nil {
+			return nil, err
+		}
+		return &ingest.Stream{Key: ingest.Key{ReportType: string(filter.ReportType), RegionCode: filter.RegionCode}, Start: start}, nil
+	}
+	frequency := "daily"
+	if r.frequencies {
+		frequency = o.frequency
+	}
+	switch appstore.SalesReportFrequency(strings.ToUpper(frequency)) {
+	case appstore.SalesReportFrequencyDaily, appstore.SalesReportFrequencyWeekly, appstore.SalesReportFrequencyMonthly, appstore.SalesReportFrequencyYearly:
+	default:
+		return nil, &usageError{fmt.Sprintf("unknown frequency %s", frequency)}
+	}
+	key := ingest.Key{
+		ReportType:    string(r.reportType),
+		ReportSubType: string(r.subType),
+		Version:       firstNonEmpty(o.version, r.version),
+		Frequency:     strings.ToUpper(frequency),
+	}
+	return &ingest.Stream{Key: key, Start: start}, nil
+}
+
+//buildSalesRequest Build sales report request
+func buildSalesRequest(base appstore.SalesReportsBaseFilter, o *reportOptions) *request {
+	filter := appstore.NewSalesReportsFilter()
+	base.ReportType = filter.ReportType
+	filter.SalesReportsBaseFilter = base
+	filter.SubTypeSummary()
+	return &request{
+		rows: func(ctx context.Context, client *appstore.Client) (interface{}, *http.Response, error) {
+			result, resp, err := client.SalesReports().GetSalesReports(ctx, filter)
+			if err != nil {
+				return nil, resp, err
+			}
+			return result.Data, resp, nil
+		},
+		raw: func(ctx context.Context, client *appstore.Client) (*http.Response, error) {
+			return client.SalesReports().GetReports(ctx, filter)
+		},
+	}
+}
+
+//buildSubscriptionsRequest Build subscriptions report request
+func buildSubscriptionsRequest(base appstore.SalesReportsBaseFilter, o *reportOptions) *request {
+	filter := appstore.NewSubscriptionsReportsFilter()
+	base.ReportType = filter.ReportType
+	filter.SalesReportsBaseFilter = base
+	filter.SubTypeSummary()
+	return &request{
+		rows: func(ctx context.Context, client *appstore.Client) (interface{}, *http.Response, error) {
+			result, resp, err := client.SalesReports().GetSubscriptionsReports(ctx, filter)
+			if err != nil {
+				return nil, resp, err
+			}
+			return result.Data, resp, nil
+		},
+		raw: func(ctx context.Context, client *appstore.Client) (*http.Response, error) {
+			return client.SalesReports().GetReports(ctx, filter)
+		},
+	}
+}
+
+//buildSubscriptionsEventsRequest Build subscription events report request
+func buildSubscriptionsEventsRequest(base appstore.SalesReportsBaseFilter, o *reportOptions) *request {
+	filter := appstore.NewSubscriptionsEventsReportsFilter()
+	base.ReportType = filter.ReportType
+	filter.SalesReportsBaseFilter = base
+	filter.SubTypeSummary()
+	return &request{
+		rows: func(ctx context.Context, client *appstore.Client) (interface{}, *http.Response, error) {
+			result, resp, err := client.SalesReports().GetSubscriptionsEventsReports(ctx, filter)
+			if err != nil {
+				return nil, resp, err
+			}
+			return result.Data, resp, nil
+		},
+		raw: func(ctx context.Context, client *appstore.Client) (*http.Response, error) {
+			return client.SalesReports().GetReports(ctx, filter)
+		},
+	}
+}
+
+//buildSubscribersRequest Build subscribers report request
+func buildSubscribersRequest(base appstore.SalesReportsBaseFilter, o *reportOptions) *request {
+	filter := appstore.NewSubscribersReportsFilter()
+	base.ReportType = filter.ReportType
+	filter.SalesReportsBaseFilter = base
+	filter.SubTypeDetailed()
+	return &request{
+		rows: func(ctx context.Context, client *appstore.Client) (interface{}, *http.Response, error) {
+			result, resp, err := client.SalesReports().GetSubscribersReports(ctx, filter)
+			if err != nil {
+				return nil, resp, err
+			}
+			return result.Data, resp, nil
+		},
+		raw: func(ctx context.Context, client *appstore.Client) (*http.Response, error) {
+			return client.SalesReports().GetReports(ctx, filter)
+		},
+	}
+}
+
+//buildPreOrdersRequest Build pre-orders report request
+func buildPreOrdersRequest(base appstore.SalesReportsBaseFilter, o *reportOptions) *request {
+	filter := appstore.NewPreOrdersReportsFilter()
+	base.ReportType = filter.ReportType
+	filter.SalesReportsBaseFilter = base
+	filter.SubTypeSummary()
+	return &request{
+		rows: func(ctx context.Context, client *appstore.Client) (interface{}, *http.Response, error) {
+			result, resp, err := client.SalesReports().GetPreOrdersReports(ctx, filter)
+			if err != nil {
+				return nil, resp, err
+			}
+			return result.Data, resp, nil
+		},
+		raw: func(ctx context.Context, client *appstore.Client) (*http.Response, error) {
+			return client.SalesReports().GetReports(ctx, filter)
+		},
+	}
+}
+
+//financeFilter Build financial report filter of options
+func financeFilter(date time.Time, o *reportOptions) (*appstore.FinancesReportsFilter, error) {
+	filter := appstore.NewFinancesReportsFilter().SetReportDate(date)
+	switch strings.ToLower(o.financeType) {
+	case "", "financial":
+		filter.TypeFinancial().SetRegionCode(firstNonEmpty(o.region, appstore.FinanceRegionCodeAll))
+	case "finance_detail", "finance-detail":
+		filter.TypeFinanceDetail().SetRegionCode(firstNonEmpty(o.region, appstore.FinanceRegionCodeFinanceDetail))
+	default:
+		return nil, &usageError{fmt.Sprintf("unknown financial report type %s", o.financeType)}
+	}
+	if err := appstore.ValidateRegionCode(filter.RegionCode); err != nil {
+		return nil, &usageError{err.Error()}
+	}
+	return filter, nil
+}
+
+//buildFinanceRequest Build financial report request
+func buildFinanceRequest(date time.Time, o *reportOptions) (*request, error) {
+	filter, err := financeFilter(date, o)
+	if err != nil {
+		return nil, err
+	}
+	return &request{
+		rows: func(ctx context.Context, client *appstore.Client) (interface{}, *http.Response, error) {
+			result, resp, err := client.FinancesReports().GetFinancialReports(ctx, filter)
+			if err != nil {
+				return nil, resp, err
+			}
+			return result.Data, resp, nil
+		},
+		raw: func(ctx context.Context, client *appstore.Client) (*http.Response, error) {
+			return client.FinancesReports().GetReports(ctx, filter)
+		},
+	}, nil
+}
+
+//parseDate Parse report date in any of YYYY-MM-DD, YYYY-MM, YYYY formats
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "2006-01", "2006"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, &usageError{fmt.Sprintf("invalid date %s, expected YYYY-MM-DD, YYYY-MM or YYYY", value)}
+}
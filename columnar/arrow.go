@@ -0,0 +1,323 @@
+package columnar
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+//ArrowBatchSizeDefault const, rows of record batch
+const ArrowBatchSizeDefault = 65536
+
+//arrowMagic Arrow IPC file header and footer magic
+var arrowMagic = []byte("ARROW1")
+
+//arrowContinuation Arrow IPC encapsulated message continuation marker
+const arrowContinuation uint32 = 0xffffffff
+
+//arrowMetadataVersion const, Arrow columnar format V5
+const arrowMetadataVersion int16 = 4
+
+//Arrow schema type union
+const (
+	arrowTypeInt           uint8 = 2
+	arrowTypeFloatingPoint uint8 = 3
+	arrowTypeUtf8          uint8 = 5
+	arrowTypeBool          uint8 = 6
+	arrowTypeDecimal       uint8 = 7
+	arrowTypeDate          uint8 = 8
+	arrowTypeTimestamp     uint8 = 10
+)
+
+//Arrow message header union
+const (
+	arrowMessageSchema      uint8 = 1
+	arrowMessageRecordBatch uint8 = 3
+)
+
+//arrowBlock record batch location of file footer
+type arrowBlock struct {
+	offset         int64
+	metadataLength int32
+	bodyLength     int64
+}
+
+//ArrowWriter writer of report rows to Arrow IPC file, or IPC stream when Stream is set.
+//Rows are buffered until BatchSize rows are written or Flush is called, file footer is written by Close.
+//Every column is nullable, money values are decimal128 of column precision and scale
+type ArrowWriter struct {
+	Schema    *Schema
+	BatchSize int
+	Stream    bool //write IPC stream format without file magic and footer
+	out       io.Writer
+	offset    int64
+	buffer    *rowBuffer
+	blocks    []arrowBlock
+	closed    bool
+}
+
+//NewArrowWriter Create new Arrow IPC file writer of schema rows
+func NewArrowWriter(out io.Writer, schema *Schema) *ArrowWriter {
+	return &ArrowWriter{Schema: schema, BatchSize: ArrowBatchSizeDefault, out: out}
+}
+
+//Write Write report row, row is struct of schema or pointer to it
+func (w *ArrowWriter) Write(row interface{}) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if err := w.buffer.add(row); err != nil {
+		return fmt.Errorf("ArrowWriter.Write: %v", err)
+	}
+	if w.BatchSize > 0 && w.buffer.rows >= w.BatchSize {
+		return w.Flush()
+	}
+	return nil
+}
+
+//WriteRows Write slice of report rows
+func (w *ArrowWriter) WriteRows(rows interface{}) error {
+	return forEach(rows, w.Write)
+}
+
+//Flush Write buffered rows as record batch
+func (w *ArrowWriter) Flush() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if w.buffer.rows == 0 {
+		return nil
+	}
+	nodes := make([]byte, 0, 16*len(w.Schema.Columns))
+	buffers := make([]byte, 0)
+	body := make([]byte, 0)
+	for i, column := range w.Schema.Columns {
+		values := w.buffer.columns[i]
+		validity, nulls := arrowValidity(values)
+		nodes = appendInt64s(nodes, int64(len(values)), int64(nulls))
+		for _, data := range append([][]byte{validity}, arrowBuffers(column, values)...) {
+			buffers = appendInt64s(buffers, int64(len(body)), int64(len(data)))
+			body = append(body, data...)
+			body = append(body, make([]byte, padding(len(data), 8))...)
+		}
+	}
+	batch := newFbTable(4).
+		int64(0, int64(w.buffer.rows)).
+		object(1, &fbStructs{data: nodes, size: 16, align: 8}).
+		object(2, &fbStructs{data: buffers, size: 16, align: 8})
+	block, err := w.writeMessage(arrowMessageRecordBatch, batch, body)
+	if err != nil {
+		return fmt.Errorf("ArrowWriter.Flush: %v", err)
+	}
+	w.blocks = append(w.blocks, block)
+	w.buffer.reset()
+	return nil
+}
+
+//Close Write buffered rows, end of stream marker and file footer, underlying writer is not closed
+func (w *ArrowWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	w.closed = true
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint32(data, arrowContinuation)
+	if !w.Stream {
+		blocks := make([]byte, 0, 24*len(w.blocks))
+		for _, block := range w.blocks {
+			blocks = appendInt64s(blocks, block.offset, int64(block.metadataLength), block.bodyLength)
+		}
+		footer := fbFinish(newFbTable(5).
+			int16(0, arrowMetadataVersion).
+			object(1, w.schemaTable()).
+			object(2, &fbStructs{size: 24, align: 8}).
+			object(3, &fbStructs{data: blocks, size: 24, align: 8}))
+		data = append(data, footer...)
+		data = append(data, 0, 0, 0, 0)
+		binary.LittleEndian.PutUint32(data[len(data)-4:], uint32(len(footer)))
+		data = append(data, arrowMagic...)
+	}
+	if err := w.write(data); err != nil {
+		return fmt.Errorf("ArrowWriter.Close: %v", err)
+	}
+	return nil
+}
+
+//start Validate schema and write file header and schema message before first record batch
+func (w *ArrowWriter) start() error {
+	if w.closed {
+		return fmt.Errorf("ArrowWriter: writer is closed")
+	}
+	if w.buffer != nil {
+		return nil
+	}
+	if err := w.Schema.validate(); err != nil {
+		return fmt.Errorf("ArrowWriter: %v", err)
+	}
+	w.buffer = newRowBuffer(w.Schema)
+	if !w.Stream {
+		if err := w.write(append(append([]byte{}, arrowMagic...), 0, 0)); err != nil {
+			return fmt.Errorf("ArrowWriter: %v", err)
+		}
+	}
+	if _, err := w.writeMessage(arrowMessageSchema, w.schemaTable(), nil); err != nil {
+		return fmt.Errorf("ArrowWriter: %v", err)
+	}
+	return nil
+}
+
+//write Write data and track file offset
+func (w *ArrowWriter) write(data []byte) error {
+	n, err := w.out.Write(data)
+	w.offset += int64(n)
+	return err
+}
+
+//writeMessage Write encapsulated message: continuation marker, metadata length, Message flatbuffer and body
+func (w *ArrowWriter) writeMessage(headerType uint8, header *fbTable, body []byte) (arrowBlock, error) {
+	metadata := fbFinish(newFbTable(5).
+		int16(0, arrowMetadataVersion).
+		uint8(1, headerType).
+		object(2, header).
+		int64(3, int64(len(body))))
+	block := arrowBlock{offset: w.offset, metadataLength: int32(8 + len(metadata)), bodyLength: int64(len(body))}
+	prefix := make([]byte, 8)
+	binary.LittleEndian.PutUint32(prefix, arrowContinuation)
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(len(metadata)))
+	for _, data := range [][]byte{prefix, metadata, body} {
+		if err := w.write(data); err != nil {
+			return block, err
+		}
+	}
+	return block, nil
+}
+
+//schemaTable Build Schema table of columns
+func (w *ArrowWriter) schemaTable() *fbTable {
+	fields := make(fbTables, 0, len(w.Schema.Columns))
+	for _, column := range w.Schema.Columns {
+		typeType, typeTable := arrowType(column)
+		fields = append(fields, newFbTable(7).
+			object(0, fbString(column.Name)).
+			bool(1, true).
+			uint8(2, typeType).
+			object(3, typeTable).
+			object(5, fbTables{}))
+	}
+	return newFbTable(4).int16(0, 0).object(1, fields)
+}
+
+//arrowType Get Arrow type of column
+func arrowType(column *Column) (uint8, *fbTable) {
+	switch column.Type {
+	case ColumnTypeInteger:
+		return arrowTypeInt, newFbTable(2).int32(0, 64).bool(1, true)
+	case ColumnTypeFloat:
+		return arrowTypeFloatingPoint, newFbTable(1).int16(0, 2) //DOUBLE
+	case ColumnTypeDecimal:
+		return arrowTypeDecimal, newFbTable(3).int32(0, int32(column.Precision)).int32(1, int32(column.Scale)).int32(2, 128)
+	case ColumnTypeDate:
+		return arrowTypeDate, newFbTable(1).int16(0, 0) //DAY
+	case ColumnTypeTimestamp:
+		return arrowTypeTimestamp, newFbTable(2).int16(0, 2).object(1, fbString("UTC")) //MICROSECOND
+	case ColumnTypeBoolean:
+		return arrowTypeBool, newFbTable(0)
+	}
+	return arrowTypeUtf8, newFbTable(0)
+}
+
+//arrowValidity Build validity bitmap, bitmap is omitted without nulls
+func arrowValidity(values []value) ([]byte, int) {
+	nulls := 0
+	bitmap := make([]byte, (len(values)+7)/8)
+	for i, v := range values {
+		if v.null {
+			nulls++
+			continue
+		}
+		bitmap[i/8] |= 1 << uint(i%8)
+	}
+	if nulls == 0 {
+		return nil, 0
+	}
+	return bitmap, nulls
+}
+
+//arrowBuffers Build data buffers of column, null slots are zero
+func arrowBuffers(column *Column, values []value) [][]byte {
+	switch column.Type {
+	case ColumnTypeString:
+		offsets := make([]byte, 4*(len(values)+1))
+		data := make([]byte, 0)
+		for i, v := range values {
+			data = append(data, v.text...)
+			binary.LittleEndian.PutUint32(offsets[4*(i+1):], uint32(len(data)))
+		}
+		return [][]byte{offsets, data}
+	case ColumnTypeBoolean:
+		bitmap := make([]byte, (len(values)+7)/8)
+		for i, v := range values {
+			if v.boolean {
+				bitmap[i/8] |= 1 << uint(i%8)
+			}
+		}
+		return [][]byte{bitmap}
+	case ColumnTypeDate:
+		data := make([]byte, 4*len(values))
+		for i, v := range values {
+			binary.LittleEndian.PutUint32(data[4*i:], uint32(int32(v.integer)))
+		}
+		return [][]byte{data}
+	case ColumnTypeFloat:
+		data := make([]byte, 8*len(values))
+		for i, v := range values {
+			binary.LittleEndian.PutUint64(data[8*i:], math.Float64bits(v.float))
+		}
+		return [][]byte{data}
+	case ColumnTypeDecimal:
+		//128 bit two's complement, high word is sign extension of 64 bit unscaled value
+		data := make([]byte, 16*len(values))
+		for i, v := range values {
+			binary.LittleEndian.PutUint64(data[16*i:], uint64(v.integer))
+			binary.LittleEndian.PutUint64(data[16*i+8:], uint64(v.integer>>63))
+		}
+		return [][]byte{data}
+	}
+	data := make([]byte, 8*len(values))
+	for i, v := range values {
+		binary.LittleEndian.PutUint64(data[8*i:], uint64(v.integer))
+	}
+	return [][]byte{data}
+}
+
+//appendInt64s Append little endian int64 values
+func appendInt64s(data []byte, values ...int64) []byte {
+	var buf [8]byte
+	for _, v := range values {
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		data = append(data, buf[:]...)
+	}
+	return data
+}
+
+//padding Get number of bytes padding size to alignment
+func padding(size int, align int) int {
+	return (align - size%align) % align
+}
+
+//WriteArrow Write slice of report rows to Arrow IPC file
+func WriteArrow(out io.Writer, rows interface{}) error {
+	schema, err := NewSchema(rows)
+	if err != nil {
+		return fmt.Errorf("WriteArrow: %v", err)
+	}
+	w := NewArrowWriter(out, schema)
+	if err = w.WriteRows(rows); err != nil {
+		return fmt.Errorf("WriteArrow: %v", err)
+	}
+	return w.Close()
+}
@@ -0,0 +1,325 @@
+package columnar
+
+import (
+	"bytes"
+	"encoding/binary"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/matisiekpl/appstore-sdk-go/generator"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"math"
+	"testing"
+	"time"
+)
+
+//fbReader FlatBuffers decoder, positions are absolute in buffer
+type fbReader []byte
+
+func (b fbReader) u16(pos int) int {
+	return int(binary.LittleEndian.Uint16(b[pos:]))
+}
+
+func (b fbReader) u32(pos int) int {
+	return int(binary.LittleEndian.Uint32(b[pos:]))
+}
+
+func (b fbReader) i64(pos int) int64 {
+	return int64(binary.LittleEndian.Uint64(b[pos:]))
+}
+
+func (b fbReader) root() int {
+	return b.u32(0)
+}
+
+//field Get position of table field, 0 when field is not set
+func (b fbReader) field(table int, slot int) int {
+	vtable := table - int(int32(b.u32(table)))
+	if 4+2*slot >= b.u16(vtable) {
+		return 0
+	}
+	offset := b.u16(vtable + 4 + 2*slot)
+	if offset == 0 {
+		return 0
+	}
+	return table + offset
+}
+
+func (b fbReader) deref(pos int) int {
+	return pos + b.u32(pos)
+}
+
+func (b fbReader) str(table int, slot int) string {
+	pos := b.deref(b.field(table, slot))
+	return string(b[pos+4 : pos+4+b.u32(pos)])
+}
+
+//tables Get positions of vector of tables
+func (b fbReader) tables(table int, slot int) []int {
+	pos := b.deref(b.field(table, slot))
+	result := make([]int, b.u32(pos))
+	for i := range result {
+		result[i] = b.deref(pos + 4 + 4*i)
+	}
+	return result
+}
+
+//structs Get start position and length of vector of structs
+func (b fbReader) structs(table int, slot int) (int, int) {
+	pos := b.deref(b.field(table, slot))
+	return pos + 4, b.u32(pos)
+}
+
+//arrowField decoded schema field
+type arrowField struct {
+	name     string
+	nullable bool
+	typ      int
+	attrs    []int64 //scalar attributes of type table
+}
+
+//arrowFile decoded Arrow IPC file or stream
+type arrowFile struct {
+	fields  []*arrowField
+	batches int
+	columns map[string][]interface{}
+}
+
+//readArrowSchema Decode Schema table
+func readArrowSchema(t *testing.T, b fbReader, schema int) []*arrowField {
+	fields := []*arrowField{}
+	for _, pos := range b.tables(schema, 1) {
+		field := &arrowField{name: b.str(pos, 0), nullable: b[b.field(pos, 1)] == 1, typ: int(b[b.field(pos, 2)])}
+		typ := b.deref(b.field(pos, 3))
+		for slot := 0; slot < 3; slot++ {
+			at := b.field(typ, slot)
+			switch {
+			case at == 0:
+			case field.typ == int(arrowTypeTimestamp) && slot == 1:
+				field.attrs = append(field.attrs, int64(len(b.str(typ, 1))))
+			case field.typ == int(arrowTypeInt) && slot == 1:
+				field.attrs = append(field.attrs, int64(b[at]))
+			case field.typ == int(arrowTypeFloatingPoint) || field.typ == int(arrowTypeDate) || field.typ == int(arrowTypeTimestamp):
+				field.attrs = append(field.attrs, int64(b.u16(at)))
+			default:
+				field.attrs = append(field.attrs, int64(b.u32(at)))
+			}
+		}
+		assert.NotZero(t, b.field(pos, 5))
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+//readArrowMessage Decode encapsulated message at position, returns header type, header table, metadata and body
+func readArrowMessage(t *testing.T, data []byte, pos int) (uint8, int, fbReader, []byte) {
+	assert.Equal(t, arrowContinuation, binary.LittleEndian.Uint32(data[pos:]))
+	size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+	if size == 0 {
+		return 0, 0, nil, nil
+	}
+	assert.Zero(t, (pos+8+size)%8)
+	b := fbReader(data[pos+8 : pos+8+size])
+	message := b.root()
+	assert.Equal(t, int(arrowMetadataVersion), b.u16(b.field(message, 0)))
+	bodyLength := int(b.i64(b.field(message, 3)))
+	body := data[pos+8+size : pos+8+size+bodyLength]
+	return b[b.field(message, 1)], b.deref(b.field(message, 2)), b, body
+}
+
+//readArrowBatch Decode record batch columns
+func readArrowBatch(t *testing.T, file *arrowFile, b fbReader, batch int, body []byte) {
+	length := int(b.i64(b.field(batch, 0)))
+	nodes, _ := b.structs(batch, 1)
+	buffers, _ := b.structs(batch, 2)
+	buffer := func() []byte {
+		offset, size := b.i64(buffers), b.i64(buffers+8)
+		buffers += 16
+		return body[offset : offset+size]
+	}
+	for i, field := range file.fields {
+		nulls := b.i64(nodes + 16*i + 8)
+		validity := buffer()
+		valid := func(j int) bool {
+			return nulls == 0 || validity[j/8]&(1<<uint(j%8)) != 0
+		}
+		var values []byte
+		var offsets []byte
+		if field.typ == int(arrowTypeUtf8) {
+			offsets = buffer()
+		}
+		values = buffer()
+		for j := 0; j < length; j++ {
+			var v interface{}
+			if valid(j) {
+				switch uint8(field.typ) {
+				case arrowTypeUtf8:
+					v = string(values[binary.LittleEndian.Uint32(offsets[4*j:]):binary.LittleEndian.Uint32(offsets[4*j+4:])])
+				case arrowTypeBool:
+					v = values[j/8]&(1<<uint(j%8)) != 0
+				case arrowTypeDate:
+					v = int64(int32(binary.LittleEndian.Uint32(values[4*j:])))
+				case arrowTypeFloatingPoint:
+					v = math.Float64frombits(binary.LittleEndian.Uint64(values[8*j:]))
+				case arrowTypeDecimal:
+					low := int64(binary.LittleEndian.Uint64(values[16*j:]))
+					assert.Equal(t, low>>63, int64(binary.LittleEndian.Uint64(values[16*j+8:])))
+					v = low
+				default:
+					v = int64(binary.LittleEndian.Uint64(values[8*j:]))
+				}
+			}
+			file.columns[field.name] = append(file.columns[field.name], v)
+		}
+	}
+	file.batches++
+}
+
+//readArrow Decode Arrow IPC file written by ArrowWriter, values are nil, string, int64, float64 or bool
+func readArrow(t *testing.T, data []byte) *arrowFile {
+	assert.Equal(t, "ARROW1\x00\x00", string(data[:8]))
+	assert.Equal(t, "ARROW1", string(data[len(data)-6:]))
+	size := int(binary.LittleEndian.Uint32(data[len(data)-10:]))
+	footer := fbReader(data[len(data)-10-size : len(data)-10])
+	root := footer.root()
+	file := &arrowFile{fields: readArrowSchema(t, footer, footer.deref(footer.field(root, 1))), columns: map[string][]interface{}{}}
+	if footer.field(root, 3) == 0 {
+		return file
+	}
+	blocks, count := footer.structs(root, 3)
+	for i := 0; i < count; i++ {
+		offset := int(footer.i64(blocks + 24*i))
+		metadataLength := footer.u32(blocks + 24*i + 8)
+		bodyLength := int(footer.i64(blocks + 24*i + 16))
+		assert.Zero(t, offset%8)
+		typ, batch, b, body := readArrowMessage(t, data, offset)
+		assert.Equal(t, arrowMessageRecordBatch, typ)
+		assert.Equal(t, len(b)+8, metadataLength)
+		assert.Equal(t, len(body), bodyLength)
+		readArrowBatch(t, file, b, batch, body)
+	}
+	return file
+}
+
+//readArrowStream Decode Arrow IPC stream
+func readArrowStream(t *testing.T, data []byte) *arrowFile {
+	file := &arrowFile{columns: map[string][]interface{}{}}
+	pos := 0
+	for {
+		typ, header, b, body := readArrowMessage(t, data, pos)
+		if b == nil {
+			assert.Equal(t, len(data), pos+8)
+			return file
+		}
+		if typ == arrowMessageSchema {
+			file.fields = readArrowSchema(t, b, header)
+		} else {
+			readArrowBatch(t, file, b, header, body)
+		}
+		pos += 8 + len(b) + len(body)
+	}
+}
+
+type ArrowWriterTestSuite struct {
+	suite.Suite
+	date time.Time
+	rows []*appstore.SubscriptionsEventsReport
+}
+
+func (suite *ArrowWriterTestSuite) SetupTest() {
+	suite.date = time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC)
+	suite.rows = generator.New(1).SubscriptionsEventsReports(suite.date, 10)
+}
+
+func (suite *ArrowWriterTestSuite) TestWriteArrow() {
+	buf := &bytes.Buffer{}
+	assert.NoError(suite.T(), WriteArrow(buf, suite.rows))
+	file := readArrow(suite.T(), buf.Bytes())
+	assert.Equal(suite.T(), 1, file.batches)
+	schema, _ := NewSchema(suite.rows)
+	assert.Len(suite.T(), file.fields, len(schema.Columns))
+	for i, row := range suite.rows {
+		assert.Equal(suite.T(), string(row.Event), file.columns["event"][i])
+		assert.Equal(suite.T(), int64(18387), file.columns["event_date"][i])
+		assert.Equal(suite.T(), int64(row.Quantity.Integer), file.columns["quantity"][i])
+	}
+}
+
+func (suite *ArrowWriterTestSuite) TestSchema() {
+	buf := &bytes.Buffer{}
+	assert.NoError(suite.T(), WriteArrow(buf, []*appstore.SalesReport{}))
+	file := readArrow(suite.T(), buf.Bytes())
+	assert.Equal(suite.T(), 0, file.batches)
+	types := map[string]*arrowField{}
+	for _, field := range file.fields {
+		assert.True(suite.T(), field.nullable)
+		types[field.name] = field
+	}
+	assert.Equal(suite.T(), &arrowField{name: "sku", nullable: true, typ: int(arrowTypeUtf8)}, types["sku"])
+	assert.Equal(suite.T(), &arrowField{name: "units", nullable: true, typ: int(arrowTypeFloatingPoint), attrs: []int64{2}}, types["units"])
+	assert.Equal(suite.T(), &arrowField{name: "developer_proceeds", nullable: true, typ: int(arrowTypeDecimal), attrs: []int64{18, 6, 128}}, types["developer_proceeds"])
+	assert.Equal(suite.T(), &arrowField{name: "begin_date", nullable: true, typ: int(arrowTypeDate), attrs: []int64{0}}, types["begin_date"])
+	assert.Equal(suite.T(), &arrowField{name: "apple_identifier", nullable: true, typ: int(arrowTypeInt), attrs: []int64{64, 1}}, types["apple_identifier"])
+}
+
+func (suite *ArrowWriterTestSuite) TestBatchesAndNulls() {
+	type row struct {
+		Name      string                   `json:"name"`
+		Flag      appstore.CustomBoolean   `json:"flag"`
+		Amount    appstore.Money           `json:"amount"`
+		Timestamp appstore.CustomTimestamp `json:"timestamp"`
+	}
+	schema, _ := NewSchema(row{})
+	buf := &bytes.Buffer{}
+	w := NewArrowWriter(buf, schema)
+	w.BatchSize = 2
+	assert.NoError(suite.T(), w.WriteRows([]row{
+		{Name: "foo", Flag: appstore.CustomBoolean{Boolean: true}, Amount: appstore.NewMoney(decimal.RequireFromString("-0.5"), "USD")},
+		{Flag: appstore.CustomBoolean{Null: true}, Amount: appstore.Money{Null: true}, Timestamp: appstore.CustomTimestamp{Timestamp: suite.date}},
+		{Name: "bar"},
+	}))
+	assert.NoError(suite.T(), w.Close())
+	file := readArrow(suite.T(), buf.Bytes())
+	assert.Equal(suite.T(), 2, file.batches)
+	assert.Equal(suite.T(), []interface{}{"foo", nil, "bar"}, file.columns["name"])
+	assert.Equal(suite.T(), []interface{}{true, nil, false}, file.columns["flag"])
+	assert.Equal(suite.T(), []interface{}{int64(-500000), nil, int64(0)}, file.columns["amount"])
+	assert.Equal(suite.T(), []interface{}{nil, suite.date.Unix() * 1000000, nil}, file.columns["timestamp"])
+	assert.Equal(suite.T(), &arrowField{name: "timestamp", nullable: true, typ: int(arrowTypeTimestamp), attrs: []int64{2, 3}}, file.fields[3])
+}
+
+func (suite *ArrowWriterTestSuite) TestStream() {
+	data, _ := ioutil.ReadFile("../stubs/reports/finances/financial.tsv")
+	rows := []*appstore.FinancialReport{}
+	assert.NoError(suite.T(), appstore.UnmarshalCSVWithFilterLines(data, &rows))
+	schema, _ := NewSchema(rows)
+	buf := &bytes.Buffer{}
+	w := NewArrowWriter(buf, schema)
+	w.Stream = true
+	assert.NoError(suite.T(), w.WriteRows(rows))
+	assert.NoError(suite.T(), w.Close())
+	assert.Error(suite.T(), w.Write(rows[0]))
+
+	file := readArrowStream(suite.T(), buf.Bytes())
+	assert.Equal(suite.T(), 1, file.batches)
+	assert.Len(suite.T(), file.fields, len(schema.Columns))
+	for i, row := range rows {
+		assert.Equal(suite.T(), row.VendorIdentifier, file.columns["vendor_identifier"][i])
+		assert.Equal(suite.T(), row.PartnerShare.Amount.Shift(DecimalScaleDefault).IntPart(), file.columns["partner_share"][i])
+	}
+}
+
+func (suite *ArrowWriterTestSuite) TestErrors() {
+	assert.Error(suite.T(), WriteArrow(&bytes.Buffer{}, 1))
+	schema, _ := NewSchema(suite.rows)
+	w := NewArrowWriter(&bytes.Buffer{}, schema)
+	assert.Error(suite.T(), w.Write(&appstore.SalesReport{}))
+	schema.Columns[0].Name = schema.Columns[1].Name
+	w = NewArrowWriter(&bytes.Buffer{}, schema)
+	assert.Error(suite.T(), w.Write(suite.rows[0]))
+}
+
+func TestArrowWriterTestSuite(t *testing.T) {
+	suite.Run(t, new(ArrowWriterTestSuite))
+}
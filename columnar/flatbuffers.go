@@ -0,0 +1,187 @@
+package columnar
+
+import (
+	"encoding/binary"
+)
+
+//fbObject FlatBuffers object referenced by offset: table, string or vector
+type fbObject interface{}
+
+//fbTable FlatBuffers table, fields are set by slot index of schema
+type fbTable struct {
+	slots []fbSlot
+}
+
+//fbSlot field of table, either inline little endian scalar or referenced object
+type fbSlot struct {
+	scalar []byte
+	object fbObject
+}
+
+//fbString FlatBuffers string
+type fbString string
+
+//fbTables FlatBuffers vector of tables
+type fbTables []*fbTable
+
+//fbStructs FlatBuffers vector of inline structs
+type fbStructs struct {
+	data  []byte //little endian structs
+	size  int    //size of struct
+	align int    //alignment of struct
+}
+
+//newFbTable Create table with number of slots
+func newFbTable(slots int) *fbTable {
+	return &fbTable{slots: make([]fbSlot, slots)}
+}
+
+//uint8 Set ubyte or bool field
+func (t *fbTable) uint8(slot int, v uint8) *fbTable {
+	t.slots[slot].scalar = []byte{v}
+	return t
+}
+
+//bool Set bool field
+func (t *fbTable) bool(slot int, v bool) *fbTable {
+	if v {
+		return t.uint8(slot, 1)
+	}
+	return t.uint8(slot, 0)
+}
+
+//int16 Set short field
+func (t *fbTable) int16(slot int, v int16) *fbTable {
+	t.slots[slot].scalar = make([]byte, 2)
+	binary.LittleEndian.PutUint16(t.slots[slot].scalar, uint16(v))
+	return t
+}
+
+//int32 Set int field
+func (t *fbTable) int32(slot int, v int32) *fbTable {
+	t.slots[slot].scalar = make([]byte, 4)
+	binary.LittleEndian.PutUint32(t.slots[slot].scalar, uint32(v))
+	return t
+}
+
+//int64 Set long field
+func (t *fbTable) int64(slot int, v int64) *fbTable {
+	t.slots[slot].scalar = make([]byte, 8)
+	binary.LittleEndian.PutUint64(t.slots[slot].scalar, uint64(v))
+	return t
+}
+
+//object Set table, string or vector field
+func (t *fbTable) object(slot int, v fbObject) *fbTable {
+	t.slots[slot].object = v
+	return t
+}
+
+//fbBuilder FlatBuffers serializer. Objects are written front to back, children after parents,
+//so unsigned offsets always point forward and vtables precede their tables
+type fbBuilder struct {
+	buf []byte
+}
+
+//fbFinish Serialize root table, size of result is multiple of 8
+func fbFinish(root *fbTable) []byte {
+	b := &fbBuilder{buf: make([]byte, 4)}
+	pos := b.table(root)
+	binary.LittleEndian.PutUint32(b.buf, uint32(pos))
+	b.pad(8)
+	return b.buf
+}
+
+//pad Pad buffer to alignment
+func (b *fbBuilder) pad(align int) {
+	for len(b.buf)%align != 0 {
+		b.buf = append(b.buf, 0)
+	}
+}
+
+//reserve Append zero bytes, returns position
+func (b *fbBuilder) reserve(size int) int {
+	pos := len(b.buf)
+	b.buf = append(b.buf, make([]byte, size)...)
+	return pos
+}
+
+//offset Write unsigned offset at position pointing to object
+func (b *fbBuilder) offset(at int, object fbObject) {
+	pos := b.object(object)
+	binary.LittleEndian.PutUint32(b.buf[at:], uint32(pos-at))
+}
+
+//object Write object, returns position
+func (b *fbBuilder) object(object fbObject) int {
+	switch v := object.(type) {
+	case *fbTable:
+		return b.table(v)
+	case fbString:
+		b.pad(4)
+		pos := b.reserve(4 + len(v) + 1)
+		binary.LittleEndian.PutUint32(b.buf[pos:], uint32(len(v)))
+		copy(b.buf[pos+4:], v)
+		return pos
+	case fbTables:
+		b.pad(4)
+		pos := b.reserve(4 + 4*len(v))
+		binary.LittleEndian.PutUint32(b.buf[pos:], uint32(len(v)))
+		for i, table := range v {
+			b.offset(pos+4+4*i, table)
+		}
+		return pos
+	case *fbStructs:
+		//elements follow length and are aligned to struct alignment
+		for (len(b.buf)+4)%v.align != 0 {
+			b.buf = append(b.buf, 0)
+		}
+		pos := b.reserve(4 + len(v.data))
+		binary.LittleEndian.PutUint32(b.buf[pos:], uint32(len(v.data)/v.size))
+		copy(b.buf[pos+4:], v.data)
+		return pos
+	}
+	panic("fbBuilder: unsupported object")
+}
+
+//table Write vtable and table, returns table position
+func (b *fbBuilder) table(t *fbTable) int {
+	//inline layout: soffset to vtable, then fields in slot order aligned to their size
+	offsets := make([]int, len(t.slots))
+	size := 4
+	for i, slot := range t.slots {
+		fieldSize := len(slot.scalar)
+		if slot.object != nil {
+			fieldSize = 4
+		}
+		if fieldSize == 0 {
+			continue
+		}
+		for size%fieldSize != 0 {
+			size++
+		}
+		offsets[i] = size
+		size += fieldSize
+	}
+	b.pad(2)
+	vtable := b.reserve(4 + 2*len(t.slots))
+	binary.LittleEndian.PutUint16(b.buf[vtable:], uint16(4+2*len(t.slots)))
+	binary.LittleEndian.PutUint16(b.buf[vtable+2:], uint16(size))
+	for i, offset := range offsets {
+		binary.LittleEndian.PutUint16(b.buf[vtable+4+2*i:], uint16(offset))
+	}
+	b.pad(8)
+	pos := b.reserve(size)
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(int32(pos-vtable)))
+	for i, slot := range t.slots {
+		if slot.scalar != nil {
+			copy(b.buf[pos+offsets[i]:], slot.scalar)
+		}
+	}
+	for i, slot := range t.slots {
+		if slot.object != nil {
+			b.offset(pos+offsets[i], slot.object)
+		}
+	}
+	return pos
+}
@@ -0,0 +1,46 @@
+package columnar
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type FlatBuffersTestSuite struct {
+	suite.Suite
+}
+
+func (suite *FlatBuffersTestSuite) TestFinish() {
+	child := newFbTable(2).int64(1, -5)
+	root := newFbTable(6).
+		uint8(0, 7).
+		int64(1, 1<<40).
+		int16(2, -3).
+		object(3, fbString("foo")).
+		object(4, fbTables{child}).
+		object(5, &fbStructs{data: appendInt64s(nil, 1, 2), size: 16, align: 8})
+	data := fbFinish(root)
+	assert.Zero(suite.T(), len(data)%8)
+
+	b := fbReader(data)
+	table := b.root()
+	assert.Zero(suite.T(), table%8)
+	assert.Equal(suite.T(), byte(7), b[b.field(table, 0)])
+	assert.Zero(suite.T(), b.field(table, 1)%8)
+	assert.Equal(suite.T(), int64(1<<40), b.i64(b.field(table, 1)))
+	assert.Equal(suite.T(), 0xfffd, b.u16(b.field(table, 2)))
+	assert.Equal(suite.T(), "foo", b.str(table, 3))
+	children := b.tables(table, 4)
+	assert.Len(suite.T(), children, 1)
+	assert.Zero(suite.T(), b.field(children[0], 0))
+	assert.Equal(suite.T(), int64(-5), b.i64(b.field(children[0], 1)))
+	start, count := b.structs(table, 5)
+	assert.Zero(suite.T(), start%8)
+	assert.Equal(suite.T(), 1, count)
+	assert.Equal(suite.T(), int64(2), b.i64(start+8))
+	assert.Zero(suite.T(), b.field(table, 7))
+}
+
+func TestFlatBuffersTestSuite(t *testing.T) {
+	suite.Run(t, new(FlatBuffersTestSuite))
+}
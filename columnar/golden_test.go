@@ -0,0 +1,153 @@
+package columnar
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+//updateGolden regenerate golden files with go test ./columnar -update
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+//goldenRow row of golden files, one column of every supported type
+type goldenRow struct {
+	Name      string                   `json:"name"`
+	Units     appstore.CustomInteger   `json:"units"`
+	Rate      appstore.CustomFloat64   `json:"rate"`
+	Flag      appstore.CustomBoolean   `json:"flag"`
+	Amount    appstore.Money           `json:"amount"`
+	Date      appstore.CustomDate      `json:"date"`
+	Timestamp appstore.CustomTimestamp `json:"timestamp"`
+}
+
+type GoldenTestSuite struct {
+	suite.Suite
+	date time.Time
+	rows []goldenRow
+}
+
+func (suite *GoldenTestSuite) SetupTest() {
+	suite.date = time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC)
+	suite.rows = []goldenRow{
+		{
+			Name:      "foo",
+			Units:     appstore.CustomInteger{Integer: 3},
+			Rate:      appstore.CustomFloat64{Float64: 0.25},
+			Flag:      appstore.CustomBoolean{Boolean: true},
+			Amount:    appstore.NewMoney(decimal.RequireFromString("-0.5"), "USD"),
+			Date:      appstore.CustomDate{Date: suite.date},
+			Timestamp: appstore.CustomTimestamp{Timestamp: suite.date.Add(90 * time.Minute)},
+		},
+		{
+			Units:     appstore.CustomInteger{Null: true},
+			Rate:      appstore.CustomFloat64{Null: true},
+			Flag:      appstore.CustomBoolean{Null: true},
+			Amount:    appstore.Money{Null: true},
+			Date:      appstore.CustomDate{Null: true},
+			Timestamp: appstore.CustomTimestamp{Null: true},
+		},
+		{
+			Name:      "bar",
+			Units:     appstore.CustomInteger{Integer: -7},
+			Rate:      appstore.CustomFloat64{Float64: 1.5},
+			Amount:    appstore.NewMoney(decimal.RequireFromString("1234.567891"), "EUR"),
+			Date:      appstore.CustomDate{Date: suite.date.AddDate(0, 0, 1)},
+			Timestamp: appstore.CustomTimestamp{Timestamp: suite.date},
+		},
+	}
+}
+
+//parquet Write golden rows to Parquet, 2 rows per row group
+func (suite *GoldenTestSuite) parquet() []byte {
+	schema, err := NewSchema(suite.rows)
+	assert.NoError(suite.T(), err)
+	buf := &bytes.Buffer{}
+	w := NewParquetWriter(buf, schema)
+	w.RowGroupSize = 2
+	assert.NoError(suite.T(), w.WriteRows(suite.rows))
+	assert.NoError(suite.T(), w.Close())
+	return buf.Bytes()
+}
+
+//arrow Write golden rows to Arrow IPC file or stream, 2 rows per record batch
+func (suite *GoldenTestSuite) arrow(stream bool) []byte {
+	schema, err := NewSchema(suite.rows)
+	assert.NoError(suite.T(), err)
+	buf := &bytes.Buffer{}
+	w := NewArrowWriter(buf, schema)
+	w.BatchSize = 2
+	w.Stream = stream
+	assert.NoError(suite.T(), w.WriteRows(suite.rows))
+	assert.NoError(suite.T(), w.Close())
+	return buf.Bytes()
+}
+
+//golden Compare data with golden file, file is rewritten with -update
+func (suite *GoldenTestSuite) golden(name string, data []byte) {
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		assert.NoError(suite.T(), ioutil.WriteFile(path, data, 0644))
+	}
+	expected, err := ioutil.ReadFile(path)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, data, "%s differs from golden file, check it with pyarrow before running -update", name)
+}
+
+func (suite *GoldenTestSuite) TestParquet() {
+	suite.golden("golden.parquet", suite.parquet())
+	file := readParquet(suite.T(), suite.parquet())
+	assert.Len(suite.T(), file.rowGroups(), 2)
+	assert.Equal(suite.T(), []interface{}{"foo", nil, "bar"}, file.columns["name"])
+}
+
+func (suite *GoldenTestSuite) TestArrow() {
+	suite.golden("golden.arrow", suite.arrow(false))
+	suite.golden("golden.arrows", suite.arrow(true))
+	file := readArrow(suite.T(), suite.arrow(false))
+	assert.Equal(suite.T(), 2, file.batches)
+	assert.Equal(suite.T(), []interface{}{"foo", nil, "bar"}, file.columns["name"])
+}
+
+//TestPyArrow Read golden files with pyarrow, the reference implementation, skipped when pyarrow is not installed
+func (suite *GoldenTestSuite) TestPyArrow() {
+	if err := exec.Command("python3", "-c", "import pyarrow").Run(); err != nil {
+		suite.T().Skip("pyarrow is not installed")
+	}
+	for _, name := range []string{"golden.parquet", "golden.arrow", "golden.arrows"} {
+		out, err := exec.Command("python3", "testdata/read_golden.py", filepath.Join("testdata", name)).CombinedOutput()
+		if !assert.NoError(suite.T(), err, "%s: %s", name, out) {
+			continue
+		}
+		columns := make(map[string][]interface{})
+		assert.NoError(suite.T(), json.Unmarshal(out, &columns), name)
+		assert.Equal(suite.T(), []interface{}{"foo", nil, "bar"}, columns["name"], name)
+		assert.Equal(suite.T(), []interface{}{float64(3), nil, float64(-7)}, columns["units"], name)
+		assert.Equal(suite.T(), []interface{}{0.25, nil, 1.5}, columns["rate"], name)
+		assert.Equal(suite.T(), []interface{}{true, nil, false}, columns["flag"], name)
+		assert.Equal(suite.T(), []interface{}{"2020-05-05", nil, "2020-05-06"}, columns["date"], name)
+		assert.Equal(suite.T(), []interface{}{"2020-05-05T01:30:00+00:00", nil, "2020-05-05T00:00:00+00:00"}, columns["timestamp"], name)
+		if assert.Len(suite.T(), columns["amount"], 3, name) {
+			for i, amount := range []string{"-0.5", "", "1234.567891"} {
+				if amount == "" {
+					assert.Nil(suite.T(), columns["amount"][i], name)
+					continue
+				}
+				value, _ := columns["amount"][i].(string)
+				assert.True(suite.T(), decimal.RequireFromString(amount).Equal(decimal.RequireFromString(value)), "%s amount %d: %v", name, i, value)
+			}
+		}
+	}
+}
+
+func TestGoldenTestSuite(t *testing.T) {
+	suite.Run(t, new(GoldenTestSuite))
+}
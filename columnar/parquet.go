@@ -0,0 +1,425 @@
+package columnar
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+//Compression Parquet column chunk compression codec
+type Compression int32
+
+const (
+	//CompressionNone const, pages are not compressed
+	CompressionNone Compression = 0
+	//CompressionGzip const, pages are compressed with gzip
+	CompressionGzip Compression = 2
+)
+
+//ParquetRowGroupSizeDefault const, rows of row group
+const ParquetRowGroupSizeDefault = 65536
+
+//ParquetCreatedBy const, writer name in file metadata
+const ParquetCreatedBy = "appstore-sdk-go"
+
+//parquetMagic Parquet file header and footer magic
+var parquetMagic = []byte("PAR1")
+
+//Parquet physical types
+const (
+	parquetBoolean   int32 = 0
+	parquetInt32     int32 = 1
+	parquetInt64     int32 = 2
+	parquetDouble    int32 = 5
+	parquetByteArray int32 = 6
+)
+
+//Parquet converted types, written along with logical types for older readers
+const (
+	parquetUTF8            int32 = 0
+	parquetDecimal         int32 = 5
+	parquetDate            int32 = 6
+	parquetTimestampMicros int32 = 10
+	parquetInt64Converted  int32 = 18
+)
+
+//Parquet encodings
+const (
+	parquetPlain int32 = 0
+	parquetRLE   int32 = 3
+)
+
+//Parquet field repetition types
+const (
+	parquetOptional int32 = 1
+)
+
+//parquetColumnChunk written column chunk of row group
+type parquetColumnChunk struct {
+	column           *Column
+	codec            Compression
+	offset           int64
+	values           int64
+	uncompressedSize int64
+	compressedSize   int64
+}
+
+//parquetRowGroup written row group
+type parquetRowGroup struct {
+	chunks []*parquetColumnChunk
+	rows   int64
+}
+
+//ParquetWriter writer of report rows to Parquet file.
+//Rows are buffered until RowGroupSize rows are written or Flush is called, footer is written by Close.
+//Every column is optional, values are PLAIN encoded in single data page per column chunk
+type ParquetWriter struct {
+	Schema       *Schema
+	RowGroupSize int
+	Compression  Compression
+	out          io.Writer
+	offset       int64
+	buffer       *rowBuffer
+	rowGroups    []*parquetRowGroup
+	closed       bool
+}
+
+//NewParquetWriter Create new Parquet writer of schema rows
+func NewParquetWriter(out io.Writer, schema *Schema) *ParquetWriter {
+	return &ParquetWriter{Schema: schema, RowGroupSize: ParquetRowGroupSizeDefault, Compression: CompressionNone, out: out}
+}
+
+//Write Write report row, row is struct of schema or pointer to it
+func (w *ParquetWriter) Write(row interface{}) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if err := w.buffer.add(row); err != nil {
+		return fmt.Errorf("ParquetWriter.Write: %v", err)
+	}
+	if w.RowGroupSize > 0 && w.buffer.rows >= w.RowGroupSize {
+		return w.Flush()
+	}
+	return nil
+}
+
+//WriteRows Write slice of report rows
+func (w *ParquetWriter) WriteRows(rows interface{}) error {
+	return forEach(rows, w.Write)
+}
+
+//Flush Write buffered rows as row group
+func (w *ParquetWriter) Flush() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if w.buffer.rows == 0 {
+		return nil
+	}
+	group := &parquetRowGroup{rows: int64(w.buffer.rows)}
+	for i, column := range w.Schema.Columns {
+		chunk, err := w.writeColumnChunk(column, w.buffer.columns[i])
+		if err != nil {
+			return fmt.Errorf("ParquetWriter.Flush: column %s %v", column.Name, err)
+		}
+		group.chunks = append(group.chunks, chunk)
+	}
+	w.rowGroups = append(w.rowGroups, group)
+	w.buffer.reset()
+	return nil
+}
+
+//Close Write buffered rows and file footer, underlying writer is not closed
+func (w *ParquetWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	w.closed = true
+	footer := w.fileMetaData()
+	tail := make([]byte, 4)
+	binary.LittleEndian.PutUint32(tail, uint32(len(footer)))
+	for _, data := range [][]byte{footer, tail, parquetMagic} {
+		if err := w.write(data); err != nil {
+			return fmt.Errorf("ParquetWriter.Close: %v", err)
+		}
+	}
+	return nil
+}
+
+//start Validate schema and write file header before first row group
+func (w *ParquetWriter) start() error {
+	if w.closed {
+		return fmt.Errorf("ParquetWriter: writer is closed")
+	}
+	if w.buffer != nil {
+		return nil
+	}
+	if err := w.Schema.validate(); err != nil {
+		return fmt.Errorf("ParquetWriter: %v", err)
+	}
+	w.buffer = newRowBuffer(w.Schema)
+	if err := w.write(parquetMagic); err != nil {
+		return fmt.Errorf("ParquetWriter: %v", err)
+	}
+	return nil
+}
+
+//write Write data and track file offset
+func (w *ParquetWriter) write(data []byte) error {
+	n, err := w.out.Write(data)
+	w.offset += int64(n)
+	return err
+}
+
+//writeColumnChunk Write column chunk of single data page
+func (w *ParquetWriter) writeColumnChunk(column *Column, values []value) (*parquetColumnChunk, error) {
+	levels := make([]bool, len(values))
+	for i, v := range values {
+		levels[i] = !v.null
+	}
+	page := &bytes.Buffer{}
+	encodedLevels := encodeLevels(levels)
+	_ = binary.Write(page, binary.LittleEndian, uint32(len(encodedLevels)))
+	page.Write(encodedLevels)
+	encodePlain(page, column, values)
+	body := page.Bytes()
+	uncompressedSize := len(body)
+	switch w.Compression {
+	case CompressionNone:
+	case CompressionGzip:
+		compressed := &bytes.Buffer{}
+		gz := gzip.NewWriter(compressed)
+		if _, err := gz.Write(body); err != nil {
+			return nil, err
+		}
+		if err := gz.Close(); err != nil {
+			return nil, err
+		}
+		body = compressed.Bytes()
+	default:
+		return nil, fmt.Errorf("compression %d is not supported", w.Compression)
+	}
+	header := newThriftWriter()
+	header.i32(1, 0) //DATA_PAGE
+	header.i32(2, int32(uncompressedSize))
+	header.i32(3, int32(len(body)))
+	header.structBegin(5)
+	header.i32(1, int32(len(values)))
+	header.i32(2, parquetPlain)
+	header.i32(3, parquetRLE)
+	header.i32(4, parquetRLE)
+	header.structEnd()
+	headerData := header.bytes()
+	chunk := &parquetColumnChunk{
+		column:           column,
+		codec:            w.Compression,
+		offset:           w.offset,
+		values:           int64(len(values)),
+		uncompressedSize: int64(len(headerData) + uncompressedSize),
+		compressedSize:   int64(len(headerData) + len(body)),
+	}
+	if err := w.write(headerData); err != nil {
+		return nil, err
+	}
+	if err := w.write(body); err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
+
+//fileMetaData Encode FileMetaData footer
+func (w *ParquetWriter) fileMetaData() []byte {
+	var rows int64
+	for _, group := range w.rowGroups {
+		rows += group.rows
+	}
+	meta := newThriftWriter()
+	meta.i32(1, 1)
+	meta.listBegin(2, thriftStruct, len(w.Schema.Columns)+1)
+	meta.elemBegin()
+	meta.string(4, "schema")
+	meta.i32(5, int32(len(w.Schema.Columns)))
+	meta.structEnd()
+	for _, column := range w.Schema.Columns {
+		meta.elemBegin()
+		writeSchemaElement(meta, column)
+		meta.structEnd()
+	}
+	meta.i64(3, rows)
+	meta.listBegin(4, thriftStruct, len(w.rowGroups))
+	for _, group := range w.rowGroups {
+		meta.elemBegin()
+		var uncompressedSize, compressedSize int64
+		meta.listBegin(1, thriftStruct, len(group.chunks))
+		for _, chunk := range group.chunks {
+			uncompressedSize += chunk.uncompressedSize
+			compressedSize += chunk.compressedSize
+			meta.elemBegin()
+			meta.i64(2, chunk.offset)
+			meta.structBegin(3)
+			meta.i32(1, parquetPhysicalType(chunk.column))
+			meta.i32List(2, parquetPlain, parquetRLE)
+			meta.stringList(3, chunk.column.Name)
+			meta.i32(4, int32(chunk.codec))
+			meta.i64(5, chunk.values)
+			meta.i64(6, chunk.uncompressedSize)
+			meta.i64(7, chunk.compressedSize)
+			meta.i64(9, chunk.offset)
+			meta.structEnd()
+			meta.structEnd()
+		}
+		meta.i64(2, uncompressedSize)
+		meta.i64(3, group.rows)
+		if len(group.chunks) > 0 {
+			meta.i64(5, group.chunks[0].offset)
+		}
+		meta.i64(6, compressedSize)
+		meta.structEnd()
+	}
+	meta.string(6, ParquetCreatedBy)
+	return meta.bytes()
+}
+
+//writeSchemaElement Encode SchemaElement of column with converted and logical types
+func writeSchemaElement(meta *thriftWriter, column *Column) {
+	meta.i32(1, parquetPhysicalType(column))
+	meta.i32(3, parquetOptional)
+	meta.string(4, column.Name)
+	switch column.Type {
+	case ColumnTypeString:
+		meta.i32(6, parquetUTF8)
+		meta.structBegin(10)
+		meta.structBegin(1) //STRING
+		meta.structEnd()
+		meta.structEnd()
+	case ColumnTypeInteger:
+		meta.i32(6, parquetInt64Converted)
+		meta.structBegin(10)
+		meta.structBegin(10) //INTEGER
+		meta.i8(1, 64)
+		meta.bool(2, true)
+		meta.structEnd()
+		meta.structEnd()
+	case ColumnTypeDecimal:
+		meta.i32(6, parquetDecimal)
+		meta.i32(7, int32(column.Scale))
+		meta.i32(8, int32(column.Precision))
+		meta.structBegin(10)
+		meta.structBegin(5) //DECIMAL
+		meta.i32(1, int32(column.Scale))
+		meta.i32(2, int32(column.Precision))
+		meta.structEnd()
+		meta.structEnd()
+	case ColumnTypeDate:
+		meta.i32(6, parquetDate)
+		meta.structBegin(10)
+		meta.structBegin(6) //DATE
+		meta.structEnd()
+		meta.structEnd()
+	case ColumnTypeTimestamp:
+		meta.i32(6, parquetTimestampMicros)
+		meta.structBegin(10)
+		meta.structBegin(8) //TIMESTAMP
+		meta.bool(1, true)
+		meta.structBegin(2)
+		meta.structBegin(2) //MICROS
+		meta.structEnd()
+		meta.structEnd()
+		meta.structEnd()
+		meta.structEnd()
+	}
+}
+
+//parquetPhysicalType Get physical type of column
+func parquetPhysicalType(column *Column) int32 {
+	switch column.Type {
+	case ColumnTypeString:
+		return parquetByteArray
+	case ColumnTypeFloat:
+		return parquetDouble
+	case ColumnTypeDate:
+		return parquetInt32
+	case ColumnTypeBoolean:
+		return parquetBoolean
+	}
+	return parquetInt64
+}
+
+//encodeLevels Encode definition levels of optional column with RLE/bit-packing hybrid encoding of bit width 1, as runs only
+func encodeLevels(levels []bool) []byte {
+	out := &bytes.Buffer{}
+	var header [binary.MaxVarintLen64]byte
+	for start := 0; start < len(levels); {
+		end := start + 1
+		for end < len(levels) && levels[end] == levels[start] {
+			end++
+		}
+		n := binary.PutUvarint(header[:], uint64(end-start)<<1)
+		out.Write(header[:n])
+		if levels[start] {
+			out.WriteByte(1)
+		} else {
+			out.WriteByte(0)
+		}
+		start = end
+	}
+	return out.Bytes()
+}
+
+//encodePlain Encode non null values with PLAIN encoding
+func encodePlain(out *bytes.Buffer, column *Column, values []value) {
+	var buf [8]byte
+	var bits byte
+	var count int
+	for _, v := range values {
+		if v.null {
+			continue
+		}
+		switch column.Type {
+		case ColumnTypeString:
+			binary.LittleEndian.PutUint32(buf[:4], uint32(len(v.text)))
+			out.Write(buf[:4])
+			out.WriteString(v.text)
+		case ColumnTypeFloat:
+			binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v.float))
+			out.Write(buf[:])
+		case ColumnTypeDate:
+			binary.LittleEndian.PutUint32(buf[:4], uint32(int32(v.integer)))
+			out.Write(buf[:4])
+		case ColumnTypeBoolean:
+			if v.boolean {
+				bits |= 1 << uint(count%8)
+			}
+			count++
+			if count%8 == 0 {
+				out.WriteByte(bits)
+				bits = 0
+			}
+		default:
+			binary.LittleEndian.PutUint64(buf[:], uint64(v.integer))
+			out.Write(buf[:])
+		}
+	}
+	if count%8 != 0 {
+		out.WriteByte(bits)
+	}
+}
+
+//WriteParquet Write slice of report rows to Parquet file
+func WriteParquet(out io.Writer, rows interface{}) error {
+	schema, err := NewSchema(rows)
+	if err != nil {
+		return fmt.Errorf("WriteParquet: %v", err)
+	}
+	w := NewParquetWriter(out, schema)
+	if err = w.WriteRows(rows); err != nil {
+		return fmt.Errorf("WriteParquet: %v", err)
+	}
+	return w.Close()
+}
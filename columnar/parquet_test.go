@@ -0,0 +1,315 @@
+package columnar
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/matisiekpl/appstore-sdk-go/generator"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"math"
+	"testing"
+	"time"
+)
+
+//thriftReader Thrift compact protocol decoder of Parquet metadata, structs are decoded to maps by field id
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) varint() int64 {
+	u := r.uvarint()
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case thriftTrue:
+		return true
+	case thriftFalse:
+		return false
+	case thriftByte:
+		r.pos++
+		return int64(int8(r.data[r.pos-1]))
+	case 4, thriftI32, thriftI64:
+		return r.varint()
+	case thriftBinary:
+		n := int(r.uvarint())
+		r.pos += n
+		return string(r.data[r.pos-n : r.pos])
+	case thriftList:
+		header := r.data[r.pos]
+		r.pos++
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = r.value(header & 0x0f)
+		}
+		return list
+	case thriftStruct:
+		return r.structValue()
+	}
+	panic("unsupported thrift type")
+}
+
+func (r *thriftReader) structValue() map[int16]interface{} {
+	fields := map[int16]interface{}{}
+	var last int16
+	for {
+		header := r.data[r.pos]
+		r.pos++
+		if header == 0 {
+			return fields
+		}
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.varint())
+		}
+		last = id
+		fields[id] = r.value(header & 0x0f)
+	}
+}
+
+//parquetFile decoded Parquet file
+type parquetFile struct {
+	meta    map[int16]interface{}
+	schema  []map[int16]interface{}
+	columns map[string][]interface{}
+}
+
+func (f *parquetFile) rowGroups() []map[int16]interface{} {
+	result := []map[int16]interface{}{}
+	for _, group := range f.meta[4].([]interface{}) {
+		result = append(result, group.(map[int16]interface{}))
+	}
+	return result
+}
+
+func (f *parquetFile) element(name string) map[int16]interface{} {
+	for _, element := range f.schema {
+		if element[4] == name {
+			return element
+		}
+	}
+	return nil
+}
+
+//readParquet Decode Parquet file written by ParquetWriter, values of columns are nil, string, int64, float64 or bool
+func readParquet(t *testing.T, data []byte) *parquetFile {
+	assert.Equal(t, "PAR1", string(data[:4]))
+	assert.Equal(t, "PAR1", string(data[len(data)-4:]))
+	size := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footer := &thriftReader{data: data[len(data)-8-size : len(data)-8]}
+	file := &parquetFile{meta: footer.structValue(), columns: map[string][]interface{}{}}
+	assert.Equal(t, size, footer.pos)
+	for _, element := range file.meta[2].([]interface{}) {
+		file.schema = append(file.schema, element.(map[int16]interface{}))
+	}
+	for _, group := range file.rowGroups() {
+		for _, chunk := range group[1].([]interface{}) {
+			meta := chunk.(map[int16]interface{})[3].(map[int16]interface{})
+			name := meta[3].([]interface{})[0].(string)
+			header := &thriftReader{data: data, pos: int(meta[9].(int64))}
+			page := header.structValue()
+			body := data[header.pos : header.pos+int(page[3].(int64))]
+			assert.Equal(t, meta[7].(int64), int64(header.pos)+int64(len(body))-meta[9].(int64))
+			if meta[4].(int64) == int64(CompressionGzip) {
+				gz, err := gzip.NewReader(bytes.NewReader(body))
+				assert.NoError(t, err)
+				body, _ = ioutil.ReadAll(gz)
+			}
+			assert.Equal(t, page[2].(int64), int64(len(body)))
+			count := int(page[5].(map[int16]interface{})[1].(int64))
+			file.columns[name] = append(file.columns[name], decodePage(body, meta[1].(int64), count)...)
+		}
+	}
+	return file
+}
+
+//decodePage Decode definition levels written as runs and PLAIN values
+func decodePage(body []byte, physicalType int64, count int) []interface{} {
+	levelsSize := int(binary.LittleEndian.Uint32(body))
+	levels := &thriftReader{data: body[4 : 4+levelsSize]}
+	defined := []bool{}
+	for levels.pos < len(levels.data) {
+		run := int(levels.uvarint() >> 1)
+		for i := 0; i < run; i++ {
+			defined = append(defined, levels.data[levels.pos] == 1)
+		}
+		levels.pos++
+	}
+	values := body[4+levelsSize:]
+	result := make([]interface{}, count)
+	bit := 0
+	for i := 0; i < count; i++ {
+		if !defined[i] {
+			continue
+		}
+		switch int32(physicalType) {
+		case parquetByteArray:
+			n := int(binary.LittleEndian.Uint32(values))
+			result[i] = string(values[4 : 4+n])
+			values = values[4+n:]
+		case parquetInt32:
+			result[i] = int64(int32(binary.LittleEndian.Uint32(values)))
+			values = values[4:]
+		case parquetInt64:
+			result[i] = int64(binary.LittleEndian.Uint64(values))
+			values = values[8:]
+		case parquetDouble:
+			result[i] = math.Float64frombits(binary.LittleEndian.Uint64(values))
+			values = values[8:]
+		case parquetBoolean:
+			result[i] = values[bit/8]&(1<<uint(bit%8)) != 0
+			bit++
+		}
+	}
+	return result
+}
+
+type ParquetWriterTestSuite struct {
+	suite.Suite
+	date time.Time
+	rows []*appstore.SalesReport
+}
+
+func (suite *ParquetWriterTestSuite) SetupTest() {
+	suite.date = time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC)
+	suite.rows = generator.New(1).SalesReports(suite.date, 10)
+}
+
+func (suite *ParquetWriterTestSuite) TestWriteParquet() {
+	buf := &bytes.Buffer{}
+	assert.NoError(suite.T(), WriteParquet(buf, suite.rows))
+	file := readParquet(suite.T(), buf.Bytes())
+	assert.Equal(suite.T(), int64(10), file.meta[3])
+	assert.Equal(suite.T(), ParquetCreatedBy, file.meta[6])
+	assert.Len(suite.T(), file.rowGroups(), 1)
+	assert.Len(suite.T(), file.schema, 1+len(file.columns))
+	for i, row := range suite.rows {
+		assert.Equal(suite.T(), row.SKU, file.columns["sku"][i])
+		assert.Equal(suite.T(), row.Units.Float64, file.columns["units"][i])
+		assert.Equal(suite.T(), row.DeveloperProceeds.Amount.Shift(DecimalScaleDefault).IntPart(), file.columns["developer_proceeds"][i])
+		assert.Equal(suite.T(), int64(18387), file.columns["begin_date"][i])
+		assert.Equal(suite.T(), int64(row.AppleIdentifier.Integer), file.columns["apple_identifier"][i])
+	}
+}
+
+func (suite *ParquetWriterTestSuite) TestSchemaElements() {
+	buf := &bytes.Buffer{}
+	assert.NoError(suite.T(), WriteParquet(buf, suite.rows))
+	file := readParquet(suite.T(), buf.Bytes())
+	root := file.schema[0]
+	assert.Equal(suite.T(), "schema", root[4])
+	assert.Equal(suite.T(), int64(len(file.schema)-1), root[5])
+
+	sku := file.element("sku")
+	assert.Equal(suite.T(), int64(parquetByteArray), sku[1])
+	assert.Equal(suite.T(), int64(parquetOptional), sku[3])
+	assert.Equal(suite.T(), int64(parquetUTF8), sku[6])
+	assert.Contains(suite.T(), sku[10], int16(1))
+
+	proceeds := file.element("developer_proceeds")
+	assert.Equal(suite.T(), int64(parquetInt64), proceeds[1])
+	assert.Equal(suite.T(), int64(parquetDecimal), proceeds[6])
+	assert.Equal(suite.T(), int64(DecimalScaleDefault), proceeds[7])
+	assert.Equal(suite.T(), int64(DecimalPrecisionDefault), proceeds[8])
+	assert.Equal(suite.T(), map[int16]interface{}{1: int64(DecimalScaleDefault), 2: int64(DecimalPrecisionDefault)}, proceeds[10].(map[int16]interface{})[5])
+
+	date := file.element("begin_date")
+	assert.Equal(suite.T(), int64(parquetInt32), date[1])
+	assert.Equal(suite.T(), int64(parquetDate), date[6])
+
+	units := file.element("units")
+	assert.Equal(suite.T(), int64(parquetDouble), units[1])
+
+	identifier := file.element("apple_identifier")
+	assert.Equal(suite.T(), int64(parquetInt64), identifier[1])
+	assert.Equal(suite.T(), map[int16]interface{}{1: int64(64), 2: true}, identifier[10].(map[int16]interface{})[10])
+}
+
+func (suite *ParquetWriterTestSuite) TestRowGroupsAndCompression() {
+	data, _ := ioutil.ReadFile("../stubs/reports/sales/sales.tsv")
+	rows := []*appstore.SalesReport{}
+	assert.NoError(suite.T(), appstore.UnmarshalCSV(data, &rows))
+	schema, _ := NewSchema(rows)
+	buf := &bytes.Buffer{}
+	w := NewParquetWriter(buf, schema)
+	w.RowGroupSize = 1
+	w.Compression = CompressionGzip
+	for _, row := range rows {
+		assert.NoError(suite.T(), w.Write(row))
+	}
+	assert.NoError(suite.T(), w.Close())
+	assert.NoError(suite.T(), w.Close())
+	assert.Error(suite.T(), w.Write(rows[0]))
+
+	file := readParquet(suite.T(), buf.Bytes())
+	assert.Len(suite.T(), file.rowGroups(), len(rows))
+	assert.Equal(suite.T(), int64(len(rows)), file.meta[3])
+	for i, row := range rows {
+		assert.Equal(suite.T(), row.SKU, file.columns["sku"][i])
+		if row.PromoCode == "" {
+			assert.Nil(suite.T(), file.columns["promo_code"][i])
+		}
+	}
+}
+
+func (suite *ParquetWriterTestSuite) TestNullsAndBooleans() {
+	type row struct {
+		Name   string                 `json:"name"`
+		Flag   appstore.CustomBoolean `json:"flag"`
+		Amount appstore.Money         `json:"amount"`
+		Date   appstore.CustomDate    `json:"date"`
+	}
+	rows := []row{
+		{Name: "foo", Flag: appstore.CustomBoolean{Boolean: true}, Amount: appstore.NewMoney(decimal.RequireFromString("-0.5"), "USD")},
+		{Flag: appstore.CustomBoolean{Null: true}, Amount: appstore.Money{Null: true}, Date: appstore.CustomDate{Date: suite.date}},
+	}
+	for i := 0; i < 9; i++ {
+		rows = append(rows, row{Name: "bar", Flag: appstore.CustomBoolean{Boolean: i%2 == 0}})
+	}
+	buf := &bytes.Buffer{}
+	assert.NoError(suite.T(), WriteParquet(buf, rows))
+	file := readParquet(suite.T(), buf.Bytes())
+	assert.Equal(suite.T(), []interface{}{"foo", nil}, file.columns["name"][:2])
+	assert.Equal(suite.T(), []interface{}{true, nil, true, false, true, false, true, false, true, false, true}, file.columns["flag"])
+	assert.Equal(suite.T(), []interface{}{int64(-500000), nil}, file.columns["amount"][:2])
+	assert.Equal(suite.T(), []interface{}{nil, int64(18387)}, file.columns["date"][:2])
+}
+
+func (suite *ParquetWriterTestSuite) TestEmpty() {
+	buf := &bytes.Buffer{}
+	assert.NoError(suite.T(), WriteParquet(buf, []*appstore.FinancialReport{}))
+	file := readParquet(suite.T(), buf.Bytes())
+	assert.Equal(suite.T(), int64(0), file.meta[3])
+	assert.Empty(suite.T(), file.rowGroups())
+}
+
+func (suite *ParquetWriterTestSuite) TestErrors() {
+	assert.Error(suite.T(), WriteParquet(&bytes.Buffer{}, []string{"foo"}))
+	schema, _ := NewSchema(suite.rows)
+	w := NewParquetWriter(&bytes.Buffer{}, schema)
+	assert.Error(suite.T(), w.Write(&appstore.FinancialReport{}))
+	w = NewParquetWriter(&bytes.Buffer{}, schema)
+	w.Compression = Compression(1)
+	assert.NoError(suite.T(), w.Write(suite.rows[0]))
+	assert.Error(suite.T(), w.Close())
+}
+
+func TestParquetWriterTestSuite(t *testing.T) {
+	suite.Run(t, new(ParquetWriterTestSuite))
+}
@@ -0,0 +1,350 @@
+package columnar
+
+import (
+	"database/sql/driver"
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/shopspring/decimal"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//ColumnType column logical type
+type ColumnType string
+
+const (
+	//ColumnTypeString const, UTF-8 text
+	ColumnTypeString ColumnType = "string"
+	//ColumnTypeInteger const, signed 64 bit integer
+	ColumnTypeInteger ColumnType = "integer"
+	//ColumnTypeFloat const, 64 bit float
+	ColumnTypeFloat ColumnType = "float"
+	//ColumnTypeDecimal const, decimal with column precision and scale
+	ColumnTypeDecimal ColumnType = "decimal"
+	//ColumnTypeDate const, calendar date without time zone
+	ColumnTypeDate ColumnType = "date"
+	//ColumnTypeTimestamp const, UTC timestamp with microseconds precision
+	ColumnTypeTimestamp ColumnType = "timestamp"
+	//ColumnTypeBoolean const, boolean
+	ColumnTypeBoolean ColumnType = "boolean"
+)
+
+//DecimalPrecisionDefault const, max number of digits of money values, decimals are stored as 64 bit integers
+const DecimalPrecisionDefault = 18
+
+//DecimalScaleDefault const, number of digits after decimal point of money values
+const DecimalScaleDefault = 6
+
+//Column schema column of report row field
+type Column struct {
+	Name      string     //json tag of field, column name in exported files
+	Header    string     //csv tag of field, Apple's header name
+	Type      ColumnType //logical type
+	Precision int        //decimal precision, up to 18 digits
+	Scale     int        //decimal scale
+	index     []int      //field index in row struct
+}
+
+//Schema columns of report row struct, every column is nullable
+type Schema struct {
+	Columns []*Column
+	rowType reflect.Type
+}
+
+//NewSchema Create schema of report row struct, row may be struct, pointer to struct or slice of them.
+//Columns are exported fields of struct, fields of embedded structs are flattened, fields tagged with "-" are skipped
+func NewSchema(row interface{}) (*Schema, error) {
+	if row == nil {
+		return nil, fmt.Errorf("NewSchema: nil row")
+	}
+	rowType := reflect.TypeOf(row)
+	for rowType.Kind() == reflect.Ptr || rowType.Kind() == reflect.Slice || rowType.Kind() == reflect.Array {
+		rowType = rowType.Elem()
+	}
+	if rowType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("NewSchema: struct row expected, got %v", rowType.Kind())
+	}
+	schema := &Schema{rowType: rowType}
+	if err := schema.addColumns(rowType, nil); err != nil {
+		return nil, fmt.Errorf("NewSchema: %v", err)
+	}
+	if len(schema.Columns) == 0 {
+		return nil, fmt.Errorf("NewSchema: %s has no columns", rowType.Name())
+	}
+	return schema, nil
+}
+
+//Column Get column by name
+func (s *Schema) Column(name string) (*Column, bool) {
+	for _, column := range s.Columns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+	return nil, false
+}
+
+//RowType Get report row struct type of schema
+func (s *Schema) RowType() reflect.Type {
+	return s.rowType
+}
+
+//addColumns Add columns of struct fields
+func (s *Schema) addColumns(t reflect.Type, parent []int) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		index := append(append([]int{}, parent...), i)
+		if field.Tag.Get("csv") == "-" || field.Tag.Get("json") == "-" {
+			continue
+		}
+		columnType, ok := columnTypeOf(field.Type)
+		if !ok && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			//exported fields of embedded structs are promoted even when embedded struct is unexported
+			if err := s.addColumns(field.Type, index); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if !ok {
+			return fmt.Errorf("field %s has unsupported type %v", field.Name, field.Type)
+		}
+		column := &Column{Name: tagName(field, "json"), Header: tagName(field, "csv"), Type: columnType, index: index}
+		if columnType == ColumnTypeDecimal {
+			column.Precision = DecimalPrecisionDefault
+			column.Scale = DecimalScaleDefault
+		}
+		s.Columns = append(s.Columns, column)
+	}
+	return nil
+}
+
+//validate Check schema columns
+func (s *Schema) validate() error {
+	names := make(map[string]bool, len(s.Columns))
+	for _, column := range s.Columns {
+		if column.Name == "" {
+			return fmt.Errorf("column of field %v has no name", column.index)
+		}
+		if names[column.Name] {
+			return fmt.Errorf("column %s is duplicated", column.Name)
+		}
+		names[column.Name] = true
+		if column.Type == ColumnTypeDecimal && (column.Precision < 1 || column.Precision > 18 || column.Scale < 0 || column.Scale > column.Precision) {
+			return fmt.Errorf("column %s has invalid decimal precision %d and scale %d", column.Name, column.Precision, column.Scale)
+		}
+	}
+	return nil
+}
+
+//tagName Get name from struct tag, field name by default
+func tagName(field reflect.StructField, tag string) string {
+	name := strings.Split(field.Tag.Get(tag), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+//columnTypeOf Get column type of field type
+func columnTypeOf(t reflect.Type) (ColumnType, bool) {
+	switch t {
+	case reflect.TypeOf(appstore.Money{}):
+		return ColumnTypeDecimal, true
+	case reflect.TypeOf(appstore.CustomInteger{}):
+		return ColumnTypeInteger, true
+	case reflect.TypeOf(appstore.CustomFloat64{}):
+		return ColumnTypeFloat, true
+	case reflect.TypeOf(appstore.CustomDate{}):
+		return ColumnTypeDate, true
+	case reflect.TypeOf(appstore.CustomTimestamp{}), reflect.TypeOf(time.Time{}):
+		return ColumnTypeTimestamp, true
+	case reflect.TypeOf(appstore.CustomBoolean{}):
+		return ColumnTypeBoolean, true
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return ColumnTypeString, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return ColumnTypeInteger, true
+	case reflect.Float32, reflect.Float64:
+		return ColumnTypeFloat, true
+	case reflect.Bool:
+		return ColumnTypeBoolean, true
+	}
+	return "", false
+}
+
+//value column value of row
+type value struct {
+	null    bool
+	integer int64 //integer, unscaled decimal, days of date or microseconds of timestamp
+	float   float64
+	boolean bool
+	text    string
+}
+
+//Field Get field of column of row struct value
+func (c *Column) Field(row reflect.Value) reflect.Value {
+	return row.FieldByIndex(c.index)
+}
+
+//value Get column value of row struct
+func (c *Column) value(row reflect.Value) (value, error) {
+	v, err := DriverValue(c.Field(row))
+	if err != nil {
+		return value{}, fmt.Errorf("column %s: %v", c.Name, err)
+	}
+	if v == nil {
+		return value{null: true}, nil
+	}
+	switch x := v.(type) {
+	case int64:
+		return value{integer: x}, nil
+	case float64:
+		return value{float: x}, nil
+	case bool:
+		return value{boolean: x}, nil
+	case time.Time:
+		if c.Type == ColumnTypeDate {
+			return value{integer: days(x)}, nil
+		}
+		return value{integer: micros(x)}, nil
+	case string:
+		if c.Type != ColumnTypeDecimal {
+			return value{text: x}, nil
+		}
+		amount, err := decimal.NewFromString(x)
+		if err != nil {
+			return value{}, fmt.Errorf("column %s value %s is not decimal: %v", c.Name, x, err)
+		}
+		unscaled, err := c.unscaled(amount)
+		return value{integer: unscaled}, err
+	}
+	return value{}, fmt.Errorf("column %s has unsupported value %T", c.Name, v)
+}
+
+//DriverValue Get database value of report row field, null and blank values are nil.
+//Custom types and Money are converted by their Valuer, money amounts are decimal strings
+func DriverValue(field reflect.Value) (driver.Value, error) {
+	//Valuer has pointer receiver, fields of rows passed by value are not addressable
+	ptr := reflect.New(field.Type())
+	ptr.Elem().Set(field)
+	if valuer, ok := ptr.Interface().(appstore.SQLValuer); ok {
+		return valuer.Valuer().Value()
+	}
+	if t, ok := field.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return nil, nil
+		}
+		return t, nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		//blank report cells are null like in custom types
+		text := strings.Trim(field.String(), " ")
+		if text == "" {
+			return nil, nil
+		}
+		return text, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int(), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return int64(field.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), nil
+	case reflect.Bool:
+		return field.Bool(), nil
+	}
+	return nil, fmt.Errorf("unsupported type %v", field.Type())
+}
+
+//unscaled Get decimal amount as integer of column scale, amounts which do not fit precision and scale are rejected instead of rounded
+func (c *Column) unscaled(amount decimal.Decimal) (int64, error) {
+	shifted := amount.Shift(int32(c.Scale))
+	if !shifted.Equal(shifted.Truncate(0)) {
+		return 0, fmt.Errorf("column %s value %s has more than %d decimal places", c.Name, amount.String(), c.Scale)
+	}
+	if shifted.Abs().Cmp(decimal.New(1, int32(c.Precision))) >= 0 {
+		return 0, fmt.Errorf("column %s value %s has more than %d digits", c.Name, amount.String(), c.Precision)
+	}
+	return shifted.IntPart(), nil
+}
+
+//days Get days since Unix epoch of calendar date
+func days(date time.Time) int64 {
+	utc := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return utc.Unix() / 86400
+}
+
+//micros Get microseconds since Unix epoch
+func micros(timestamp time.Time) int64 {
+	return timestamp.Unix()*1000000 + int64(timestamp.Nanosecond()/1000)
+}
+
+//rowBuffer rows buffered by columns until written as row group or record batch
+type rowBuffer struct {
+	schema  *Schema
+	columns [][]value
+	rows    int
+}
+
+//newRowBuffer Create row buffer of schema
+func newRowBuffer(schema *Schema) *rowBuffer {
+	return &rowBuffer{schema: schema, columns: make([][]value, len(schema.Columns))}
+}
+
+//add Add row struct or pointer to it, values are copied so row may be reused by caller
+func (b *rowBuffer) add(row interface{}) error {
+	rv := reflect.ValueOf(row)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return fmt.Errorf("nil row")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Type() != b.schema.rowType {
+		return fmt.Errorf("row of type %v expected, got %v", b.schema.rowType, rv.Type())
+	}
+	values := make([]value, len(b.schema.Columns))
+	for i, column := range b.schema.Columns {
+		v, err := column.value(rv)
+		if err != nil {
+			return err
+		}
+		values[i] = v
+	}
+	for i, v := range values {
+		b.columns[i] = append(b.columns[i], v)
+	}
+	b.rows++
+	return nil
+}
+
+//reset Remove buffered rows
+func (b *rowBuffer) reset() {
+	for i := range b.columns {
+		b.columns[i] = b.columns[i][:0]
+	}
+	b.rows = 0
+}
+
+//forEach Call fn with every row of slice
+func forEach(rows interface{}, fn func(row interface{}) error) error {
+	rv := reflect.ValueOf(rows)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("slice of rows expected, got %v", rv.Kind())
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := fn(rv.Index(i).Interface()); err != nil {
+			return fmt.Errorf("row %d: %v", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package columnar
+
+import (
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type schemaTestRow struct {
+	Name      string                 `csv:"Name" json:"name"`
+	Units     appstore.CustomInteger `csv:"Units" json:"units"`
+	Ignored   string                 `csv:"-" json:"ignored"`
+	Proceeds  appstore.Money         `csv:"Proceeds" json:"proceeds"`
+	Date      appstore.CustomDate    `csv:"Date" json:"date"`
+	Timestamp time.Time
+	internal  int
+}
+
+type schemaTestEmbeddedRow struct {
+	schemaTestKey
+	Count int `json:"count"`
+}
+
+type schemaTestKey struct {
+	Country string `json:"country"`
+}
+
+type SchemaTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SchemaTestSuite) TestNewSchema() {
+	schema, err := NewSchema([]*schemaTestRow{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), schema.Columns, 5)
+	assert.Equal(suite.T(), "name", schema.Columns[0].Name)
+	assert.Equal(suite.T(), "Name", schema.Columns[0].Header)
+	assert.Equal(suite.T(), ColumnTypeString, schema.Columns[0].Type)
+	assert.Equal(suite.T(), ColumnTypeInteger, schema.Columns[1].Type)
+	assert.Equal(suite.T(), ColumnTypeDecimal, schema.Columns[2].Type)
+	assert.Equal(suite.T(), DecimalPrecisionDefault, schema.Columns[2].Precision)
+	assert.Equal(suite.T(), DecimalScaleDefault, schema.Columns[2].Scale)
+	assert.Equal(suite.T(), ColumnTypeDate, schema.Columns[3].Type)
+	assert.Equal(suite.T(), "Timestamp", schema.Columns[4].Name)
+	assert.Equal(suite.T(), ColumnTypeTimestamp, schema.Columns[4].Type)
+}
+
+func (suite *SchemaTestSuite) TestNewSchemaReports() {
+	for _, row := range []interface{}{
+		&appstore.SalesReport{},
+		&appstore.SubscriptionsReport{},
+		&appstore.SubscriptionsEventsReport{},
+		&appstore.SubscribersReport{},
+		&appstore.PreOrdersReport{},
+		&appstore.SubscriptionsOffersRedemptionReport{},
+		&appstore.FinancialReport{},
+	} {
+		schema, err := NewSchema(row)
+		assert.NoError(suite.T(), err)
+		assert.NoError(suite.T(), schema.validate())
+		assert.Equal(suite.T(), reflect.TypeOf(row).Elem().NumField(), len(schema.Columns))
+	}
+	schema, _ := NewSchema(appstore.SalesReport{})
+	column, ok := schema.Column("developer_proceeds")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ColumnTypeDecimal, column.Type)
+	column, _ = schema.Column("product_type_identifier")
+	assert.Equal(suite.T(), ColumnTypeString, column.Type)
+	_, ok = schema.Column("foo")
+	assert.False(suite.T(), ok)
+}
+
+func (suite *SchemaTestSuite) TestNewSchemaEmbedded() {
+	schema, err := NewSchema(schemaTestEmbeddedRow{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), schema.Columns, 2)
+	assert.Equal(suite.T(), "country", schema.Columns[0].Name)
+	assert.Equal(suite.T(), "count", schema.Columns[1].Name)
+}
+
+func (suite *SchemaTestSuite) TestField() {
+	schema, _ := NewSchema(&schemaTestEmbeddedRow{})
+	assert.Equal(suite.T(), reflect.TypeOf(schemaTestEmbeddedRow{}), schema.RowType())
+	row := schemaTestEmbeddedRow{schemaTestKey: schemaTestKey{Country: "US"}, Count: 2}
+	assert.Equal(suite.T(), "US", schema.Columns[0].Field(reflect.ValueOf(row)).String())
+	assert.Equal(suite.T(), int64(2), schema.Columns[1].Field(reflect.ValueOf(row)).Int())
+}
+
+func (suite *SchemaTestSuite) TestNewSchemaInvalid() {
+	_, err := NewSchema(nil)
+	assert.Error(suite.T(), err)
+	_, err = NewSchema([]string{})
+	assert.Error(suite.T(), err)
+	_, err = NewSchema(struct{ Foo []int }{})
+	assert.Error(suite.T(), err)
+	_, err = NewSchema(struct{ foo int }{})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SchemaTestSuite) TestValidate() {
+	schema, _ := NewSchema(schemaTestRow{})
+	schema.Columns[2].Precision = 19
+	assert.Error(suite.T(), schema.validate())
+	schema.Columns[2].Precision = 18
+	schema.Columns[1].Name = "name"
+	assert.Error(suite.T(), schema.validate())
+}
+
+func (suite *SchemaTestSuite) TestValues() {
+	schema, _ := NewSchema(schemaTestRow{})
+	buffer := newRowBuffer(schema)
+	date := time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC)
+	row := &schemaTestRow{
+		Name:      " foo ",
+		Units:     appstore.CustomInteger{Integer: 3},
+		Proceeds:  appstore.NewMoney(decimal.RequireFromString("-1.25"), "USD"),
+		Date:      appstore.CustomDate{Date: date},
+		Timestamp: date.Add(1500 * time.Microsecond),
+	}
+	assert.NoError(suite.T(), buffer.add(row))
+	assert.NoError(suite.T(), buffer.add(&schemaTestRow{Units: appstore.CustomInteger{Null: true}, Proceeds: appstore.Money{Null: true}}))
+	assert.Equal(suite.T(), 2, buffer.rows)
+	assert.Equal(suite.T(), value{text: "foo"}, buffer.columns[0][0])
+	assert.Equal(suite.T(), value{integer: 3}, buffer.columns[1][0])
+	assert.Equal(suite.T(), value{integer: -1250000}, buffer.columns[2][0])
+	assert.Equal(suite.T(), value{integer: 18387}, buffer.columns[3][0])
+	assert.Equal(suite.T(), value{integer: 1588636800001500}, buffer.columns[4][0])
+	for i := range schema.Columns {
+		assert.True(suite.T(), buffer.columns[i][1].null)
+	}
+	buffer.reset()
+	assert.Equal(suite.T(), 0, buffer.rows)
+	assert.Empty(suite.T(), buffer.columns[0])
+}
+
+func (suite *SchemaTestSuite) TestValuesInvalid() {
+	schema, _ := NewSchema(schemaTestRow{})
+	buffer := newRowBuffer(schema)
+	assert.Error(suite.T(), buffer.add(&appstore.SalesReport{}))
+	assert.Error(suite.T(), buffer.add((*schemaTestRow)(nil)))
+	assert.Error(suite.T(), buffer.add(&schemaTestRow{Proceeds: appstore.NewMoney(decimal.RequireFromString("0.0000001"), "USD")}))
+	assert.Error(suite.T(), buffer.add(&schemaTestRow{Proceeds: appstore.NewMoney(decimal.RequireFromString("1000000000000"), "USD")}))
+	assert.Equal(suite.T(), 0, buffer.rows)
+}
+
+func (suite *SchemaTestSuite) TestDriverValue() {
+	date := time.Date(2020, 10, 5, 0, 0, 0, 0, time.UTC)
+	row := schemaTestRow{
+		Name:     " ",
+		Units:    appstore.CustomInteger{Integer: 5},
+		Proceeds: appstore.NewMoney(decimal.RequireFromString("1.5"), "USD"),
+		Date:     appstore.CustomDate{Date: date},
+	}
+	rv := reflect.ValueOf(row)
+	for field, expected := range map[string]interface{}{"Name": nil, "Units": int64(5), "Proceeds": "1.50", "Date": date, "Timestamp": nil} {
+		value, err := DriverValue(rv.FieldByName(field))
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), expected, value, field)
+	}
+	value, _ := DriverValue(reflect.ValueOf(appstore.CustomInteger{Null: true}))
+	assert.Nil(suite.T(), value)
+	_, err := DriverValue(reflect.ValueOf([]int{1}))
+	assert.Error(suite.T(), err)
+}
+
+func TestSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(SchemaTestSuite))
+}
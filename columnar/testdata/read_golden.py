@@ -0,0 +1,35 @@
+"""Read Parquet file, Arrow IPC file or Arrow IPC stream with pyarrow and print columns as JSON.
+
+Used by GoldenTestSuite.TestPyArrow to check golden files with the reference implementation:
+python3 read_golden.py golden.parquet
+"""
+import datetime
+import decimal
+import json
+import sys
+
+import pyarrow as pa
+import pyarrow.parquet as pq
+
+
+def read(path):
+    if path.endswith(".parquet"):
+        return pq.read_table(path)
+    with open(path, "rb") as source:
+        if path.endswith(".arrows"):
+            return pa.ipc.open_stream(source).read_all()
+        return pa.ipc.open_file(source).read_all()
+
+
+def convert(value):
+    if isinstance(value, datetime.datetime):
+        if value.tzinfo is None:
+            value = value.replace(tzinfo=datetime.timezone.utc)
+        return value.isoformat()
+    if isinstance(value, (datetime.date, decimal.Decimal)):
+        return str(value)
+    return value
+
+
+table = read(sys.argv[1])
+json.dump({name: [convert(v) for v in table.column(name).to_pylist()] for name in table.column_names}, sys.stdout)
@@ -0,0 +1,138 @@
+package columnar
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+//Thrift compact protocol types used by Parquet metadata
+const (
+	thriftTrue   byte = 1
+	thriftFalse  byte = 2
+	thriftByte   byte = 3
+	thriftI32    byte = 5
+	thriftI64    byte = 6
+	thriftBinary byte = 8
+	thriftList   byte = 9
+	thriftStruct byte = 12
+)
+
+//thriftWriter Thrift compact protocol encoder of Parquet metadata structs
+type thriftWriter struct {
+	buf  bytes.Buffer
+	last []int16 //last field id of every open struct
+}
+
+//newThriftWriter Create encoder of root struct
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{last: []int16{0}}
+}
+
+//field Write field header
+func (w *thriftWriter) field(id int16, typ byte) {
+	delta := id - w.last[len(w.last)-1]
+	if delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+	w.last[len(w.last)-1] = id
+}
+
+//uvarint Write unsigned varint
+func (w *thriftWriter) uvarint(v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	w.buf.Write(buf[:n])
+}
+
+//varint Write zigzag varint
+func (w *thriftWriter) varint(v int64) {
+	w.uvarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+//i8 Write byte field
+func (w *thriftWriter) i8(id int16, v int8) {
+	w.field(id, thriftByte)
+	w.buf.WriteByte(byte(v))
+}
+
+//i32 Write int32 field
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.varint(int64(v))
+}
+
+//i64 Write int64 field
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.varint(v)
+}
+
+//bool Write bool field, value is part of field header
+func (w *thriftWriter) bool(id int16, v bool) {
+	if v {
+		w.field(id, thriftTrue)
+	} else {
+		w.field(id, thriftFalse)
+	}
+}
+
+//string Write string field
+func (w *thriftWriter) string(id int16, v string) {
+	w.field(id, thriftBinary)
+	w.uvarint(uint64(len(v)))
+	w.buf.WriteString(v)
+}
+
+//structBegin Write struct field header, struct is closed by structEnd
+func (w *thriftWriter) structBegin(id int16) {
+	w.field(id, thriftStruct)
+	w.elemBegin()
+}
+
+//structEnd Close struct
+func (w *thriftWriter) structEnd() {
+	w.buf.WriteByte(0)
+	w.last = w.last[:len(w.last)-1]
+}
+
+//listBegin Write list field header, elements are written by caller
+func (w *thriftWriter) listBegin(id int16, elemType byte, size int) {
+	w.field(id, thriftList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+		return
+	}
+	w.buf.WriteByte(0xf0 | elemType)
+	w.uvarint(uint64(size))
+}
+
+//elemBegin Begin struct element of list, element is closed by structEnd
+func (w *thriftWriter) elemBegin() {
+	w.last = append(w.last, 0)
+}
+
+//i32List Write list of int32 field
+func (w *thriftWriter) i32List(id int16, values ...int32) {
+	w.listBegin(id, thriftI32, len(values))
+	for _, v := range values {
+		w.varint(int64(v))
+	}
+}
+
+//stringList Write list of strings field
+func (w *thriftWriter) stringList(id int16, values ...string) {
+	w.listBegin(id, thriftBinary, len(values))
+	for _, v := range values {
+		w.uvarint(uint64(len(v)))
+		w.buf.WriteString(v)
+	}
+}
+
+//bytes Get encoded root struct, closes root struct
+func (w *thriftWriter) bytes() []byte {
+	w.structEnd()
+	return w.buf.Bytes()
+}
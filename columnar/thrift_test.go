@@ -0,0 +1,58 @@
+package columnar
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type ThriftWriterTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ThriftWriterTestSuite) TestFieldHeaders() {
+	w := newThriftWriter()
+	w.i32(1, -1)
+	w.i64(20, 300)
+	w.bool(21, true)
+	w.bool(22, false)
+	w.i8(5, -2)
+	assert.Equal(suite.T(), []byte{0x15, 0x01, 0x06, 0x28, 0xd8, 0x04, 0x11, 0x12, 0x03, 0x0a, 0xfe, 0x00}, w.bytes())
+}
+
+func (suite *ThriftWriterTestSuite) TestNested() {
+	w := newThriftWriter()
+	w.structBegin(2)
+	w.string(1, "foo")
+	w.structEnd()
+	w.listBegin(3, thriftStruct, 1)
+	w.elemBegin()
+	w.i32(1, 1)
+	w.structEnd()
+	w.i32(4, 2)
+	data := w.bytes()
+	assert.Equal(suite.T(), []byte{0x2c, 0x18, 0x03, 'f', 'o', 'o', 0x00, 0x19, 0x1c, 0x15, 0x02, 0x00, 0x15, 0x04, 0x00}, data)
+
+	r := &thriftReader{data: data}
+	assert.Equal(suite.T(), map[int16]interface{}{
+		2: map[int16]interface{}{1: "foo"},
+		3: []interface{}{map[int16]interface{}{1: int64(1)}},
+		4: int64(2),
+	}, r.structValue())
+}
+
+func (suite *ThriftWriterTestSuite) TestLongLists() {
+	values := strings.Split(strings.Repeat("a,", 20), ",")
+	w := newThriftWriter()
+	w.stringList(1, values...)
+	w.i32List(2, make([]int32, 16)...)
+	r := &thriftReader{data: w.bytes()}
+	fields := r.structValue()
+	assert.Len(suite.T(), fields[1], 21)
+	assert.Len(suite.T(), fields[2], 16)
+}
+
+func TestThriftWriterTestSuite(t *testing.T) {
+	suite.Run(t, new(ThriftWriterTestSuite))
+}
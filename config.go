@@ -11,6 +11,9 @@ const AppStoreConnectAPIAudience = "appstoreconnect-v1"
 //AppStoreConnectAPITokenTtl const
 const AppStoreConnectAPITokenTtl = 600
 
+//AppStoreConnectAPITokenRenewBefore const, seconds before expiry token is signed again
+const AppStoreConnectAPITokenRenewBefore = 60
+
 //AppStoreConnectAPIHttpMaxIdleConnection const
 const AppStoreConnectAPIHttpMaxIdleConnection = 10
 
@@ -0,0 +1,336 @@
+package appstore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	//FinanceRegionCodeEuroZone Euro-Zone region code
+	FinanceRegionCodeEuroZone = "EU"
+	//FinanceRegionCodeLatinAmerica Latin America and the Caribbean region code
+	FinanceRegionCodeLatinAmerica = "LL"
+	//FinanceRegionCodeRestOfWorld Rest of World region code
+	FinanceRegionCodeRestOfWorld = "WW"
+	//FinanceRegionCodeAll Consolidated financial report of all regions
+	FinanceRegionCodeAll = "ZZ"
+	//FinanceRegionCodeFinanceDetail Finance detail report of all regions, the only region of FINANCE_DETAIL report type
+	FinanceRegionCodeFinanceDetail = "Z1"
+)
+
+//FinanceRegion Apple financial report region, see Financial Report Regions and Currencies
+type FinanceRegion struct {
+	Code         string
+	Name         string
+	Currency     string //report currency, blank for consolidated regions
+	Consolidated bool   //report of all regions
+}
+
+//Countries Get countries of region, sorted by code
+func (r *FinanceRegion) Countries() []*Country {
+	countries := make([]*Country, 0)
+	for _, country := range countryTable {
+		if country.RegionCode == r.Code || r.Consolidated {
+			countries = append(countries, country)
+		}
+	}
+	return countries
+}
+
+//Country App Store country or territory
+type Country struct {
+	Code         string //ISO 3166-1 alpha-2 code
+	Name         string
+	RegionCode   string //financial report region code
+	StorefrontID int    //App Store storefront ID
+}
+
+//FinanceRegion Get financial report region of country
+func (c *Country) FinanceRegion() *FinanceRegion {
+	region, _ := LookupFinanceRegion(c.RegionCode)
+	return region
+}
+
+//Currency Get financial report currency of country
+func (c *Country) Currency() string {
+	if region := c.FinanceRegion(); region != nil {
+		return region.Currency
+	}
+	return ""
+}
+
+//financeRegionTable Apple financial report regions and currencies
+var financeRegionTable = []*FinanceRegion{
+	{Code: "US", Name: "United States", Currency: "USD"},
+	{Code: "CA", Name: "Canada", Currency: "CAD"},
+	{Code: "MX", Name: "Mexico", Currency: "MXN"},
+	{Code: "BR", Name: "Brazil", Currency: "BRL"},
+	{Code: "CL", Name: "Chile", Currency: "CLP"},
+	{Code: "CO", Name: "Colombia", Currency: "COP"},
+	{Code: "PE", Name: "Peru", Currency: "PEN"},
+	{Code: FinanceRegionCodeLatinAmerica, Name: "Latin America and the Caribbean", Currency: "USD"},
+	{Code: FinanceRegionCodeEuroZone, Name: "Euro-Zone", Currency: "EUR"},
+	{Code: "GB", Name: "United Kingdom", Currency: "GBP"},
+	{Code: "CH", Name: "Switzerland", Currency: "CHF"},
+	{Code: "DK", Name: "Denmark", Currency: "DKK"},
+	{Code: "NO", Name: "Norway", Currency: "NOK"},
+	{Code: "SE", Name: "Sweden", Currency: "SEK"},
+	{Code: "PL", Name: "Poland", Currency: "PLN"},
+	{Code: "CZ", Name: "Czech Republic", Currency: "CZK"},
+	{Code: "HU", Name: "Hungary", Currency: "HUF"},
+	{Code: "RO", Name: "Romania", Currency: "RON"},
+	{Code: "BG", Name: "Bulgaria", Currency: "BGN"},
+	{Code: "RU", Name: "Russia", Currency: "RUB"},
+	{Code: "TR", Name: "Turkey", Currency: "TRY"},
+	{Code: "IL", Name: "Israel", Currency: "ILS"},
+	{Code: "SA", Name: "Saudi Arabia", Currency: "SAR"},
+	{Code: "AE", Name: "United Arab Emirates", Currency: "AED"},
+	{Code: "QA", Name: "Qatar", Currency: "QAR"},
+	{Code: "EG", Name: "Egypt", Currency: "EGP"},
+	{Code: "NG", Name: "Nigeria", Currency: "NGN"},
+	{Code: "ZA", Name: "South Africa", Currency: "ZAR"},
+	{Code: "TZ", Name: "Tanzania", Currency: "TZS"},
+	{Code: "KZ", Name: "Kazakhstan", Currency: "KZT"},
+	{Code: "PK", Name: "Pakistan", Currency: "PKR"},
+	{Code: "AU", Name: "Australia", Currency: "AUD"},
+	{Code: "NZ", Name: "New Zealand", Currency: "NZD"},
+	{Code: "JP", Name: "Japan", Currency: "JPY"},
+	{Code: "CN", Name: "China mainland", Currency: "CNY"},
+	{Code: "HK", Name: "Hong Kong", Currency: "HKD"},
+	{Code: "TW", Name: "Taiwan", Currency: "TWD"},
+	{Code: "KR", Name: "Republic of Korea", Currency: "KRW"},
+	{Code: "SG", Name: "Singapore", Currency: "SGD"},
+	{Code: "ID", Name: "Indonesia", Currency: "IDR"},
+	{Code: "IN", Name: "India", Currency: "INR"},
+	{Code: "MY", Name: "Malaysia", Currency: "MYR"},
+	{Code: "PH", Name: "Philippines", Currency: "PHP"},
+	{Code: "TH", Name: "Thailand", Currency: "THB"},
+	{Code: "VN", Name: "Vietnam", Currency: "VND"},
+	{Code: FinanceRegionCodeRestOfWorld, Name: "Rest of World", Currency: "USD"},
+	{Code: FinanceRegionCodeAll, Name: "All Regions", Consolidated: true},
+	{Code: FinanceRegionCodeFinanceDetail, Name: "All Regions (Finance Detail)", Consolidated: true},
+}
+
+//countryTable App Store countries and territories, sorted by code
+var countryTable = []*Country{
+	{Code: "AE", Name: "United Arab Emirates", RegionCode: "AE", StorefrontID: 143481},
+	{Code: "AG", Name: "Antigua and Barbuda", RegionCode: "LL", StorefrontID: 143540},
+	{Code: "AI", Name: "Anguilla", RegionCode: "LL", StorefrontID: 143538},
+	{Code: "AL", Name: "Albania", RegionCode: "WW", StorefrontID: 143575},
+	{Code: "AM", Name: "Armenia", RegionCode: "WW", StorefrontID: 143524},
+	{Code: "AO", Name: "Angola", RegionCode: "WW", StorefrontID: 143564},
+	{Code: "AR", Name: "Argentina", RegionCode: "LL", StorefrontID: 143505},
+	{Code: "AT", Name: "Austria", RegionCode: "EU", StorefrontID: 143445},
+	{Code: "AU", Name: "Australia", RegionCode: "AU", StorefrontID: 143460},
+	{Code: "AZ", Name: "Azerbaijan", RegionCode: "WW", StorefrontID: 143568},
+	{Code: "BB", Name: "Barbados", RegionCode: "LL", StorefrontID: 143541},
+	{Code: "BE", Name: "Belgium", RegionCode: "EU", StorefrontID: 143446},
+	{Code: "BF", Name: "Burkina Faso", RegionCode: "WW", StorefrontID: 143578},
+	{Code: "BG", Name: "Bulgaria", RegionCode: "BG", StorefrontID: 143526},
+	{Code: "BH", Name: "Bahrain", RegionCode: "WW", StorefrontID: 143559},
+	{Code: "BJ", Name: "Benin", RegionCode: "WW", StorefrontID: 143576},
+	{Code: "BM", Name: "Bermuda", RegionCode: "LL", StorefrontID: 143542},
+	{Code: "BN", Name: "Brunei", RegionCode: "WW", StorefrontID: 143560},
+	{Code: "BO", Name: "Bolivia", RegionCode: "LL", StorefrontID: 143556},
+	{Code: "BR", Name: "Brazil", RegionCode: "BR", StorefrontID: 143503},
+	{Code: "BS", Name: "Bahamas", RegionCode: "LL", StorefrontID: 143539},
+	{Code: "BT", Name: "Bhutan", RegionCode: "WW", StorefrontID: 143577},
+	{Code: "BW", Name: "Botswana", RegionCode: "WW", StorefrontID: 143525},
+	{Code: "BY", Name: "Belarus", RegionCode: "WW", StorefrontID: 143565},
+	{Code: "BZ", Name: "Belize", RegionCode: "LL", StorefrontID: 143555},
+	{Code: "CA", Name: "Canada", RegionCode: "CA", StorefrontID: 143455},
+	{Code: "CG", Name: "Republic of the Congo", RegionCode: "WW", StorefrontID: 143582},
+	{Code: "CH", Name: "Switzerland", RegionCode: "CH", StorefrontID: 143459},
+	{Code: "CL", Name: "Chile", RegionCode: "CL", StorefrontID: 143483},
+	{Code: "CN", Name: "China mainland", RegionCode: "CN", StorefrontID: 143465},
+	{Code: "CO", Name: "Colombia", RegionCode: "CO", StorefrontID: 143501},
+	{Code: "CR", Name: "Costa Rica", RegionCode: "LL", StorefrontID: 143495},
+	{Code: "CV", Name: "Cape Verde", RegionCode: "WW", StorefrontID: 143580},
+	{Code: "CY", Name: "Cyprus", RegionCode: "EU", StorefrontID: 143557},
+	{Code: "CZ", Name: "Czech Republic", RegionCode: "CZ", StorefrontID: 143489},
+	{Code: "DE", Name: "Germany", RegionCode: "EU", StorefrontID: 143443},
+	{Code: "DK", Name: "Denmark", RegionCode: "DK", StorefrontID: 143458},
+	{Code: "DM", Name: "Dominica", RegionCode: "LL", StorefrontID: 143545},
+	{Code: "DO", Name: "Dominican Republic", RegionCode: "LL", StorefrontID: 143508},
+	{Code: "DZ", Name: "Algeria", RegionCode: "WW", StorefrontID: 143563},
+	{Code: "EC", Name: "Ecuador", RegionCode: "LL", StorefrontID: 143509},
+	{Code: "EE", Name: "Estonia", RegionCode: "EU", StorefrontID: 143518},
+	{Code: "EG", Name: "Egypt", RegionCode: "EG", StorefrontID: 143516},
+	{Code: "ES", Name: "Spain", RegionCode: "EU", StorefrontID: 143454},
+	{Code: "FI", Name: "Finland", RegionCode: "EU", StorefrontID: 143447},
+	{Code: "FJ", Name: "Fiji", RegionCode: "WW", StorefrontID: 143583},
+	{Code: "FM", Name: "Micronesia", RegionCode: "WW", StorefrontID: 143591},
+	{Code: "FR", Name: "France", RegionCode: "EU", StorefrontID: 143442},
+	{Code: "GB", Name: "United Kingdom", RegionCode: "GB", StorefrontID: 143444},
+	{Code: "GD", Name: "Grenada", RegionCode: "LL", StorefrontID: 143546},
+	{Code: "GH", Name: "Ghana", RegionCode: "WW", StorefrontID: 143573},
+	{Code: "GM", Name: "Gambia", RegionCode: "WW", StorefrontID: 143584},
+	{Code: "GR", Name: "Greece", RegionCode: "EU", StorefrontID: 143448},
+	{Code: "GT", Name: "Guatemala", RegionCode: "LL", StorefrontID: 143504},
+	{Code: "GW", Name: "Guinea-Bissau", RegionCode: "WW", StorefrontID: 143585},
+	{Code: "GY", Name: "Guyana", RegionCode: "LL", StorefrontID: 143553},
+	{Code: "HK", Name: "Hong Kong", RegionCode: "HK", StorefrontID: 143463},
+	{Code: "HN", Name: "Honduras", RegionCode: "LL", StorefrontID: 143510},
+	{Code: "HR", Name: "Croatia", RegionCode: "EU", StorefrontID: 143494},
+	{Code: "HU", Name: "Hungary", RegionCode: "HU", StorefrontID: 143482},
+	{Code: "ID", Name: "Indonesia", RegionCode: "ID", StorefrontID: 143476},
+	{Code: "IE", Name: "Ireland", RegionCode: "EU", StorefrontID: 143449},
+	{Code: "IL", Name: "Israel", RegionCode: "IL", StorefrontID: 143491},
+	{Code: "IN", Name: "India", RegionCode: "IN", StorefrontID: 143467},
+	{Code: "IS", Name: "Iceland", RegionCode: "WW", StorefrontID: 143558},
+	{Code: "IT", Name: "Italy", RegionCode: "EU", StorefrontID: 143450},
+	{Code: "JM", Name: "Jamaica", RegionCode: "LL", StorefrontID: 143511},
+	{Code: "JO", Name: "Jordan", RegionCode: "WW", StorefrontID: 143528},
+	{Code: "JP", Name: "Japan", RegionCode: "JP", StorefrontID: 143462},
+	{Code: "KE", Name: "Kenya", RegionCode: "WW", StorefrontID: 143529},
+	{Code: "KG", Name: "Kyrgyzstan", RegionCode: "WW", StorefrontID: 143586},
+	{Code: "KH", Name: "Cambodia", RegionCode: "WW", StorefrontID: 143579},
+	{Code: "KN", Name: "St. Kitts and Nevis", RegionCode: "LL", StorefrontID: 143548},
+	{Code: "KR", Name: "Republic of Korea", RegionCode: "KR", StorefrontID: 143466},
+	{Code: "KW", Name: "Kuwait", RegionCode: "WW", StorefrontID: 143493},
+	{Code: "KY", Name: "Cayman Islands", RegionCode: "LL", StorefrontID: 143544},
+	{Code: "KZ", Name: "Kazakhstan", RegionCode: "KZ", StorefrontID: 143517},
+	{Code: "LA", Name: "Laos", RegionCode: "WW", StorefrontID: 143587},
+	{Code: "LB", Name: "Lebanon", RegionCode: "WW", StorefrontID: 143497},
+	{Code: "LC", Name: "St. Lucia", RegionCode: "LL", StorefrontID: 143549},
+	{Code: "LK", Name: "Sri Lanka", RegionCode: "WW", StorefrontID: 143486},
+	{Code: "LR", Name: "Liberia", RegionCode: "WW", StorefrontID: 143588},
+	{Code: "LT", Name: "Lithuania", RegionCode: "EU", StorefrontID: 143520},
+	{Code: "LU", Name: "Luxembourg", RegionCode: "EU", StorefrontID: 143451},
+	{Code: "LV", Name: "Latvia", RegionCode: "EU", StorefrontID: 143519},
+	{Code: "MD", Name: "Moldova", RegionCode: "WW", StorefrontID: 143523},
+	{Code: "MG", Name: "Madagascar", RegionCode: "WW", StorefrontID: 143531},
+	{Code: "MK", Name: "North Macedonia", RegionCode: "WW", StorefrontID: 143530},
+	{Code: "ML", Name: "Mali", RegionCode: "WW", StorefrontID: 143532},
+	{Code: "MN", Name: "Mongolia", RegionCode: "WW", StorefrontID: 143592},
+	{Code: "MO", Name: "Macao", RegionCode: "WW", StorefrontID: 143515},
+	{Code: "MR", Name: "Mauritania", RegionCode: "WW", StorefrontID: 143590},
+	{Code: "MS", Name: "Montserrat", RegionCode: "LL", StorefrontID: 143547},
+	{Code: "MT", Name: "Malta", RegionCode: "EU", StorefrontID: 143521},
+	{Code: "MU", Name: "Mauritius", RegionCode: "WW", StorefrontID: 143533},
+	{Code: "MW", Name: "Malawi", RegionCode: "WW", StorefrontID: 143589},
+	{Code: "MX", Name: "Mexico", RegionCode: "MX", StorefrontID: 143468},
+	{Code: "MY", Name: "Malaysia", RegionCode: "MY", StorefrontID: 143473},
+	{Code: "MZ", Name: "Mozambique", RegionCode: "WW", StorefrontID: 143593},
+	{Code: "NA", Name: "Namibia", RegionCode: "WW", StorefrontID: 143594},
+	{Code: "NE", Name: "Niger", RegionCode: "WW", StorefrontID: 143534},
+	{Code: "NG", Name: "Nigeria", RegionCode: "NG", StorefrontID: 143561},
+	{Code: "NI", Name: "Nicaragua", RegionCode: "LL", StorefrontID: 143512},
+	{Code: "NL", Name: "Netherlands", RegionCode: "EU", StorefrontID: 143452},
+	{Code: "NO", Name: "Norway", RegionCode: "NO", StorefrontID: 143457},
+	{Code: "NP", Name: "Nepal", RegionCode: "WW", StorefrontID: 143484},
+	{Code: "NZ", Name: "New Zealand", RegionCode: "NZ", StorefrontID: 143461},
+	{Code: "OM", Name: "Oman", RegionCode: "WW", StorefrontID: 143562},
+	{Code: "PA", Name: "Panama", RegionCode: "LL", StorefrontID: 143485},
+	{Code: "PE", Name: "Peru", RegionCode: "PE", StorefrontID: 143507},
+	{Code: "PG", Name: "Papua New Guinea", RegionCode: "WW", StorefrontID: 143597},
+	{Code: "PH", Name: "Philippines", RegionCode: "PH", StorefrontID: 143474},
+	{Code: "PK", Name: "Pakistan", RegionCode: "PK", StorefrontID: 143477},
+	{Code: "PL", Name: "Poland", RegionCode: "PL", StorefrontID: 143478},
+	{Code: "PT", Name: "Portugal", RegionCode: "EU", StorefrontID: 143453},
+	{Code: "PW", Name: "Palau", RegionCode: "WW", StorefrontID: 143595},
+	{Code: "PY", Name: "Paraguay", RegionCode: "LL", StorefrontID: 143513},
+	{Code: "QA", Name: "Qatar", RegionCode: "QA", StorefrontID: 143498},
+	{Code: "RO", Name: "Romania", RegionCode: "RO", StorefrontID: 143487},
+	{Code: "RU", Name: "Russia", RegionCode: "RU", StorefrontID: 143469},
+	{Code: "SA", Name: "Saudi Arabia", RegionCode: "SA", StorefrontID: 143479},
+	{Code: "SB", Name: "Solomon Islands", RegionCode: "WW", StorefrontID: 143601},
+	{Code: "SC", Name: "Seychelles", RegionCode: "WW", StorefrontID: 143599},
+	{Code: "SE", Name: "Sweden", RegionCode: "SE", StorefrontID: 143456},
+	{Code: "SG", Name: "Singapore", RegionCode: "SG", StorefrontID: 143464},
+	{Code: "SI", Name: "Slovenia", RegionCode: "EU", StorefrontID: 143499},
+	{Code: "SK", Name: "Slovakia", RegionCode: "EU", StorefrontID: 143496},
+	{Code: "SL", Name: "Sierra Leone", RegionCode: "WW", StorefrontID: 143600},
+	{Code: "SN", Name: "Senegal", RegionCode: "WW", StorefrontID: 143535},
+	{Code: "SR", Name: "Suriname", RegionCode: "LL", StorefrontID: 143554},
+	{Code: "ST", Name: "Sao Tome and Principe", RegionCode: "WW", StorefrontID: 143598},
+	{Code: "SV", Name: "El Salvador", RegionCode: "LL", StorefrontID: 143506},
+	{Code: "SZ", Name: "Eswatini", RegionCode: "WW", StorefrontID: 143602},
+	{Code: "TC", Name: "Turks and Caicos Islands", RegionCode: "LL", StorefrontID: 143552},
+	{Code: "TD", Name: "Chad", RegionCode: "WW", StorefrontID: 143581},
+	{Code: "TH", Name: "Thailand", RegionCode: "TH", StorefrontID: 143475},
+	{Code: "TJ", Name: "Tajikistan", RegionCode: "WW", StorefrontID: 143603},
+	{Code: "TM", Name: "Turkmenistan", RegionCode: "WW", StorefrontID: 143604},
+	{Code: "TN", Name: "Tunisia", RegionCode: "WW", StorefrontID: 143536},
+	{Code: "TR", Name: "Turkey", RegionCode: "TR", StorefrontID: 143480},
+	{Code: "TT", Name: "Trinidad and Tobago", RegionCode: "LL", StorefrontID: 143551},
+	{Code: "TW", Name: "Taiwan", RegionCode: "TW", StorefrontID: 143470},
+	{Code: "TZ", Name: "Tanzania", RegionCode: "TZ", StorefrontID: 143572},
+	{Code: "UA", Name: "Ukraine", RegionCode: "WW", StorefrontID: 143492},
+	{Code: "UG", Name: "Uganda", RegionCode: "WW", StorefrontID: 143537},
+	{Code: "US", Name: "United States", RegionCode: "US", StorefrontID: 143441},
+	{Code: "UY", Name: "Uruguay", RegionCode: "LL", StorefrontID: 143514},
+	{Code: "UZ", Name: "Uzbekistan", RegionCode: "WW", StorefrontID: 143566},
+	{Code: "VC", Name: "St. Vincent and the Grenadines", RegionCode: "LL", StorefrontID: 143550},
+	{Code: "VE", Name: "Venezuela", RegionCode: "LL", StorefrontID: 143502},
+	{Code: "VG", Name: "British Virgin Islands", RegionCode: "LL", StorefrontID: 143543},
+	{Code: "VN", Name: "Vietnam", RegionCode: "VN", StorefrontID: 143471},
+	{Code: "YE", Name: "Yemen", RegionCode: "WW", StorefrontID: 143571},
+	{Code: "ZA", Name: "South Africa", RegionCode: "ZA", StorefrontID: 143472},
+	{Code: "ZW", Name: "Zimbabwe", RegionCode: "WW", StorefrontID: 143605},
+}
+
+//normalizeCode Normalize country or region code
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.Trim(code, " "))
+}
+
+//LookupFinanceRegion Get financial report region by code, e.g. US, EU, WW, ZZ
+func LookupFinanceRegion(code string) (*FinanceRegion, bool) {
+	code = normalizeCode(code)
+	for _, region := range financeRegionTable {
+		if region.Code == code {
+			return region, true
+		}
+	}
+	return nil, false
+}
+
+//FinanceRegions Get financial report regions, separate regions first
+func FinanceRegions() []*FinanceRegion {
+	return append([]*FinanceRegion{}, financeRegionTable...)
+}
+
+//ValidateRegionCode Check financial report region code is known
+func ValidateRegionCode(code string) error {
+	if _, ok := LookupFinanceRegion(code); !ok {
+		return fmt.Errorf("ValidateRegionCode: unknown region code %s", code)
+	}
+	return nil
+}
+
+//LookupCountry Get country by ISO 3166-1 alpha-2 code, e.g. CountryCode, CountryOfSale, Territory or ProviderCountry of report rows
+func LookupCountry(code string) (*Country, bool) {
+	code = normalizeCode(code)
+	i := sort.Search(len(countryTable), func(i int) bool {
+		return countryTable[i].Code >= code
+	})
+	if i < len(countryTable) && countryTable[i].Code == code {
+		return countryTable[i], true
+	}
+	return nil, false
+}
+
+//LookupCountryByStorefrontID Get country by App Store storefront ID
+func LookupCountryByStorefrontID(id int) (*Country, bool) {
+	for _, country := range countryTable {
+		if country.StorefrontID == id {
+			return country, true
+		}
+	}
+	return nil, false
+}
+
+//CountryName Get country name by code, code itself for unknown countries
+func CountryName(code string) string {
+	if country, ok := LookupCountry(code); ok {
+		return country.Name
+	}
+	return code
+}
+
+//Countries Get App Store countries and territories, sorted by code
+func Countries() []*Country {
+	return append([]*Country{}, countryTable...)
+}
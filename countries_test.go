@@ -0,0 +1,93 @@
+package appstore
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type CountriesTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CountriesTestSuite) TestCountryTableIsSortedAndValid() {
+	for i, country := range countryTable {
+		if i > 0 {
+			assert.True(suite.T(), countryTable[i-1].Code < country.Code, country.Code)
+		}
+		assert.Len(suite.T(), country.Code, 2)
+		assert.NotNil(suite.T(), country.FinanceRegion(), country.Code)
+		assert.NotZero(suite.T(), country.StorefrontID, country.Code)
+	}
+}
+
+func (suite *CountriesTestSuite) TestLookupCountry() {
+	country, ok := LookupCountry(" us")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "United States", country.Name)
+	assert.Equal(suite.T(), 143441, country.StorefrontID)
+	assert.Equal(suite.T(), "USD", country.Currency())
+	country, ok = LookupCountry("DE")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), FinanceRegionCodeEuroZone, country.RegionCode)
+	assert.Equal(suite.T(), "EUR", country.Currency())
+	country, ok = LookupCountry("AR")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), FinanceRegionCodeLatinAmerica, country.RegionCode)
+	country, ok = LookupCountry("UA")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), FinanceRegionCodeRestOfWorld, country.RegionCode)
+	_, ok = LookupCountry("XX")
+	assert.False(suite.T(), ok)
+}
+
+func (suite *CountriesTestSuite) TestLookupCountryByStorefrontID() {
+	country, ok := LookupCountryByStorefrontID(143469)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "RU", country.Code)
+	_, ok = LookupCountryByStorefrontID(1)
+	assert.False(suite.T(), ok)
+}
+
+func (suite *CountriesTestSuite) TestCountryName() {
+	assert.Equal(suite.T(), "Japan", CountryName("JP"))
+	assert.Equal(suite.T(), "XX", CountryName("XX"))
+}
+
+func (suite *CountriesTestSuite) TestLookupFinanceRegion() {
+	region, ok := LookupFinanceRegion("eu")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "Euro-Zone", region.Name)
+	assert.Equal(suite.T(), "EUR", region.Currency)
+	codes := []string{}
+	for _, country := range region.Countries() {
+		codes = append(codes, country.Code)
+	}
+	assert.Contains(suite.T(), codes, "FR")
+	assert.NotContains(suite.T(), codes, "GB")
+	region, ok = LookupFinanceRegion(FinanceRegionCodeAll)
+	assert.True(suite.T(), ok)
+	assert.True(suite.T(), region.Consolidated)
+	assert.Len(suite.T(), region.Countries(), len(countryTable))
+	_, ok = LookupFinanceRegion("XX")
+	assert.False(suite.T(), ok)
+}
+
+func (suite *CountriesTestSuite) TestValidateRegionCode() {
+	assert.NoError(suite.T(), ValidateRegionCode("WW"))
+	assert.NoError(suite.T(), ValidateRegionCode("Z1"))
+	err := ValidateRegionCode("XX")
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "ValidateRegionCode: unknown region code XX", err.Error())
+}
+
+func (suite *CountriesTestSuite) TestStubReportCountriesAreKnown() {
+	for _, code := range []string{"RU", "UA", "KZ", "BY", "US", "NG", "ZA", "UZ", "AZ", "KW"} {
+		_, ok := LookupCountry(code)
+		assert.True(suite.T(), ok, code)
+	}
+}
+
+func TestCountriesTestSuite(t *testing.T) {
+	suite.Run(t, new(CountriesTestSuite))
+}
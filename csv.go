@@ -2,16 +2,42 @@ package appstore
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/csv"
+	"fmt"
 	"github.com/gocarina/gocsv"
+	"github.com/shopspring/decimal"
 	"io"
+	"reflect"
+	"strconv"
 	"strings"
 )
 
+//csvHeaderAliases Apple's report header names which differ from struct csv tags
+var csvHeaderAliases = map[string]string{
+	"SalesReportVersion":                               "Version",
+	"ISRC / ISBN":                                      "ISRC/ISBN",
+	"Artist / Show / Developer / Author":               "Artist/Show/Developer/Author",
+	"Label / Studio / Network / Developer / Publisher": "Label/Studio/Network/Developer/Publisher",
+	"ISAN / Other Identifier":                          "ISAN/Other Identifier",
+}
+
+//csvDateFormats Date format by report row type, CustomDateFormatSlash is used by default
+var csvDateFormats = map[reflect.Type]string{
+	reflect.TypeOf(SubscriptionsEventsReport{}):           CustomDateFormatDefault,
+	reflect.TypeOf(SubscribersReport{}):                   CustomDateFormatDefault,
+	reflect.TypeOf(SubscriptionsOffersRedemptionReport{}): CustomDateFormatDefault,
+}
+
 //UnmarshalCSV unmarshal raw data to structures
 func UnmarshalCSV(in []byte, out interface{}) error {
 	r := NewCSVReader(bytes.NewReader(in))
-	return gocsv.UnmarshalCSV(r, out)
+	err := gocsv.UnmarshalCSV(r, out)
+	if err != nil {
+		return err
+	}
+	bindMoneyCurrencies(out)
+	return nil
 }
 
 //UnmarshalCSVWithFilterLines unmarshal raw data to structures with filter lines
@@ -20,7 +46,146 @@ func UnmarshalCSVWithFilterLines(in []byte, out interface{}) error {
 	if err != nil {
 		return err
 	}
-	return gocsv.UnmarshalDecoder(decoder, out)
+	err = gocsv.UnmarshalDecoder(decoder, out)
+	if err != nil {
+		return err
+	}
+	bindMoneyCurrencies(out)
+	return nil
+}
+
+//MarshalCSV marshal report rows to Apple's TSV format
+func MarshalCSV(in interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	err := WriteCSV(&buf, in)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+//MarshalCSVGzip marshal report rows to gzipped Apple's TSV format
+func MarshalCSVGzip(in interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	err := WriteCSV(zw, in)
+	if err != nil {
+		return nil, err
+	}
+	if err = zw.Close(); err != nil {
+		return nil, fmt.Errorf("MarshalCSVGzip: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+//WriteCSV write report rows slice to out in Apple's TSV format
+func WriteCSV(out io.Writer, in interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(in))
+	if rv.Kind() != reflect.Slice {
+		return fmt.Errorf("WriteCSV: slice expected, got %v", rv.Kind())
+	}
+	rowType := rv.Type().Elem()
+	if rowType.Kind() == reflect.Ptr {
+		rowType = rowType.Elem()
+	}
+	if rowType.Kind() != reflect.Struct {
+		return fmt.Errorf("WriteCSV: slice of structs expected, got %v", rowType.Kind())
+	}
+	dateFormat, ok := csvDateFormats[rowType]
+	if !ok {
+		dateFormat = CustomDateFormatSlash
+	}
+
+	w := NewCSVWriter(out)
+	header := make([]string, 0, rowType.NumField())
+	for i := 0; i < rowType.NumField(); i++ {
+		header = append(header, csvHeaderName(rowType.Field(i)))
+	}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("WriteCSV write header: %v", err)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		row := rv.Index(i)
+		if row.Kind() == reflect.Ptr {
+			if row.IsNil() {
+				continue
+			}
+			row = row.Elem()
+		}
+		record := make([]string, 0, rowType.NumField())
+		for j := 0; j < rowType.NumField(); j++ {
+			value, err := csvFieldValue(row.Field(j), dateFormat)
+			if err != nil {
+				return fmt.Errorf("WriteCSV field %s: %v", rowType.Field(j).Name, err)
+			}
+			record = append(record, value)
+		}
+		if err := w.Write(record); err != nil {
+			return fmt.Errorf("WriteCSV write row: %v", err)
+		}
+	}
+	if rowType == reflect.TypeOf(FinancialReport{}) {
+		for _, record := range financialReportTotals(rv) {
+			if err := w.Write(record); err != nil {
+				return fmt.Errorf("WriteCSV write totals: %v", err)
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+//csvHeaderName Get Apple's header name of struct field
+func csvHeaderName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("csv"), ",")[0]
+	if name == "" {
+		name = field.Name
+	}
+	if alias, ok := csvHeaderAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+//csvFieldValue Format struct field value
+func csvFieldValue(value reflect.Value, dateFormat string) (string, error) {
+	switch v := value.Addr().Interface().(type) {
+	case *CustomDate:
+		return v.MarshalCSVWithFormat(dateFormat)
+	case gocsv.TypeMarshaller:
+		return v.MarshalCSV()
+	}
+	return fmt.Sprintf("%v", value.Interface()), nil
+}
+
+//financialReportTotals Build financial report total lines
+func financialReportTotals(rows reflect.Value) [][]string {
+	amount := Money{Amount: decimal.Zero}
+	units := 0
+	count := 0
+	for i := 0; i < rows.Len(); i++ {
+		row := reflect.Indirect(rows.Index(i))
+		if !row.IsValid() {
+			continue
+		}
+		report := row.Addr().Interface().(*FinancialReport)
+		amount.Amount = amount.Amount.Add(report.ExtendedPartnerShare.Amount)
+		amount.Currency = report.PartnerShareCurrency
+		units += report.Quantity.Integer
+		count++
+	}
+	return [][]string{
+		{"Total_Rows", strconv.Itoa(count)},
+		{"Total_Amount", amount.Round().format()},
+		{"Total_Units", strconv.Itoa(units)},
+	}
+}
+
+//NewCSVWriter Create new CSV writer for marshaler
+func NewCSVWriter(out io.Writer) *csv.Writer {
+	w := csv.NewWriter(out)
+	w.Comma = '\t'
+	return w
 }
 
 //NewCSVReader Create new CSV reader for unmarshaler
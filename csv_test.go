@@ -5,6 +5,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -25,8 +28,8 @@ func (suite *CSVTestSuite) TestUnmarshalCSVSalesReport() {
 	assert.Equal(suite.T(), 1234567890, reports[0].AppleIdentifier.Value())
 	assert.Equal(suite.T(), "2020-10-05", reports[0].BeginDate.Value().Format(CustomDateFormatDefault))
 	assert.Equal(suite.T(), "2020-10-05", reports[0].EndDate.Value().Format(CustomDateFormatDefault))
-	assert.Equal(suite.T(), float64(299), reports[0].CustomerPrice.Value())
-	assert.Equal(suite.T(), 209.3000030517578, reports[0].DeveloperProceeds.Value())
+	assert.Equal(suite.T(), "299.00 RUB", reports[0].CustomerPrice.String())
+	assert.Equal(suite.T(), "209.30 RUB", reports[0].DeveloperProceeds.String())
 	assert.Equal(suite.T(), float64(12), reports[0].Units.Value())
 }
 
@@ -39,8 +42,8 @@ func (suite *CSVTestSuite) TestUnmarshalSalesSubscriptionsReport() {
 	assert.Equal(suite.T(), 1234567890, reports[0].AppAppleID.Value())
 	assert.Equal(suite.T(), 1234567890, reports[0].SubscriptionAppleID.Value())
 	assert.Equal(suite.T(), 1234567890, reports[0].SubscriptionGroupID.Value())
-	assert.Equal(suite.T(), float64(2950), reports[0].CustomerPrice.Value())
-	assert.Equal(suite.T(), float64(2065), reports[0].DeveloperProceeds.Value())
+	assert.Equal(suite.T(), "2950.00 RUB", reports[0].CustomerPrice.String())
+	assert.Equal(suite.T(), "2065.00 RUB", reports[0].DeveloperProceeds.String())
 	assert.Equal(suite.T(), 0, reports[0].BillingRetry.Value())
 	assert.Equal(suite.T(), 20, reports[0].ActiveStandardPriceSubscriptions.Value())
 	assert.Equal(suite.T(), 0, reports[0].ActiveFreeTrialIntroductoryOfferSubscriptions.Value())
@@ -76,12 +79,83 @@ func (suite *CSVTestSuite) TestUnmarshalSalesSubscribersReport() {
 	assert.Equal(suite.T(), 1234567890, reports[0].SubscriptionAppleID.Value())
 	assert.Equal(suite.T(), 1234567890, reports[0].SubscriptionGroupID.Value())
 	assert.Equal(suite.T(), "2020-10-05", reports[0].EventDate.Value().Format(CustomDateFormatDefault))
-	assert.Equal(suite.T(), 4.489999771118164, reports[0].CustomerPrice.Value())
-	assert.Equal(suite.T(), 3.1500000953674316, reports[0].DeveloperProceeds.Value())
+	assert.Equal(suite.T(), "4.49 USD", reports[0].CustomerPrice.String())
+	assert.Equal(suite.T(), "3.15 USD", reports[0].DeveloperProceeds.String())
 	assert.Equal(suite.T(), 1, reports[0].Units.Value())
 	assert.True(suite.T(), reports[0].PurchaseDate.Value().IsZero())
 }
 
+func (suite *CSVTestSuite) TestMarshalCSVRoundTrip() {
+	stubs := map[string]interface{}{
+		"stubs/reports/sales/sales.tsv":                &[]*SalesReport{},
+		"stubs/reports/sales/subscriptions.tsv":        &[]*SubscriptionsReport{},
+		"stubs/reports/sales/subscriptions-events.tsv": &[]*SubscriptionsEventsReport{},
+		"stubs/reports/sales/subscribers.tsv":          &[]*SubscribersReport{},
+		"stubs/reports/sales/preorders.tsv":            &[]*PreOrdersReport{},
+	}
+	for path, reports := range stubs {
+		reportData, _ := ioutil.ReadFile(path)
+		assert.NoError(suite.T(), UnmarshalCSV(reportData, reports), path)
+		data, err := MarshalCSV(reports)
+		assert.NoError(suite.T(), err, path)
+		restored := reflect.New(reflect.TypeOf(reports).Elem()).Interface()
+		assert.NoError(suite.T(), UnmarshalCSV(data, restored), path)
+		assert.Equal(suite.T(), reports, restored, path)
+	}
+}
+
+func (suite *CSVTestSuite) TestMarshalCSVSalesReportFormat() {
+	reportData, _ := ioutil.ReadFile("stubs/reports/sales/sales.tsv")
+	reports := []*SalesReport{}
+	_ = UnmarshalCSV(reportData, &reports)
+	data, err := MarshalCSV(reports)
+	assert.NoError(suite.T(), err)
+	lines := strings.Split(string(data), "\n")
+	assert.Contains(suite.T(), lines[0], "\tTitle\tVersion\tProduct Type Identifier\t")
+	assert.Contains(suite.T(), lines[1], "\t12\t209.30\t10/05/2020\t10/05/2020\tRUB\t")
+}
+
+func (suite *CSVTestSuite) TestMarshalCSVSubscribersReportFormat() {
+	reportData, _ := ioutil.ReadFile("stubs/reports/sales/subscribers.tsv")
+	reports := []*SubscribersReport{}
+	_ = UnmarshalCSV(reportData, &reports)
+	data, err := MarshalCSV(reports)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), strings.HasPrefix(strings.Split(string(data), "\n")[1], "2020-10-05\t"))
+}
+
+func (suite *CSVTestSuite) TestMarshalCSVFinancialReport() {
+	reportData, _ := ioutil.ReadFile("stubs/reports/finances/financial.tsv")
+	reports := []*FinancialReport{}
+	_ = UnmarshalCSVWithFilterLines(reportData, &reports)
+	data, err := MarshalCSV(reports)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), string(data), "\tISRC/ISBN\t")
+	assert.Contains(suite.T(), string(data), "Total_Rows\t2\nTotal_Amount\t34.65\nTotal_Units\t6\n")
+	restored := []*FinancialReport{}
+	assert.NoError(suite.T(), UnmarshalCSVWithFilterLines(data, &restored))
+	assert.Equal(suite.T(), reports, restored)
+}
+
+func (suite *CSVTestSuite) TestMarshalCSVGzip() {
+	reportData, _ := ioutil.ReadFile("stubs/reports/sales/sales.tsv")
+	reports := []*SalesReport{}
+	_ = UnmarshalCSV(reportData, &reports)
+	expected, _ := MarshalCSV(reports)
+	data, err := MarshalCSVGzip(reports)
+	assert.NoError(suite.T(), err)
+	handler := &ResponseHandlerGzip{}
+	raw, _ := handler.ReadBody(&http.Response{Body: ioutil.NopCloser(bytes.NewReader(data))})
+	assert.Equal(suite.T(), expected, raw)
+}
+
+func (suite *CSVTestSuite) TestMarshalCSVInvalid() {
+	_, err := MarshalCSV(&SalesReport{})
+	assert.Error(suite.T(), err)
+	_, err = MarshalCSV([]string{"foo"})
+	assert.Error(suite.T(), err)
+}
+
 func TestCSVTestSuite(t *testing.T) {
 	suite.Run(t, new(CSVTestSuite))
 }
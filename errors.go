@@ -17,3 +17,20 @@ type ErrorSource struct {
 	Parameter string `json:"parameter"` //The query parameter that produced the error.
 	Pointer   string `json:"pointer"`   //A JSON pointer that indicates the location in the request entity where the error originates
 }
+
+//FilterError Validation error of report filter with query parameter of invalid field
+type FilterError struct {
+	Method    string //Validating method, e.g. SalesReportsFilter.IsValid
+	Parameter string //Query parameter of invalid field, e.g. filter[frequency]
+	Message   string //Validation message, e.g. Frequency is not valid
+}
+
+//Error method
+func (e *FilterError) Error() string {
+	return e.Method + ": " + e.Message
+}
+
+//newFilterError Create filter validation error
+func newFilterError(method string, parameter string, message string) *FilterError {
+	return &FilterError{Method: method, Parameter: parameter, Message: message}
+}
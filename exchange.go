@@ -0,0 +1,363 @@
+package appstore
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+//ExchangeRateProvider source of currency exchange rates
+type ExchangeRateProvider interface {
+	//Rate Get amount of to currency for 1 unit of from currency at date, zero date means latest known rate
+	Rate(from string, to string, date time.Time) (decimal.Decimal, error)
+}
+
+//exchangeRowDateFields Report row date fields used as conversion date, in priority order
+var exchangeRowDateFields = []string{"BeginDate", "EventDate", "StartDate", "Date"}
+
+//StaticExchangeRateProvider fixed exchange rates relative to base currency
+type StaticExchangeRateProvider struct {
+	base  string
+	rates map[string]decimal.Decimal
+}
+
+//Rate Get exchange rate, date is ignored
+func (p *StaticExchangeRateProvider) Rate(from string, to string, date time.Time) (decimal.Decimal, error) {
+	return crossRate(p.base, p.rates, from, to)
+}
+
+//NewStaticExchangeRateProvider Create provider from rates as amount of currency for 1 unit of base currency
+func NewStaticExchangeRateProvider(base string, rates map[string]decimal.Decimal) *StaticExchangeRateProvider {
+	normalized := make(map[string]decimal.Decimal, len(rates))
+	for currency, rate := range rates {
+		normalized[strings.ToUpper(currency)] = rate
+	}
+	return &StaticExchangeRateProvider{base: strings.ToUpper(base), rates: normalized}
+}
+
+//dailyExchangeRate exchange rate of date
+type dailyExchangeRate struct {
+	date time.Time
+	rate decimal.Decimal
+}
+
+//DailyExchangeRateProvider daily exchange rates relative to base currency
+type DailyExchangeRateProvider struct {
+	base  string
+	rates map[string][]*dailyExchangeRate //sorted by date
+}
+
+//Rate Get exchange rate of date, latest rate before date is used for days without rate (weekends, holidays)
+func (p *DailyExchangeRateProvider) Rate(from string, to string, date time.Time) (decimal.Decimal, error) {
+	rates := make(map[string]decimal.Decimal, 2)
+	for _, currency := range []string{strings.ToUpper(from), strings.ToUpper(to)} {
+		if rate, ok := p.rateOf(currency, date); ok {
+			rates[currency] = rate
+		}
+	}
+	rate, err := crossRate(p.base, rates, from, to)
+	if err != nil {
+		return rate, fmt.Errorf("%v at %s", err, date.Format(CustomDateFormatDefault))
+	}
+	return rate, nil
+}
+
+//rateOf Get rate of currency at date
+func (p *DailyExchangeRateProvider) rateOf(currency string, date time.Time) (decimal.Decimal, bool) {
+	rates := p.rates[currency]
+	if len(rates) == 0 {
+		return decimal.Zero, false
+	}
+	if date.IsZero() {
+		return rates[len(rates)-1].rate, true
+	}
+	i := sort.Search(len(rates), func(i int) bool { return rates[i].date.After(date) })
+	if i == 0 {
+		return decimal.Zero, false
+	}
+	return rates[i-1].rate, true
+}
+
+//NewDailyExchangeRateProvider Create provider from CSV with Date (YYYY-MM-DD), Currency and Rate columns,
+//rate is amount of currency for 1 unit of base currency
+func NewDailyExchangeRateProvider(in io.Reader, base string) (*DailyExchangeRateProvider, error) {
+	r := csv.NewReader(in)
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("NewDailyExchangeRateProvider read: %v", err)
+	}
+	p := &DailyExchangeRateProvider{base: strings.ToUpper(base), rates: make(map[string][]*dailyExchangeRate)}
+	if len(records) == 0 {
+		return p, nil
+	}
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"date", "currency", "rate"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("NewDailyExchangeRateProvider: column %s not found", name)
+		}
+	}
+	for line, record := range records[1:] {
+		date, err := time.Parse(CustomDateFormatDefault, record[columns["date"]])
+		if err != nil {
+			return nil, fmt.Errorf("NewDailyExchangeRateProvider line %d: %v", line+2, err)
+		}
+		rate, err := decimal.NewFromString(record[columns["rate"]])
+		if err != nil {
+			return nil, fmt.Errorf("NewDailyExchangeRateProvider line %d: %v", line+2, err)
+		}
+		currency := strings.ToUpper(record[columns["currency"]])
+		p.rates[currency] = append(p.rates[currency], &dailyExchangeRate{date: date, rate: rate})
+	}
+	for _, rates := range p.rates {
+		sort.SliceStable(rates, func(i, j int) bool { return rates[i].date.Before(rates[j].date) })
+	}
+	return p, nil
+}
+
+//LoadDailyExchangeRateProvider Create provider from CSV file
+func LoadDailyExchangeRateProvider(path string, base string) (*DailyExchangeRateProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("LoadDailyExchangeRateProvider: %v", err)
+	}
+	defer f.Close()
+	return NewDailyExchangeRateProvider(f, base)
+}
+
+//paymentRegionRegexp Region currency of payment report line, e.g. "Euro-Zone (EUR)"
+var paymentRegionRegexp = regexp.MustCompile(`\(([A-Z]{3})\)\s*$`)
+
+//ApplePaymentExchangeRateProvider exchange rates of App Store Connect "Payments and Financial Reports" export
+type ApplePaymentExchangeRateProvider struct {
+	base  string //bank account currency
+	rates map[string]decimal.Decimal
+}
+
+//Rate Get exchange rate, rates are fixed for the fiscal month of payment report and date is ignored
+func (p *ApplePaymentExchangeRateProvider) Rate(from string, to string, date time.Time) (decimal.Decimal, error) {
+	return crossRate(p.base, p.rates, from, to)
+}
+
+//BankCurrency Get bank account currency of payment report
+func (p *ApplePaymentExchangeRateProvider) BankCurrency() string {
+	return p.base
+}
+
+//NewApplePaymentExchangeRateProvider Create provider from payment report with "Country or Region (Currency)",
+//"Exchange Rate" and "Bank Account Currency" columns, comma or tab separated
+func NewApplePaymentExchangeRateProvider(in io.Reader) (*ApplePaymentExchangeRateProvider, error) {
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("NewApplePaymentExchangeRateProvider read: %v", err)
+	}
+	r := csv.NewReader(strings.NewReader(string(data)))
+	if strings.Count(string(data), "\t") > strings.Count(string(data), ",") {
+		r.Comma = '\t'
+	}
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	p := &ApplePaymentExchangeRateProvider{rates: make(map[string]decimal.Decimal)}
+	var columns map[string]int
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("NewApplePaymentExchangeRateProvider read: %v", err)
+		}
+		if columns == nil {
+			if len(record) > 0 && strings.HasPrefix(record[0], "Country or Region") {
+				columns = make(map[string]int)
+				for i, name := range record {
+					columns[strings.TrimSpace(name)] = i
+				}
+			}
+			continue
+		}
+		rateIdx, rateOk := columns["Exchange Rate"]
+		bankIdx, bankOk := columns["Bank Account Currency"]
+		if !rateOk || !bankOk || len(record) <= rateIdx || len(record) <= bankIdx {
+			continue
+		}
+		matches := paymentRegionRegexp.FindStringSubmatch(record[0])
+		if matches == nil {
+			continue
+		}
+		rate, err := decimal.NewFromString(strings.Replace(record[rateIdx], ",", "", -1))
+		if err != nil || rate.IsZero() {
+			continue
+		}
+		p.base = strings.ToUpper(strings.TrimSpace(record[bankIdx]))
+		//exchange rate is amount of bank currency for 1 unit of region currency
+		p.rates[matches[1]] = decimal.New(1, 0).DivRound(rate, 16)
+	}
+	if columns == nil {
+		return nil, fmt.Errorf("NewApplePaymentExchangeRateProvider: header not found")
+	}
+	return p, nil
+}
+
+//crossRate Get rate of from/to currencies using rates relative to base currency
+func crossRate(base string, rates map[string]decimal.Decimal, from string, to string) (decimal.Decimal, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+	if from == to {
+		return decimal.New(1, 0), nil
+	}
+	get := func(currency string) (decimal.Decimal, bool) {
+		if currency == base {
+			return decimal.New(1, 0), true
+		}
+		rate, ok := rates[currency]
+		return rate, ok && !rate.IsZero()
+	}
+	fromRate, fromOk := get(from)
+	toRate, toOk := get(to)
+	if !fromOk || !toOk {
+		return decimal.Zero, fmt.Errorf("exchange rate %s/%s not found", from, to)
+	}
+	return toRate.DivRound(fromRate, 16), nil
+}
+
+//MissingExchangeRate report row money field which was not converted
+type MissingExchangeRate struct {
+	Row      int
+	Field    string
+	Currency string
+	Date     time.Time
+	Err      error
+}
+
+//ConversionResult result of report rows conversion
+type ConversionResult struct {
+	Converted int                    //number of converted money fields
+	Missing   []*MissingExchangeRate //money fields left in original currency
+}
+
+//CurrencyConverter converter of money to target currency
+type CurrencyConverter struct {
+	provider ExchangeRateProvider
+	target   string
+}
+
+//Convert Convert money to target currency at date, result is rounded to target currency minor units
+func (c *CurrencyConverter) Convert(m Money, date time.Time) (Money, error) {
+	if strings.EqualFold(m.Currency, c.target) || m.Amount.IsZero() {
+		//zero amount needs no rate, free downloads have no currency
+		return Money{Amount: m.Amount, Currency: c.target, Null: m.Null}.pad(), nil
+	}
+	if m.Currency == "" {
+		return m, fmt.Errorf("CurrencyConverter.Convert: money currency is empty")
+	}
+	rate, err := c.provider.Rate(m.Currency, c.target, date)
+	if err != nil {
+		return m, fmt.Errorf("CurrencyConverter.Convert: %v", err)
+	}
+	return Money{Amount: m.Amount.Mul(rate), Currency: c.target}.Round(), nil
+}
+
+//ConvertReports Convert money fields of report rows slice in place, currency fields of converted money are set to target.
+//Fields without exchange rate are kept and reported in result.
+func (c *CurrencyConverter) ConvertReports(rows interface{}) (*ConversionResult, error) {
+	rv := reflect.Indirect(reflect.ValueOf(rows))
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("CurrencyConverter.ConvertReports: slice expected, got %v", rv.Kind())
+	}
+	result := &ConversionResult{}
+	for i := 0; i < rv.Len(); i++ {
+		row := rv.Index(i)
+		if row.Kind() == reflect.Ptr {
+			if row.IsNil() {
+				continue
+			}
+			row = row.Elem()
+		}
+		if row.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("CurrencyConverter.ConvertReports: slice of structs expected, got %v", row.Kind())
+		}
+		c.convertRow(i, row, result)
+	}
+	return result, nil
+}
+
+//moneyConversion converted value of money field of report row
+type moneyConversion struct {
+	field         string
+	currencyField string
+	money         *Money
+	value         Money
+	err           error
+}
+
+//convertRow Convert money fields of report row. Money fields sharing currency field are converted all or none,
+//so currency field never disagrees with amounts bound to it
+func (c *CurrencyConverter) convertRow(index int, row reflect.Value, result *ConversionResult) {
+	date := exchangeRowDate(row)
+	rowType := row.Type()
+	conversions := make([]*moneyConversion, 0)
+	failed := make(map[string]error)
+	for i := 0; i < rowType.NumField(); i++ {
+		money, ok := row.Field(i).Addr().Interface().(*Money)
+		if !ok || money.Null {
+			continue
+		}
+		conversion := &moneyConversion{field: rowType.Field(i).Name, currencyField: rowType.Field(i).Tag.Get(MoneyCurrencyTag), money: money}
+		conversion.value, conversion.err = c.Convert(*money, date)
+		if conversion.err != nil && conversion.currencyField != "" && failed[conversion.currencyField] == nil {
+			failed[conversion.currencyField] = conversion.err
+		}
+		conversions = append(conversions, conversion)
+	}
+	converted := make(map[string]bool)
+	for _, conversion := range conversions {
+		err := conversion.err
+		if err == nil && conversion.currencyField != "" {
+			err = failed[conversion.currencyField]
+		}
+		if err != nil {
+			result.Missing = append(result.Missing, &MissingExchangeRate{Row: index, Field: conversion.field, Currency: conversion.money.Currency, Date: date, Err: err})
+			continue
+		}
+		*conversion.money = conversion.value
+		converted[conversion.currencyField] = true
+		result.Converted++
+	}
+	for name := range converted {
+		currency := row.FieldByName(name)
+		if name != "" && currency.IsValid() && currency.Kind() == reflect.String {
+			currency.SetString(c.target)
+		}
+	}
+}
+
+//exchangeRowDate Get conversion date of report row
+func exchangeRowDate(row reflect.Value) time.Time {
+	for _, name := range exchangeRowDateFields {
+		field := row.FieldByName(name)
+		if !field.IsValid() {
+			continue
+		}
+		if date, ok := field.Addr().Interface().(*CustomDate); ok && !date.Null && !date.Date.IsZero() {
+			return date.Date
+		}
+	}
+	return time.Time{}
+}
+
+//NewCurrencyConverter Create new converter to target currency
+func NewCurrencyConverter(provider ExchangeRateProvider, target string) *CurrencyConverter {
+	return &CurrencyConverter{provider: provider, target: strings.ToUpper(target)}
+}
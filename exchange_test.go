@@ -0,0 +1,173 @@
+package appstore
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ExchangeTestSuite struct {
+	suite.Suite
+	static *StaticExchangeRateProvider
+}
+
+func (suite *ExchangeTestSuite) SetupTest() {
+	suite.static = NewStaticExchangeRateProvider("USD", map[string]decimal.Decimal{
+		"rub": decimal.RequireFromString("80"),
+		"EUR": decimal.RequireFromString("0.8"),
+	})
+}
+
+func (suite *ExchangeTestSuite) TestStaticRate() {
+	rate, err := suite.static.Rate("RUB", "USD", time.Time{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "0.0125", rate.String())
+	rate, err = suite.static.Rate("EUR", "RUB", time.Time{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "100", rate.String())
+	rate, err = suite.static.Rate("JPY", "JPY", time.Time{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1", rate.String())
+	_, err = suite.static.Rate("JPY", "USD", time.Time{})
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "exchange rate JPY/USD not found", err.Error())
+}
+
+func (suite *ExchangeTestSuite) TestDailyRate() {
+	provider, err := LoadDailyExchangeRateProvider("stubs/exchange/daily.csv", "USD")
+	assert.NoError(suite.T(), err)
+	rate, _ := provider.Rate("USD", "RUB", time.Date(2020, 10, 5, 0, 0, 0, 0, time.UTC))
+	assert.Equal(suite.T(), "80", rate.String())
+	rate, _ = provider.Rate("USD", "RUB", time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC))
+	assert.Equal(suite.T(), "78.5", rate.String())
+	rate, _ = provider.Rate("USD", "RUB", time.Time{})
+	assert.Equal(suite.T(), "79", rate.String())
+	_, err = provider.Rate("EUR", "USD", time.Date(2020, 10, 2, 0, 0, 0, 0, time.UTC))
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "exchange rate EUR/USD not found at 2020-10-02", err.Error())
+}
+
+func (suite *ExchangeTestSuite) TestDailyRateInvalid() {
+	_, err := NewDailyExchangeRateProvider(strings.NewReader("Day,Currency,Rate\n"), "USD")
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "NewDailyExchangeRateProvider: column date not found", err.Error())
+	_, err = NewDailyExchangeRateProvider(strings.NewReader("Date,Currency,Rate\n2020-10-05,RUB,foo\n"), "USD")
+	assert.Error(suite.T(), err)
+	_, err = LoadDailyExchangeRateProvider("stubs/exchange/foo.csv", "USD")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ExchangeTestSuite) TestApplePaymentRate() {
+	f, _ := os.Open("stubs/exchange/payments.csv")
+	defer f.Close()
+	provider, err := NewApplePaymentExchangeRateProvider(f)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "USD", provider.BankCurrency())
+	rate, err := provider.Rate("EUR", "USD", time.Time{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1.25", rate.String())
+	rate, err = provider.Rate("RUB", "USD", time.Time{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "0.0125", rate.String())
+	_, err = provider.Rate("JPY", "USD", time.Time{})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ExchangeTestSuite) TestApplePaymentRateTabSeparated() {
+	data, _ := ioutil.ReadFile("stubs/exchange/payments.csv")
+	tsv := strings.NewReplacer(`"1,234.56"`, "1234.56", `"2,511.60"`, "2511.60", `"1,390.96"`, "1390.96", ",", "\t").Replace(string(data))
+	provider, err := NewApplePaymentExchangeRateProvider(strings.NewReader(tsv))
+	assert.NoError(suite.T(), err)
+	rate, _ := provider.Rate("EUR", "USD", time.Time{})
+	assert.Equal(suite.T(), "1.25", rate.String())
+	_, err = NewApplePaymentExchangeRateProvider(strings.NewReader("foo,bar\n"))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ExchangeTestSuite) TestConvert() {
+	converter := NewCurrencyConverter(suite.static, "usd")
+	price, _ := NewMoneyFromString("299", "RUB")
+	result, err := converter.Convert(price, time.Time{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "3.74 USD", result.String())
+	result, err = converter.Convert(Money{Amount: decimal.New(1, 0)}, time.Time{})
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "CurrencyConverter.Convert: money currency is empty", err.Error())
+	result, err = converter.Convert(Money{Amount: decimal.Zero}, time.Time{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "0.00 USD", result.String())
+}
+
+func (suite *ExchangeTestSuite) TestConvertReports() {
+	reportData, _ := ioutil.ReadFile("stubs/reports/sales/sales.tsv")
+	reports := []*SalesReport{}
+	_ = UnmarshalCSV(reportData, &reports)
+	reports = append(reports[:1], &SalesReport{
+		DeveloperProceeds:  Money{Amount: decimal.New(10, 0), Currency: "JPY"},
+		CurrencyOfProceeds: "JPY",
+		CustomerPrice:      Money{Null: true},
+	})
+	result, err := NewCurrencyConverter(suite.static, "USD").ConvertReports(&reports)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.Converted)
+	assert.Equal(suite.T(), "2.62 USD", reports[0].DeveloperProceeds.String())
+	assert.Equal(suite.T(), "3.74 USD", reports[0].CustomerPrice.String())
+	assert.Equal(suite.T(), "USD", reports[0].CurrencyOfProceeds)
+	assert.Equal(suite.T(), "USD", reports[0].CustomerCurrency)
+	assert.Len(suite.T(), result.Missing, 1)
+	assert.Equal(suite.T(), 1, result.Missing[0].Row)
+	assert.Equal(suite.T(), "DeveloperProceeds", result.Missing[0].Field)
+	assert.Equal(suite.T(), "JPY", result.Missing[0].Currency)
+	assert.Equal(suite.T(), "JPY", reports[1].CurrencyOfProceeds)
+	assert.True(suite.T(), reports[1].CustomerPrice.Null)
+}
+
+func (suite *ExchangeTestSuite) TestConvertReportsSharedCurrencyAllOrNone() {
+	reports := []*FinancialReport{{
+		PartnerShare:         Money{Amount: decimal.Zero, Currency: "JPY"},
+		ExtendedPartnerShare: Money{Amount: decimal.New(10, 0), Currency: "JPY"},
+		PartnerShareCurrency: "JPY",
+		CustomerPrice:        Money{Amount: decimal.New(8, 0), Currency: "EUR"},
+		CustomerCurrency:     "EUR",
+	}}
+	result, err := NewCurrencyConverter(suite.static, "USD").ConvertReports(reports)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Converted)
+	assert.Equal(suite.T(), "10.00 USD", reports[0].CustomerPrice.String())
+	assert.Equal(suite.T(), "USD", reports[0].CustomerCurrency)
+	//zero partner share converts without rate, but its sibling does not, so both stay in JPY
+	assert.Equal(suite.T(), "JPY", reports[0].PartnerShareCurrency)
+	assert.Equal(suite.T(), "JPY", reports[0].PartnerShare.Currency)
+	assert.Equal(suite.T(), "JPY", reports[0].ExtendedPartnerShare.Currency)
+	assert.Len(suite.T(), result.Missing, 2)
+	assert.Equal(suite.T(), "PartnerShare", result.Missing[0].Field)
+	assert.Equal(suite.T(), "ExtendedPartnerShare", result.Missing[1].Field)
+	assert.Equal(suite.T(), result.Missing[1].Err, result.Missing[0].Err)
+}
+
+func (suite *ExchangeTestSuite) TestConvertReportsByDate() {
+	provider, _ := LoadDailyExchangeRateProvider("stubs/exchange/daily.csv", "USD")
+	reportData, _ := ioutil.ReadFile("stubs/reports/sales/subscribers.tsv")
+	reports := []*SubscribersReport{}
+	_ = UnmarshalCSV(reportData, &reports)
+	result, err := NewCurrencyConverter(provider, "RUB").ConvertReports(reports[:1])
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Missing)
+	assert.Equal(suite.T(), "359.20 RUB", reports[0].CustomerPrice.String())
+}
+
+func (suite *ExchangeTestSuite) TestConvertReportsInvalid() {
+	_, err := NewCurrencyConverter(suite.static, "USD").ConvertReports(&SalesReport{})
+	assert.Error(suite.T(), err)
+	_, err = NewCurrencyConverter(suite.static, "USD").ConvertReports([]string{"foo"})
+	assert.Error(suite.T(), err)
+}
+
+func TestExchangeTestSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeTestSuite))
+}
@@ -34,12 +34,17 @@ func (f *FinancesReportsFilter) SetReportDate(value time.Time) *FinancesReportsF
 	return f
 }
 
-//SetRegionCode Set report date
+//SetRegionCode Set region code, code is upper-cased as Apple expects it
 func (f *FinancesReportsFilter) SetRegionCode(value string) *FinancesReportsFilter {
-	f.RegionCode = value
+	f.RegionCode = normalizeCode(value)
 	return f
 }
 
+//SetRegion Set region code of financial report region
+func (f *FinancesReportsFilter) SetRegion(region *FinanceRegion) *FinancesReportsFilter {
+	return f.SetRegionCode(region.Code)
+}
+
 //TypeFinancial Change report type to Financial
 func (f *FinancesReportsFilter) TypeFinancial() *FinancesReportsFilter {
 	return f.SetReportType(FinancesReportTypeFinancial)
@@ -54,7 +59,7 @@ func (f *FinancesReportsFilter) TypeFinanceDetail() *FinancesReportsFilter {
 func (f *FinancesReportsFilter) toQueryParamsMap() map[string]interface{} {
 	qs := make(map[string]interface{})
 	qs["filter[reportType]"] = string(f.ReportType)
-	qs["filter[regionCode]"] = f.RegionCode
+	qs["filter[regionCode]"] = normalizeCode(f.RegionCode)
 	if !f.ReportDate.IsZero() {
 		qs["filter[reportDate]"] = f.ReportDate.Format("2006-01")
 	}
@@ -64,13 +69,23 @@ func (f *FinancesReportsFilter) toQueryParamsMap() map[string]interface{} {
 //IsValid Validate sales report filter params
 func (f *FinancesReportsFilter) IsValid() error {
 	if f.ReportType == "" {
-		return fmt.Errorf("FinancesReportsFilter.IsValid: %v", "ReportType is required")
+		return newFilterError("FinancesReportsFilter.IsValid", "filter[reportType]", "ReportType is required")
 	}
-	if f.RegionCode == "" {
-		return fmt.Errorf("FinancesReportsFilter.IsValid: %v", "RegionCode is required")
+	code := normalizeCode(f.RegionCode)
+	if code == "" {
+		return newFilterError("FinancesReportsFilter.IsValid", "filter[regionCode]", "RegionCode is required")
 	}
 	if f.ReportDate.IsZero() {
-		return fmt.Errorf("FinancesReportsFilter.IsValid: %v", "ReportDate is required")
+		return newFilterError("FinancesReportsFilter.IsValid", "filter[reportDate]", "ReportDate is required")
+	}
+	if _, ok := LookupFinanceRegion(code); !ok {
+		return newFilterError("FinancesReportsFilter.IsValid", "filter[regionCode]", fmt.Sprintf("RegionCode %s is unknown", code))
+	}
+	if f.ReportType == FinancesReportTypeFinanceDetail && code != FinanceRegionCodeFinanceDetail {
+		return newFilterError("FinancesReportsFilter.IsValid", "filter[regionCode]", fmt.Sprintf("RegionCode must be %s for %s report type", FinanceRegionCodeFinanceDetail, f.ReportType))
+	}
+	if f.ReportType == FinancesReportTypeFinancial && code == FinanceRegionCodeFinanceDetail {
+		return newFilterError("FinancesReportsFilter.IsValid", "filter[regionCode]", fmt.Sprintf("RegionCode %s is only for %s report type", FinanceRegionCodeFinanceDetail, FinancesReportTypeFinanceDetail))
 	}
 	return nil
 }
@@ -78,3 +93,40 @@ func (f *FinancesReportsFilter) IsValid() error {
 func NewFinancesReportsFilter() *FinancesReportsFilter {
 	return &FinancesReportsFilter{ReportType: FinancesReportTypeFinancial}
 }
+
+//NewFinancesReportsFiltersByRegions Create financial reports filters of date for every region code, for every separate region if none given
+func NewFinancesReportsFiltersByRegions(date time.Time, regionCodes ...string) ([]*FinancesReportsFilter, error) {
+	if len(regionCodes) == 0 {
+		for _, region := range financeRegionTable {
+			if !region.Consolidated {
+				regionCodes = append(regionCodes, region.Code)
+			}
+		}
+	}
+	filters := make([]*FinancesReportsFilter, 0, len(regionCodes))
+	for _, code := range regionCodes {
+		region, ok := LookupFinanceRegion(code)
+		if !ok {
+			return nil, fmt.Errorf("NewFinancesReportsFiltersByRegions: unknown region code %s", code)
+		}
+		filters = append(filters, NewFinancesReportsFilter().SetReportDate(date).SetRegion(region))
+	}
+	return filters, nil
+}
+
+//NewFinancesReportsFiltersByCountries Create financial reports filters of date for regions of countries, one filter per region
+func NewFinancesReportsFiltersByCountries(date time.Time, countryCodes ...string) ([]*FinancesReportsFilter, error) {
+	seen := make(map[string]bool)
+	regionCodes := make([]string, 0, len(countryCodes))
+	for _, code := range countryCodes {
+		country, ok := LookupCountry(code)
+		if !ok {
+			return nil, fmt.Errorf("NewFinancesReportsFiltersByCountries: unknown country code %s", code)
+		}
+		if !seen[country.RegionCode] {
+			seen[country.RegionCode] = true
+			regionCodes = append(regionCodes, country.RegionCode)
+		}
+	}
+	return NewFinancesReportsFiltersByRegions(date, regionCodes...)
+}
@@ -1,6 +1,7 @@
 package appstore
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"testing"
@@ -54,6 +55,72 @@ func (suite *FinancesReportsFilterTestSuite) TestToQueryParamsMap() {
 	assert.Equal(suite.T(), qs, filter.toQueryParamsMap())
 }
 
+func (suite *FinancesReportsFilterTestSuite) TestRegionCodeUpperCased() {
+	date, _ := time.Parse("2006-01-02", "2020-05-04")
+	filter := NewFinancesReportsFilter().SetReportDate(date).SetRegionCode(" us ")
+	assert.Equal(suite.T(), "US", filter.RegionCode)
+	assert.NoError(suite.T(), filter.IsValid())
+	filter = &FinancesReportsFilter{ReportDate: date, RegionCode: "z1", ReportType: FinancesReportTypeFinanceDetail}
+	assert.NoError(suite.T(), filter.IsValid())
+	assert.Equal(suite.T(), "Z1", filter.toQueryParamsMap()["filter[regionCode]"])
+}
+
+func (suite *FinancesReportsFilterTestSuite) TestIsInvalidUnknownRegionCode() {
+	date, _ := time.Parse("2006-01-02", "2020-04-17")
+	filter := NewFinancesReportsFilter().SetReportDate(date).SetRegionCode("XX")
+	err := filter.IsValid()
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "FinancesReportsFilter.IsValid: RegionCode XX is unknown", err.Error())
+	var filterErr *FilterError
+	assert.True(suite.T(), errors.As(err, &filterErr))
+	assert.Equal(suite.T(), "filter[regionCode]", filterErr.Parameter)
+}
+
+func (suite *FinancesReportsFilterTestSuite) TestIsValidFinanceDetailRegionCode() {
+	date, _ := time.Parse("2006-01-02", "2020-04-17")
+	filter := NewFinancesReportsFilter().SetReportDate(date).SetRegionCode("US").TypeFinanceDetail()
+	err := filter.IsValid()
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "FinancesReportsFilter.IsValid: RegionCode must be Z1 for FINANCE_DETAIL report type", err.Error())
+	filter.SetRegionCode(FinanceRegionCodeFinanceDetail)
+	assert.NoError(suite.T(), filter.IsValid())
+	filter.TypeFinancial()
+	err = filter.IsValid()
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "FinancesReportsFilter.IsValid: RegionCode Z1 is only for FINANCE_DETAIL report type", err.Error())
+	filter.SetRegionCode(FinanceRegionCodeAll)
+	assert.NoError(suite.T(), filter.IsValid())
+}
+
+func (suite *FinancesReportsFilterTestSuite) TestNewFinancesReportsFiltersByRegions() {
+	date, _ := time.Parse("2006-01-02", "2020-04-17")
+	filters, err := NewFinancesReportsFiltersByRegions(date, "US", "eu")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), filters, 2)
+	assert.Equal(suite.T(), "US", filters[0].RegionCode)
+	assert.Equal(suite.T(), "EU", filters[1].RegionCode)
+	assert.Equal(suite.T(), date, filters[1].ReportDate)
+	assert.NoError(suite.T(), filters[1].IsValid())
+	filters, err = NewFinancesReportsFiltersByRegions(date)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), filters, len(financeRegionTable)-2)
+	_, err = NewFinancesReportsFiltersByRegions(date, "XX")
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "NewFinancesReportsFiltersByRegions: unknown region code XX", err.Error())
+}
+
+func (suite *FinancesReportsFilterTestSuite) TestNewFinancesReportsFiltersByCountries() {
+	date, _ := time.Parse("2006-01-02", "2020-04-17")
+	filters, err := NewFinancesReportsFiltersByCountries(date, "DE", "FR", "UA", "US", "BY")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), filters, 3)
+	assert.Equal(suite.T(), "EU", filters[0].RegionCode)
+	assert.Equal(suite.T(), "WW", filters[1].RegionCode)
+	assert.Equal(suite.T(), "US", filters[2].RegionCode)
+	_, err = NewFinancesReportsFiltersByCountries(date, "XX")
+	assert.Error(suite.T(), err)
+}
+
 func TestFinancesReportsFilterTestSuite(t *testing.T) {
 	suite.Run(t, new(FinancesReportsFilterTestSuite))
 }
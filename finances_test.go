@@ -75,8 +75,8 @@ func (suite *FinancesReportsResourceTestSuite) TestGetFinancialReportsSuccess()
 	assert.Equal(suite.T(), "", result.Data[0].ISRCIsbn)
 	assert.Equal(suite.T(), "foo.bar.baz", result.Data[0].VendorIdentifier)
 	assert.Equal(suite.T(), 1, result.Data[0].Quantity.Value())
-	assert.Equal(suite.T(), 3.1500000953674316, result.Data[0].PartnerShare.Value())
-	assert.Equal(suite.T(), 3.1500000953674316, result.Data[0].ExtendedPartnerShare.Value())
+	assert.Equal(suite.T(), "3.15 USD", result.Data[0].PartnerShare.String())
+	assert.Equal(suite.T(), "3.15 USD", result.Data[0].ExtendedPartnerShare.String())
 	assert.Equal(suite.T(), "USD", result.Data[0].PartnerShareCurrency)
 	assert.Equal(suite.T(), "S", result.Data[0].SaleOrReturn)
 	assert.Equal(suite.T(), 1234567890, result.Data[0].AppleIdentifier.Value())
@@ -84,12 +84,12 @@ func (suite *FinancesReportsResourceTestSuite) TestGetFinancialReportsSuccess()
 	assert.Equal(suite.T(), "foo.bar.baz", result.Data[0].Title)
 	assert.Equal(suite.T(), "", result.Data[0].LabelStudioNetworkDeveloperPublisher)
 	assert.Equal(suite.T(), "", result.Data[0].Grid)
-	assert.Equal(suite.T(), "IAY", result.Data[0].ProductTypeIdentifier)
+	assert.Equal(suite.T(), ProductTypeIdentifierInAppAutoRenewableSubscription, result.Data[0].ProductTypeIdentifier)
 	assert.Equal(suite.T(), "", result.Data[0].ISANOtherIdentifier)
 	assert.Equal(suite.T(), "US", result.Data[0].CountryOfSale)
 	assert.Equal(suite.T(), "", result.Data[0].PreOrderFlag)
 	assert.Equal(suite.T(), "", result.Data[0].PromoCode)
-	assert.Equal(suite.T(), 4.489999771118164, result.Data[0].CustomerPrice.Value())
+	assert.Equal(suite.T(), "4.49 USD", result.Data[0].CustomerPrice.String())
 	assert.Equal(suite.T(), "USD", result.Data[0].CustomerCurrency)
 
 	defer resp.Body.Close()
@@ -0,0 +1,53 @@
+package generator
+
+import (
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+)
+
+//Country country with proceeds currency
+type Country struct {
+	Code     string
+	Currency string
+	Rate     float64 //currency units per 1 USD
+	Decimals int     //currency minor units
+}
+
+//Product app, in-app purchase or subscription
+type Product struct {
+	SKU                   string
+	Title                 string
+	AppleIdentifier       int
+	ParentIdentifier      string
+	ProductTypeIdentifier appstore.ProductTypeIdentifier
+	Category              string
+	PriceUSD              float64
+	SubscriptionGroupID   int
+	SubscriptionDuration  string
+}
+
+//IsSubscription Check product is auto-renewable subscription
+func (p *Product) IsSubscription() bool {
+	return p.ProductTypeIdentifier.InAppPurchaseType() == appstore.InAppPurchaseTypeAutoRenewableSubscription
+}
+
+//DefaultCountries countries used by default
+var DefaultCountries = []*Country{
+	{Code: "US", Currency: "USD", Rate: 1, Decimals: 2},
+	{Code: "GB", Currency: "GBP", Rate: 0.79, Decimals: 2},
+	{Code: "DE", Currency: "EUR", Rate: 0.92, Decimals: 2},
+	{Code: "FR", Currency: "EUR", Rate: 0.92, Decimals: 2},
+	{Code: "JP", Currency: "JPY", Rate: 150, Decimals: 0},
+	{Code: "CA", Currency: "CAD", Rate: 1.36, Decimals: 2},
+	{Code: "AU", Currency: "AUD", Rate: 1.52, Decimals: 2},
+	{Code: "BR", Currency: "BRL", Rate: 4.95, Decimals: 2},
+}
+
+//DefaultProducts products used by default
+var DefaultProducts = []*Product{
+	{SKU: "com.example.app", Title: "Example App", AppleIdentifier: 1234567890, ProductTypeIdentifier: "1F", Category: "Productivity", PriceUSD: 0},
+	{SKU: "com.example.app.pro", Title: "Example Pro", AppleIdentifier: 1234567891, ProductTypeIdentifier: "1F", Category: "Productivity", PriceUSD: 4.99},
+	{SKU: "com.example.app.coins", Title: "100 Coins", AppleIdentifier: 1234567892, ParentIdentifier: "com.example.app", ProductTypeIdentifier: "IA1", Category: "Productivity", PriceUSD: 0.99},
+	{SKU: "com.example.app.premium", Title: "Premium Unlock", AppleIdentifier: 1234567893, ParentIdentifier: "com.example.app", ProductTypeIdentifier: "IA9", Category: "Productivity", PriceUSD: 9.99},
+	{SKU: "com.example.app.monthly", Title: "Monthly", AppleIdentifier: 1234567894, ParentIdentifier: "com.example.app", ProductTypeIdentifier: "IAY", Category: "Productivity", PriceUSD: 2.99, SubscriptionGroupID: 20000001, SubscriptionDuration: "1 Month"},
+	{SKU: "com.example.app.yearly", Title: "Yearly", AppleIdentifier: 1234567895, ParentIdentifier: "com.example.app", ProductTypeIdentifier: "IAY", Category: "Productivity", PriceUSD: 29.99, SubscriptionGroupID: 20000001, SubscriptionDuration: "1 Year"},
+}
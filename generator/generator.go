@@ -0,0 +1,365 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/shopspring/decimal"
+	"math/rand"
+	"time"
+)
+
+//AppleCommissionRate const
+const AppleCommissionRate = 0.3
+
+//DeveloperName const
+const DeveloperName = "Example Developer"
+
+//Generator seeded synthetic report data generator.
+//Report methods generate no rows when catalog has no matching product, e.g. subscription reports without IAY products
+type Generator struct {
+	rnd       *rand.Rand
+	countries []*Country
+	products  []*Product
+}
+
+//SalesReports Generate sales report rows for date
+func (g *Generator) SalesReports(date time.Time, count int) []*appstore.SalesReport {
+	reports := make([]*appstore.SalesReport, 0, count)
+	for i := 0; i < count; i++ {
+		product := g.product(nil)
+		if product == nil {
+			break
+		}
+		country := g.country()
+		price := g.customerPrice(product, country)
+		row := &appstore.SalesReport{
+			Provider:              "APPLE",
+			ProviderCountry:       "US",
+			SKU:                   product.SKU,
+			Developer:             DeveloperName,
+			Title:                 product.Title,
+			ProductTypeIdentifier: product.ProductTypeIdentifier,
+			Units:                 appstore.CustomFloat64{Float64: float64(1 + g.rnd.Intn(50))},
+			DeveloperProceeds:     g.proceeds(price, country),
+			BeginDate:             appstore.CustomDate{Date: date},
+			EndDate:               appstore.CustomDate{Date: date},
+			CustomerCurrency:      country.Currency,
+			CountryCode:           country.Code,
+			CurrencyOfProceeds:    country.Currency,
+			AppleIdentifier:       appstore.CustomInteger{Integer: product.AppleIdentifier},
+			CustomerPrice:         price,
+			ParentIdentifier:      product.ParentIdentifier,
+			Category:              product.Category,
+			Device:                g.device(),
+			SupportedPlatforms:    "iOS",
+		}
+		if product.ParentIdentifier == "" {
+			row.Version = "1.0.0"
+		}
+		if product.IsSubscription() {
+			row.Subscription = g.pick("New", "Renewal")
+			row.Period = product.SubscriptionDuration
+		}
+		reports = append(reports, row)
+	}
+	return reports
+}
+
+//SubscriptionsReports Generate subscriptions report rows
+func (g *Generator) SubscriptionsReports(count int) []*appstore.SubscriptionsReport {
+	reports := make([]*appstore.SubscriptionsReport, 0, count)
+	for i := 0; i < count; i++ {
+		product := g.product((*Product).IsSubscription)
+		if product == nil {
+			break
+		}
+		country := g.country()
+		price := g.customerPrice(product, country)
+		reports = append(reports, &appstore.SubscriptionsReport{
+			AppName:                          g.appName(product),
+			AppAppleID:                       appstore.CustomInteger{Integer: g.appAppleId(product)},
+			SubscriptionName:                 product.Title,
+			SubscriptionAppleID:              appstore.CustomInteger{Integer: product.AppleIdentifier},
+			SubscriptionGroupID:              appstore.CustomInteger{Integer: product.SubscriptionGroupID},
+			StandardSubscriptionDuration:     product.SubscriptionDuration,
+			CustomerPrice:                    price,
+			CustomerCurrency:                 country.Currency,
+			DeveloperProceeds:                g.proceeds(price, country),
+			ProceedsCurrency:                 country.Currency,
+			Device:                           g.device(),
+			Country:                          country.Code,
+			ActiveStandardPriceSubscriptions: appstore.CustomInteger{Integer: g.rnd.Intn(500)},
+			ActiveFreeTrialIntroductoryOfferSubscriptions: appstore.CustomInteger{Integer: g.rnd.Intn(50)},
+			MarketingOptIns: appstore.CustomInteger{Integer: g.rnd.Intn(10)},
+			BillingRetry:    appstore.CustomInteger{Integer: g.rnd.Intn(5)},
+			GracePeriod:     appstore.CustomInteger{Integer: g.rnd.Intn(5)},
+		})
+	}
+	return reports
+}
+
+//SubscriptionsEventsReports Generate subscriptions events report rows for date
+func (g *Generator) SubscriptionsEventsReports(date time.Time, count int) []*appstore.SubscriptionsEventsReport {
+	reports := make([]*appstore.SubscriptionsEventsReport, 0, count)
+	for i := 0; i < count; i++ {
+		product := g.product((*Product).IsSubscription)
+		if product == nil {
+			break
+		}
+		country := g.country()
+		event := appstore.SubscriptionEvent(g.pick("Subscribe", "Renew", "Cancel", "Start Introductory Offer", "Paid Subscription from Introductory Offer", "Refund"))
+		row := &appstore.SubscriptionsEventsReport{
+			EventDate:                    appstore.CustomDate{Date: date},
+			Event:                        event,
+			AppName:                      g.appName(product),
+			AppAppleID:                   appstore.CustomInteger{Integer: g.appAppleId(product)},
+			SubscriptionName:             product.Title,
+			SubscriptionAppleID:          appstore.CustomInteger{Integer: product.AppleIdentifier},
+			SubscriptionGroupID:          appstore.CustomInteger{Integer: product.SubscriptionGroupID},
+			StandardSubscriptionDuration: product.SubscriptionDuration,
+			ConsecutivePaidPeriods:       appstore.CustomInteger{Integer: g.rnd.Intn(12)},
+			OriginalStartDate:            appstore.CustomDate{Date: date.AddDate(0, -g.rnd.Intn(12), 0)},
+			Device:                       g.device(),
+			Country:                      country.Code,
+			Quantity:                     appstore.CustomInteger{Integer: 1 + g.rnd.Intn(20)},
+		}
+		if event == appstore.SubscriptionEventStartIntroductoryOffer {
+			row.SubscriptionOfferType = "Free Trial"
+			row.SubscriptionOfferDuration = "1 Week"
+		}
+		if event == appstore.SubscriptionEventCancel {
+			row.CancellationReason = g.pick("Other", "Price Increase", "Billing Issue")
+			row.DaysBeforeCanceling = appstore.CustomInteger{Integer: g.rnd.Intn(30)}
+		}
+		reports = append(reports, row)
+	}
+	return reports
+}
+
+//SubscribersReports Generate subscribers report rows for date
+func (g *Generator) SubscribersReports(date time.Time, count int) []*appstore.SubscribersReport {
+	reports := make([]*appstore.SubscribersReport, 0, count)
+	for i := 0; i < count; i++ {
+		product := g.product((*Product).IsSubscription)
+		if product == nil {
+			break
+		}
+		country := g.country()
+		price := g.customerPrice(product, country)
+		row := &appstore.SubscribersReport{
+			EventDate:                    appstore.CustomDate{Date: date},
+			AppName:                      g.appName(product),
+			AppAppleID:                   appstore.CustomInteger{Integer: g.appAppleId(product)},
+			SubscriptionName:             product.Title,
+			SubscriptionAppleID:          appstore.CustomInteger{Integer: product.AppleIdentifier},
+			SubscriptionGroupID:          appstore.CustomInteger{Integer: product.SubscriptionGroupID},
+			StandardSubscriptionDuration: product.SubscriptionDuration,
+			CustomerPrice:                price,
+			CustomerCurrency:             country.Currency,
+			DeveloperProceeds:            g.proceeds(price, country),
+			ProceedsCurrency:             country.Currency,
+			Country:                      country.Code,
+			SubscriberID:                 appstore.CustomInteger{Integer: 1000000000000 + g.rnd.Intn(1000000000)},
+			PurchaseDate:                 appstore.CustomDate{Date: date},
+			Units:                        appstore.CustomInteger{Integer: 1},
+		}
+		if g.rnd.Intn(20) == 0 {
+			row.Refund = "Yes"
+			row.Units = appstore.CustomInteger{Integer: -1}
+		}
+		reports = append(reports, row)
+	}
+	return reports
+}
+
+//PreOrdersReports Generate pre-orders report rows for date
+func (g *Generator) PreOrdersReports(date time.Time, count int) []*appstore.PreOrdersReport {
+	reports := make([]*appstore.PreOrdersReport, 0, count)
+	for i := 0; i < count; i++ {
+		product := g.product(func(p *Product) bool { return p.ParentIdentifier == "" })
+		if product == nil {
+			break
+		}
+		country := g.country()
+		ordered := float64(g.rnd.Intn(100))
+		canceled := float64(g.rnd.Intn(int(ordered) + 1))
+		reports = append(reports, &appstore.PreOrdersReport{
+			Provider:           "APPLE",
+			ProviderCountry:    "US",
+			SKU:                product.SKU,
+			Developer:          DeveloperName,
+			Title:              product.Title,
+			PreOrderStartDate:  appstore.CustomDate{Date: date.AddDate(0, 0, -30)},
+			PreOrderEndDate:    appstore.CustomDate{Date: date.AddDate(0, 0, 30)},
+			Ordered:            appstore.CustomFloat64{Float64: ordered},
+			Canceled:           appstore.CustomFloat64{Float64: canceled},
+			CumulativeOrdered:  appstore.CustomFloat64{Float64: ordered * 10},
+			CumulativeCanceled: appstore.CustomFloat64{Float64: canceled * 10},
+			StartDate:          appstore.CustomDate{Date: date},
+			EndDate:            appstore.CustomDate{Date: date},
+			CountryCode:        country.Code,
+			AppleIdentifier:    appstore.CustomInteger{Integer: product.AppleIdentifier},
+			Device:             g.device(),
+			SupportedPlatforms: "iOS",
+			Category:           product.Category,
+		})
+	}
+	return reports
+}
+
+//FinancialReports Generate financial report rows of the fiscal month for country
+func (g *Generator) FinancialReports(month time.Time, countryCode string, count int) []*appstore.FinancialReport {
+	country := g.findCountry(countryCode)
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	reports := make([]*appstore.FinancialReport, 0, count)
+	for i := 0; i < count; i++ {
+		product := g.product(func(p *Product) bool { return p.PriceUSD > 0 })
+		if product == nil {
+			break
+		}
+		price := g.customerPrice(product, country)
+		share := g.proceeds(price, country)
+		quantity := 1 + g.rnd.Intn(100)
+		saleOrReturn := "S"
+		if g.rnd.Intn(20) == 0 {
+			saleOrReturn = "R"
+			quantity = -quantity
+		}
+		reports = append(reports, &appstore.FinancialReport{
+			StartDate:             appstore.CustomDate{Date: start},
+			EndDate:               appstore.CustomDate{Date: start.AddDate(0, 1, -1)},
+			VendorIdentifier:      product.SKU,
+			Quantity:              appstore.CustomInteger{Integer: quantity},
+			PartnerShare:          share,
+			ExtendedPartnerShare:  share.MulInt(quantity),
+			PartnerShareCurrency:  country.Currency,
+			SaleOrReturn:          saleOrReturn,
+			AppleIdentifier:       appstore.CustomInteger{Integer: product.AppleIdentifier},
+			Title:                 product.Title,
+			ProductTypeIdentifier: product.ProductTypeIdentifier,
+			CountryOfSale:         country.Code,
+			CustomerPrice:         price,
+			CustomerCurrency:      country.Currency,
+		})
+	}
+	return reports
+}
+
+//product Pick random product matching predicate, nil when no product matches
+func (g *Generator) product(predicate func(p *Product) bool) *Product {
+	candidates := make([]*Product, 0, len(g.products))
+	for _, p := range g.products {
+		if predicate == nil || predicate(p) {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	return candidates[g.rnd.Intn(len(candidates))]
+}
+
+//country Pick random country
+func (g *Generator) country() *Country {
+	return g.countries[g.rnd.Intn(len(g.countries))]
+}
+
+//findCountry Find country by code, first country is used if not found
+func (g *Generator) findCountry(code string) *Country {
+	for _, c := range g.countries {
+		if c.Code == code {
+			return c
+		}
+	}
+	return g.countries[0]
+}
+
+//device Pick random device
+func (g *Generator) device() string {
+	return g.pick("iPhone", "iPad", "Desktop")
+}
+
+//pick Pick random value
+func (g *Generator) pick(values ...string) string {
+	return values[g.rnd.Intn(len(values))]
+}
+
+//customerPrice Convert product USD price to country currency
+func (g *Generator) customerPrice(product *Product, country *Country) appstore.Money {
+	price := decimal.NewFromFloat(product.PriceUSD).Mul(decimal.NewFromFloat(country.Rate))
+	return appstore.NewMoney(price.Round(int32(country.Decimals)), country.Currency)
+}
+
+//proceeds Developer proceeds per unit
+func (g *Generator) proceeds(price appstore.Money, country *Country) appstore.Money {
+	proceeds := price.Mul(decimal.NewFromFloat(1 - AppleCommissionRate))
+	return appstore.NewMoney(proceeds.Amount.Round(int32(country.Decimals)), country.Currency)
+}
+
+//appName Get app name of product
+func (g *Generator) appName(product *Product) string {
+	parent := g.parent(product)
+	return parent.Title
+}
+
+//appAppleId Get app apple id of product
+func (g *Generator) appAppleId(product *Product) int {
+	return g.parent(product).AppleIdentifier
+}
+
+//parent Find parent app of product
+func (g *Generator) parent(product *Product) *Product {
+	for _, p := range g.products {
+		if p.SKU == product.ParentIdentifier {
+			return p
+		}
+	}
+	return product
+}
+
+//New Create new generator with seed and copy of default catalog
+func New(seed int64) *Generator {
+	return &Generator{rnd: rand.New(rand.NewSource(seed)), countries: copyCountries(DefaultCountries), products: copyProducts(DefaultProducts)}
+}
+
+//NewWithCatalog Create new generator with seed and copy of custom catalog, at least one country and product are required
+func NewWithCatalog(seed int64, countries []*Country, products []*Product) (*Generator, error) {
+	if len(countries) == 0 {
+		return nil, errors.New("NewWithCatalog: countries are required")
+	}
+	if len(products) == 0 {
+		return nil, errors.New("NewWithCatalog: products are required")
+	}
+	for i, c := range countries {
+		if c == nil || c.Code == "" || c.Currency == "" {
+			return nil, fmt.Errorf("NewWithCatalog: country %d has no code or currency", i)
+		}
+	}
+	for i, p := range products {
+		if p == nil || p.SKU == "" {
+			return nil, fmt.Errorf("NewWithCatalog: product %d has no SKU", i)
+		}
+	}
+	return &Generator{rnd: rand.New(rand.NewSource(seed)), countries: copyCountries(countries), products: copyProducts(products)}, nil
+}
+
+//copyCountries Copy countries, changes of caller's catalog do not leak into generator
+func copyCountries(countries []*Country) []*Country {
+	result := make([]*Country, 0, len(countries))
+	for _, c := range countries {
+		country := *c
+		result = append(result, &country)
+	}
+	return result
+}
+
+//copyProducts Copy products, changes of caller's catalog do not leak into generator
+func copyProducts(products []*Product) []*Product {
+	result := make([]*Product, 0, len(products))
+	for _, p := range products {
+		product := *p
+		result = append(result, &product)
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type GeneratorTestSuite struct {
+	suite.Suite
+	date     time.Time
+	testable *Generator
+}
+
+func (suite *GeneratorTestSuite) SetupTest() {
+	suite.date, _ = time.Parse("2006-01-02", "2020-10-05")
+	suite.testable = New(42)
+}
+
+func (suite *GeneratorTestSuite) TestSeeded() {
+	assert.Equal(suite.T(), suite.testable.SalesReports(suite.date, 10), New(42).SalesReports(suite.date, 10))
+	assert.NotEqual(suite.T(), suite.testable.SalesReports(suite.date, 10), New(43).SalesReports(suite.date, 10))
+}
+
+func (suite *GeneratorTestSuite) TestSalesReportsConsistency() {
+	currencies := make(map[string]string)
+	for _, c := range DefaultCountries {
+		currencies[c.Code] = c.Currency
+	}
+	for _, row := range suite.testable.SalesReports(suite.date, 100) {
+		assert.Equal(suite.T(), currencies[row.CountryCode], row.CustomerCurrency)
+		assert.Equal(suite.T(), row.CustomerCurrency, row.CurrencyOfProceeds)
+		assert.True(suite.T(), row.DeveloperProceeds.Value().LessThanOrEqual(row.CustomerPrice.Value()))
+		assert.True(suite.T(), row.Units.Value() > 0)
+		assert.Equal(suite.T(), suite.date, row.BeginDate.Value())
+	}
+}
+
+func (suite *GeneratorTestSuite) TestSubscriptionsReports() {
+	for _, row := range suite.testable.SubscriptionsReports(20) {
+		assert.Equal(suite.T(), "Example App", row.AppName)
+		assert.Equal(suite.T(), 1234567890, row.AppAppleID.Value())
+		assert.Equal(suite.T(), 20000001, row.SubscriptionGroupID.Value())
+	}
+}
+
+func (suite *GeneratorTestSuite) TestFinancialReportsConsistency() {
+	for _, row := range suite.testable.FinancialReports(suite.date, "JP", 50) {
+		assert.Equal(suite.T(), "JPY", row.PartnerShareCurrency)
+		assert.Equal(suite.T(), "JP", row.CountryOfSale)
+		assert.True(suite.T(), row.PartnerShare.MulInt(row.Quantity.Value()).Equal(row.ExtendedPartnerShare))
+		assert.Equal(suite.T(), "2020-10-01", row.StartDate.Value().Format("2006-01-02"))
+		assert.Equal(suite.T(), "2020-10-31", row.EndDate.Value().Format("2006-01-02"))
+	}
+}
+
+func (suite *GeneratorTestSuite) TestNewWithCatalog() {
+	_, err := NewWithCatalog(1, nil, DefaultProducts)
+	assert.Equal(suite.T(), "NewWithCatalog: countries are required", err.Error())
+	_, err = NewWithCatalog(1, DefaultCountries, nil)
+	assert.Equal(suite.T(), "NewWithCatalog: products are required", err.Error())
+	_, err = NewWithCatalog(1, []*Country{{Code: "US"}}, DefaultProducts)
+	assert.Equal(suite.T(), "NewWithCatalog: country 0 has no code or currency", err.Error())
+
+	products := []*Product{{SKU: "APP", Title: "App", AppleIdentifier: 1, ProductTypeIdentifier: "1F"}}
+	generator, err := NewWithCatalog(1, DefaultCountries[:1], products)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), generator.SalesReports(suite.date, 5), 5)
+	assert.Len(suite.T(), generator.PreOrdersReports(suite.date, 5), 5)
+	assert.Empty(suite.T(), generator.SubscriptionsReports(5))
+	assert.Empty(suite.T(), generator.SubscriptionsEventsReports(suite.date, 5))
+	assert.Empty(suite.T(), generator.SubscribersReports(suite.date, 5))
+	assert.Empty(suite.T(), generator.FinancialReports(suite.date, "US", 5))
+}
+
+func (suite *GeneratorTestSuite) TestCatalogCopied() {
+	products := []*Product{{SKU: "APP", Title: "App", AppleIdentifier: 1, ProductTypeIdentifier: "1F"}}
+	generator, _ := NewWithCatalog(1, DefaultCountries[:1], products)
+	products[0].Title = "Changed"
+	assert.Equal(suite.T(), "App", generator.SalesReports(suite.date, 1)[0].Title)
+	suite.testable.countries[0].Currency = "XXX"
+	assert.Equal(suite.T(), "USD", DefaultCountries[0].Currency)
+	assert.Equal(suite.T(), "USD", New(42).countries[0].Currency)
+}
+
+func TestGeneratorTestSuite(t *testing.T) {
+	suite.Run(t, new(GeneratorTestSuite))
+}
@@ -0,0 +1,15 @@
+package generator
+
+import (
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+)
+
+//MarshalTSV Serialize slice of report rows to Apple's TSV format
+func MarshalTSV(rows interface{}) ([]byte, error) {
+	return appstore.MarshalCSV(rows)
+}
+
+//MarshalTSVGzip Serialize slice of report rows to gzipped Apple's TSV format
+func MarshalTSVGzip(rows interface{}) ([]byte, error) {
+	return appstore.MarshalCSVGzip(rows)
+}
@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"bytes"
+	"compress/gzip"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+type TSVTestSuite struct {
+	suite.Suite
+	date      time.Time
+	generator *Generator
+}
+
+func (suite *TSVTestSuite) SetupTest() {
+	suite.date, _ = time.Parse("2006-01-02", "2020-10-05")
+	suite.generator = New(1)
+}
+
+func (suite *TSVTestSuite) TestSalesReportsRoundTrip() {
+	rows := suite.generator.SalesReports(suite.date, 20)
+	data, err := MarshalTSV(rows)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), strings.HasPrefix(string(data), "Provider\tProvider Country\tSKU\t"))
+	assert.Contains(suite.T(), string(data), "\t10/05/2020\t")
+
+	parsed := []*appstore.SalesReport{}
+	assert.NoError(suite.T(), appstore.UnmarshalCSV(data, &parsed))
+	assert.Len(suite.T(), parsed, len(rows))
+	for i, row := range rows {
+		assert.Equal(suite.T(), row.SKU, parsed[i].SKU)
+		assert.Equal(suite.T(), row.AppleIdentifier, parsed[i].AppleIdentifier)
+		assert.Equal(suite.T(), row.BeginDate, parsed[i].BeginDate)
+		assert.True(suite.T(), row.DeveloperProceeds.Equal(parsed[i].DeveloperProceeds))
+	}
+}
+
+func (suite *TSVTestSuite) TestSubscriptionsRoundTrip() {
+	events := suite.generator.SubscriptionsEventsReports(suite.date, 10)
+	data, err := MarshalTSV(events)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), string(data), "2020-10-05\t")
+	parsedEvents := []*appstore.SubscriptionsEventsReport{}
+	assert.NoError(suite.T(), appstore.UnmarshalCSV(data, &parsedEvents))
+	assert.Equal(suite.T(), events[0].Event, parsedEvents[0].Event)
+	assert.Equal(suite.T(), events[0].OriginalStartDate, parsedEvents[0].OriginalStartDate)
+
+	subscribers := suite.generator.SubscribersReports(suite.date, 10)
+	data, _ = MarshalTSV(subscribers)
+	parsedSubscribers := []*appstore.SubscribersReport{}
+	assert.NoError(suite.T(), appstore.UnmarshalCSV(data, &parsedSubscribers))
+	assert.Equal(suite.T(), subscribers[0].SubscriberID, parsedSubscribers[0].SubscriberID)
+
+	subscriptions := suite.generator.SubscriptionsReports(10)
+	data, _ = MarshalTSV(subscriptions)
+	parsedSubscriptions := []*appstore.SubscriptionsReport{}
+	assert.NoError(suite.T(), appstore.UnmarshalCSV(data, &parsedSubscriptions))
+	assert.Equal(suite.T(), subscriptions[0].ActiveStandardPriceSubscriptions, parsedSubscriptions[0].ActiveStandardPriceSubscriptions)
+
+	preorders := suite.generator.PreOrdersReports(suite.date, 10)
+	data, _ = MarshalTSV(preorders)
+	parsedPreorders := []*appstore.PreOrdersReport{}
+	assert.NoError(suite.T(), appstore.UnmarshalCSV(data, &parsedPreorders))
+	assert.Equal(suite.T(), preorders[0].PreOrderStartDate, parsedPreorders[0].PreOrderStartDate)
+}
+
+func (suite *TSVTestSuite) TestFinancialReportsRoundTrip() {
+	rows := suite.generator.FinancialReports(suite.date, "US", 10)
+	data, err := MarshalTSV(rows)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), string(data), "Total_Rows\t10\n")
+	parsed := []*appstore.FinancialReport{}
+	assert.NoError(suite.T(), appstore.UnmarshalCSVWithFilterLines(data, &parsed))
+	assert.Len(suite.T(), parsed, 10)
+	assert.Equal(suite.T(), rows[0].Quantity, parsed[0].Quantity)
+}
+
+func (suite *TSVTestSuite) TestMarshalTSVGzip() {
+	rows := suite.generator.SalesReports(suite.date, 5)
+	expected, _ := MarshalTSV(rows)
+	data, err := MarshalTSVGzip(rows)
+	assert.NoError(suite.T(), err)
+	zr, _ := gzip.NewReader(bytes.NewReader(data))
+	raw, _ := ioutil.ReadAll(zr)
+	assert.Equal(suite.T(), expected, raw)
+}
+
+func (suite *TSVTestSuite) TestMarshalTSVNotSlice() {
+	_, err := MarshalTSV(&appstore.SalesReport{})
+	assert.Error(suite.T(), err)
+}
+
+func TestTSVTestSuite(t *testing.T) {
+	suite.Run(t, new(TSVTestSuite))
+}
@@ -6,5 +6,7 @@ require (
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/gocarina/gocsv v0.0.0-20200330101823-46266ca37bd3
 	github.com/jarcoal/httpmock v1.0.6
+	github.com/mattn/go-sqlite3 v1.14.6
+	github.com/shopspring/decimal v1.2.0
 	github.com/stretchr/testify v1.6.1
 )
@@ -11,10 +11,27 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 )
 
 const ResponseContentTypeJson = "application/json; charset=utf-8"
 const ResponseContentTypeGzip = "application/a-gzip"
+const HttpHeaderRateLimit = "X-Rate-Limit"
+const RequestContentTypeJson = "application/json"
+
+//reportPaths paths of resources responding with gzipped reports
+var reportPaths = []string{"v1/salesReports", "v1/financeReports"}
+
+//isReportPath Check whether resource of path responds with gzipped report
+func isReportPath(path string) bool {
+	path = strings.TrimPrefix(path, "/")
+	for _, reportPath := range reportPaths {
+		if path == reportPath {
+			return true
+		}
+	}
+	return false
+}
 
 //NewDefaultHttpClient create new http client
 func NewDefaultHttpClient() *http.Client {
@@ -29,11 +46,38 @@ func NewDefaultHttpClient() *http.Client {
 type RequestBuilder struct {
 	cfg   *Config
 	token *AuthToken
+	auth  *TokenBuilder //signs token again before it expires, token is never renewed without it
+	mu    sync.Mutex
 }
 
 //isValidToken method
 func (rb *RequestBuilder) isValidToken() bool {
-	return rb.token.IsValid()
+	_, err := rb.validToken()
+	return err == nil
+}
+
+//validToken Get valid token, token expiring in AppStoreConnectAPITokenRenewBefore seconds is signed again by token builder
+func (rb *RequestBuilder) validToken() (*AuthToken, error) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	if rb.auth != nil && (!rb.token.IsValid() || !rb.token.IsNotExpiredFor(AppStoreConnectAPITokenRenewBefore)) {
+		token, err := rb.auth.BuildAuthToken()
+		if err != nil {
+			return nil, fmt.Errorf("RequestBuilder.validToken renew: %v", err)
+		}
+		rb.token = token
+	}
+	if !rb.token.IsValid() {
+		return nil, fmt.Errorf("RequestBuilder.validToken: token is expired")
+	}
+	return rb.token, nil
+}
+
+//currentToken Get token used in Authorization header
+func (rb *RequestBuilder) currentToken() *AuthToken {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	return rb.token
 }
 
 //buildUri method
@@ -58,12 +102,16 @@ func (rb *RequestBuilder) buildQueryParams(query map[string]interface{}) string
 	return q.Encode()
 }
 
-//buildHeaders method
-func (rb *RequestBuilder) buildHeaders() http.Header {
+//buildHeaders method, reports are downloaded gzipped, other resources are JSON:API documents
+func (rb *RequestBuilder) buildHeaders(path string) http.Header {
 	headers := http.Header{}
-	headers.Set("Accept", "application/a-gzip")
-	headers.Set("Accept-Encoding", "gzip")
-	headers.Set("Authorization", "Bearer "+rb.token.Token)
+	if isReportPath(path) {
+		headers.Set("Accept", ResponseContentTypeGzip)
+		headers.Set("Accept-Encoding", "gzip")
+	} else {
+		headers.Set("Accept", RequestContentTypeJson)
+	}
+	headers.Set("Authorization", "Bearer "+rb.currentToken().Token)
 	return headers
 }
 
@@ -75,41 +123,83 @@ func (rb *RequestBuilder) BuildRequest(ctx context.Context, method string, path
 	if err != nil {
 		return nil, fmt.Errorf("transport.request build uri: %v", err)
 	}
+	//build body, only requests other than GET have a body
+	var reader io.Reader
+	if body != nil && method != http.MethodGet {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("transport.request encode body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
 	//build request
-	req, err = http.NewRequestWithContext(ctx, method, uri.String(), nil)
+	req, err = http.NewRequestWithContext(ctx, method, uri.String(), reader)
 	if err != nil {
 		return nil, fmt.Errorf("transport.request new request error: %v", err)
 	}
 	//build headers
-	req.Header = rb.buildHeaders()
+	req.Header = rb.buildHeaders(path)
+	if reader != nil {
+		req.Header.Set("Content-Type", RequestContentTypeJson)
+	}
 	return req, nil
 }
 
 //NewHttpTransport create new http transport
-func NewHttpTransport(config *Config, token *AuthToken, h *http.Client) *Transport {
+func NewHttpTransport(config *Config, token *AuthToken, h *http.Client, middlewares ...Middleware) *Transport {
 	if h == nil {
 		h = NewDefaultHttpClient()
 	}
 	rb := &RequestBuilder{cfg: config, token: token}
-	return &Transport{http: h, rb: rb}
+	return &Transport{http: h, rb: rb, middlewares: middlewares}
 }
 
 //Transport wrapper
 type Transport struct {
-	http *http.Client
-	rb   *RequestBuilder
+	http        *http.Client
+	rb          *RequestBuilder
+	middlewares MiddlewareChain
+	telemetry   Telemetry
+}
+
+//SetTelemetry Set tracing and metrics hooks
+func (t *Transport) SetTelemetry(telemetry Telemetry) *Transport {
+	t.telemetry = telemetry
+	return t
+}
+
+//getTelemetry Get tracing and metrics hooks, no-op by default
+func (t *Transport) getTelemetry() Telemetry {
+	if t.telemetry == nil {
+		return &NopTelemetry{}
+	}
+	return t.telemetry
+}
+
+//SetTokenBuilder Set token builder which signs token again before it expires, e.g. for long running daemons
+func (t *Transport) SetTokenBuilder(auth *TokenBuilder) *Transport {
+	t.rb.mu.Lock()
+	defer t.rb.mu.Unlock()
+	t.rb.auth = auth
+	return t
+}
+
+//Use Append middlewares to the transport chain
+func (t *Transport) Use(middlewares ...Middleware) *Transport {
+	t.middlewares = append(t.middlewares, middlewares...)
+	return t
 }
 
 //SendRequest method
 func (t *Transport) SendRequest(ctx context.Context, method string, path string, query map[string]interface{}, body map[string]interface{}) (resp *http.Response, err error) {
-	if !t.rb.isValidToken() {
+	if _, err = t.rb.validToken(); err != nil {
 		return nil, fmt.Errorf("transport.request invalid token: %v", err)
 	}
 	req, err := t.rb.BuildRequest(ctx, method, path, query, body)
 	if err != nil {
 		return nil, fmt.Errorf("transport.SendRequest: %v", err)
 	}
-	return t.http.Do(req)
+	return instrumentRequest(t.getTelemetry(), req, t.middlewares.Then(t.http.Do))
 }
 
 //Get method
@@ -117,6 +207,41 @@ func (t *Transport) Get(ctx context.Context, path string, query map[string]inter
 	return t.SendRequest(ctx, http.MethodGet, path, query, nil)
 }
 
+//Post method
+func (t *Transport) Post(ctx context.Context, path string, body map[string]interface{}) (resp *http.Response, err error) {
+	return t.SendRequest(ctx, http.MethodPost, path, nil, body)
+}
+
+//Patch method
+func (t *Transport) Patch(ctx context.Context, path string, body map[string]interface{}) (resp *http.Response, err error) {
+	return t.SendRequest(ctx, http.MethodPatch, path, nil, body)
+}
+
+//Delete method
+func (t *Transport) Delete(ctx context.Context, path string, body map[string]interface{}) (resp *http.Response, err error) {
+	return t.SendRequest(ctx, http.MethodDelete, path, nil, body)
+}
+
+//downloadContextKey context key marking download requests, query of pre-signed url is its signature
+type downloadContextKey struct{}
+
+//IsDownloadRequest Check request was sent by Transport.Download, its query is redacted by logger and dropped by recorder
+func IsDownloadRequest(req *http.Request) bool {
+	download, _ := req.Context().Value(downloadContextKey{}).(bool)
+	return download
+}
+
+//Download Get file by absolute url, e.g. pre-signed url of report segment, the request is sent without authorization header.
+//File is requested with identity encoding, so body is exactly the stored file and it is never decompressed by http client
+func (t *Transport) Download(ctx context.Context, uri string) (resp *http.Response, err error) {
+	req, err := http.NewRequestWithContext(context.WithValue(ctx, downloadContextKey{}, true), http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("transport.Download new request error: %v", err)
+	}
+	req.Header.Set("Accept-Encoding", "identity")
+	return instrumentRequest(t.getTelemetry(), req, t.middlewares.Then(t.http.Do))
+}
+
 //ResponseBody struct
 type ResponseBody struct {
 	status int
@@ -134,6 +259,21 @@ func (r *ResponseBody) GetError() string {
 	return err
 }
 
+//setStatus method
+func (r *ResponseBody) setStatus(status int) {
+	r.status = status
+}
+
+//getErrors method
+func (r *ResponseBody) getErrors() []*Error {
+	return r.Errors
+}
+
+//errorsHolder response with api errors
+type errorsHolder interface {
+	getErrors() []*Error
+}
+
 //IsSuccess method
 func (r *ResponseBody) IsSuccess() bool {
 	return r.status < http.StatusMultipleChoices
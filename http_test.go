@@ -27,10 +27,18 @@ func (suite *HttpRequestBuilderTestSuite) SetupTest() {
 }
 
 func (suite *HttpRequestBuilderTestSuite) TestBuildHeaders() {
-	headers := suite.testable.buildHeaders()
+	headers := suite.testable.buildHeaders("v1/salesReports")
 	assert.Equal(suite.T(), "application/a-gzip", headers.Get("Accept"))
 	assert.Equal(suite.T(), "gzip", headers.Get("Accept-Encoding"))
 	assert.Equal(suite.T(), "Bearer "+suite.token.Token, headers.Get("Authorization"))
+	assert.Equal(suite.T(), "application/a-gzip", suite.testable.buildHeaders("/v1/financeReports").Get("Accept"))
+}
+
+func (suite *HttpRequestBuilderTestSuite) TestBuildHeadersJson() {
+	headers := suite.testable.buildHeaders("v1/apps")
+	assert.Equal(suite.T(), "application/json", headers.Get("Accept"))
+	assert.Empty(suite.T(), headers.Get("Accept-Encoding"))
+	assert.Equal(suite.T(), "Bearer "+suite.token.Token, headers.Get("Authorization"))
 }
 
 func (suite *HttpRequestBuilderTestSuite) TestIsValidTokenSuccess() {
@@ -62,16 +70,26 @@ func (suite *HttpRequestBuilderTestSuite) TestBuildUriWithQueryParams() {
 }
 
 func (suite *HttpRequestBuilderTestSuite) TestBuildRequestGET() {
-	result, err := suite.testable.BuildRequest(suite.ctx, "get", "foo", map[string]interface{}{"foo": "bar"}, map[string]interface{}{"foo": "bar"})
+	result, err := suite.testable.BuildRequest(suite.ctx, "get", "v1/salesReports", map[string]interface{}{"foo": "bar"}, map[string]interface{}{"foo": "bar"})
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), http.MethodGet, result.Method)
-	assert.Equal(suite.T(), "https://github.com/foo?foo=bar", result.URL.String())
+	assert.Equal(suite.T(), "https://github.com/v1/salesReports?foo=bar", result.URL.String())
 	assert.Equal(suite.T(), "application/a-gzip", result.Header.Get("Accept"))
 	assert.Equal(suite.T(), "gzip", result.Header.Get("Accept-Encoding"))
 	assert.Equal(suite.T(), "Bearer "+suite.token.Token, result.Header.Get("Authorization"))
 	assert.Nil(suite.T(), result.Body)
 }
 
+func (suite *HttpRequestBuilderTestSuite) TestBuildRequestPOST() {
+	result, err := suite.testable.BuildRequest(suite.ctx, "post", "foo", nil, map[string]interface{}{"foo": "bar"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.MethodPost, result.Method)
+	assert.Equal(suite.T(), RequestContentTypeJson, result.Header.Get("Content-Type"))
+	assert.Equal(suite.T(), "application/json", result.Header.Get("Accept"))
+	body, _ := ioutil.ReadAll(result.Body)
+	assert.Equal(suite.T(), `{"foo":"bar"}`, string(body))
+}
+
 func TestHttpRequestBuilderTestSuite(t *testing.T) {
 	suite.Run(t, new(HttpRequestBuilderTestSuite))
 }
@@ -172,8 +190,8 @@ func (suite *HttpResponseHandlerGzipTestSuite) TestUnmarshalBody() {
 	assert.Equal(suite.T(), 1234567890, reports[0].AppleIdentifier.Value())
 	assert.Equal(suite.T(), "2020-10-05", reports[0].BeginDate.Value().Format(CustomDateFormatDefault))
 	assert.Equal(suite.T(), "2020-10-05", reports[0].EndDate.Value().Format(CustomDateFormatDefault))
-	assert.Equal(suite.T(), float64(299), reports[0].CustomerPrice.Value())
-	assert.Equal(suite.T(), 209.3000030517578, reports[0].DeveloperProceeds.Value())
+	assert.Equal(suite.T(), "299.00 RUB", reports[0].CustomerPrice.String())
+	assert.Equal(suite.T(), "209.30 RUB", reports[0].DeveloperProceeds.String())
 	assert.Equal(suite.T(), float64(12), reports[0].Units.Value())
 }
 
@@ -267,7 +285,55 @@ func (suite *HttpTransportTestSuite) TestRequestGETInvalidToken() {
 	resp, err := suite.testable.Get(suite.ctx, "foo", nil)
 	assert.Nil(suite.T(), resp)
 	assert.Error(suite.T(), err)
-	assert.Equal(suite.T(), "transport.request invalid token: <nil>", err.Error())
+	assert.Equal(suite.T(), "transport.request invalid token: RequestBuilder.validToken: token is expired", err.Error())
+}
+
+func (suite *HttpTransportTestSuite) TestRequestRenewsExpiringToken() {
+	tokens := make([]string, 0)
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/foo", func(req *http.Request) (*http.Response, error) {
+		tokens = append(tokens, req.Header.Get("Authorization"))
+		return httpmock.NewStringResponse(http.StatusOK, ""), nil
+	})
+	suite.testable = buildStubHttpTransport()
+	suite.testable.SetTokenBuilder(NewTokenBuilder(suite.cfg))
+	_, err := suite.testable.Get(suite.ctx, "foo", nil)
+	assert.NoError(suite.T(), err)
+	suite.testable.rb.token.ExpiresAt = time.Now().Unix() - 1000
+	_, err = suite.testable.Get(suite.ctx, "foo", nil)
+	assert.NoError(suite.T(), err)
+	suite.testable.rb.token.ExpiresAt = time.Now().Unix() + AppStoreConnectAPITokenRenewBefore/2
+	_, err = suite.testable.Get(suite.ctx, "foo", nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Bearer AuthToken", tokens[0])
+	assert.NotEqual(suite.T(), tokens[0], tokens[1])
+	assert.NotEqual(suite.T(), tokens[1], tokens[2])
+	assert.True(suite.T(), suite.testable.rb.token.IsNotExpiredFor(AppStoreConnectAPITokenRenewBefore))
+}
+
+func (suite *HttpTransportTestSuite) TestRequestMethods() {
+	suite.testable = buildStubHttpTransport()
+	bodies := make(map[string]string)
+	responder := func(req *http.Request) (*http.Response, error) {
+		if req.Body != nil {
+			data, _ := ioutil.ReadAll(req.Body)
+			bodies[req.Method] = string(data)
+		}
+		return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+	}
+	for _, method := range []string{"POST", "PATCH", "DELETE"} {
+		httpmock.RegisterResponder(method, suite.cfg.Uri+"/v1/foo", responder)
+	}
+	body := map[string]interface{}{"data": map[string]interface{}{"type": "foo"}}
+	resp, err := suite.testable.Post(suite.ctx, "v1/foo", body)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNoContent, resp.StatusCode)
+	_, err = suite.testable.Patch(suite.ctx, "v1/foo", body)
+	assert.NoError(suite.T(), err)
+	_, err = suite.testable.Delete(suite.ctx, "v1/foo", nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), `{"data":{"type":"foo"}}`, bodies["POST"])
+	assert.Equal(suite.T(), `{"data":{"type":"foo"}}`, bodies["PATCH"])
+	assert.Empty(suite.T(), bodies["DELETE"])
 }
 
 func TestHttpTransportTestSuite(t *testing.T) {
@@ -0,0 +1,157 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+//Key checkpoint key of report stream
+type Key struct {
+	VendorNumber  string `json:"vendor_number"`
+	ReportType    string `json:"report_type"`     //sales report type or finances report type
+	ReportSubType string `json:"report_sub_type"` //empty for finances reports
+	Version       string `json:"version"`         //empty for finances reports
+	Frequency     string `json:"frequency"`       //MONTHLY for finances reports
+	RegionCode    string `json:"region_code"`     //region code of finances reports
+}
+
+//String Get key description
+func (k Key) String() string {
+	if k.RegionCode != "" {
+		return fmt.Sprintf("%s %s %s %s", k.VendorNumber, k.ReportType, k.Frequency, k.RegionCode)
+	}
+	return fmt.Sprintf("%s %s %s %s %s", k.VendorNumber, k.ReportType, k.ReportSubType, k.Frequency, k.Version)
+}
+
+//CheckpointStore persistent set of ingested report dates per key
+type CheckpointStore interface {
+	//Dates Get ingested report dates of key in ascending order
+	Dates(ctx context.Context, key Key) ([]time.Time, error)
+	//Mark Mark report date of key as ingested
+	Mark(ctx context.Context, key Key, date time.Time) error
+}
+
+//checkpointDateFormat format of checkpoint dates
+const checkpointDateFormat = "2006-01-02"
+
+//fileCheckpoint ingested dates of key in checkpoint file
+type fileCheckpoint struct {
+	Key
+	Dates []string `json:"dates"`
+}
+
+//fileCheckpoints checkpoint file content
+type fileCheckpoints struct {
+	Checkpoints []*fileCheckpoint `json:"checkpoints"`
+}
+
+//FileCheckpointStore checkpoint store of JSON file, file is replaced atomically on every mark
+type FileCheckpointStore struct {
+	Path string
+	mu   sync.Mutex
+}
+
+//NewFileCheckpointStore Create new checkpoint store of file, missing file is created by first mark
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{Path: path}
+}
+
+//Dates Get ingested report dates of key in ascending order
+func (s *FileCheckpointStore) Dates(ctx context.Context, key Key) ([]time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	checkpoints, err := s.read()
+	if err != nil {
+		return nil, fmt.Errorf("FileCheckpointStore.Dates: %v", err)
+	}
+	dates := make([]time.Time, 0)
+	for _, checkpoint := range checkpoints.Checkpoints {
+		if checkpoint.Key != key {
+			continue
+		}
+		for _, value := range checkpoint.Dates {
+			date, err := time.Parse(checkpointDateFormat, value)
+			if err != nil {
+				return nil, fmt.Errorf("FileCheckpointStore.Dates: %v", err)
+			}
+			dates = append(dates, date)
+		}
+	}
+	return dates, nil
+}
+
+//Mark Mark report date of key as ingested
+func (s *FileCheckpointStore) Mark(ctx context.Context, key Key, date time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	checkpoints, err := s.read()
+	if err != nil {
+		return fmt.Errorf("FileCheckpointStore.Mark: %v", err)
+	}
+	var checkpoint *fileCheckpoint
+	for _, c := range checkpoints.Checkpoints {
+		if c.Key == key {
+			checkpoint = c
+		}
+	}
+	if checkpoint == nil {
+		checkpoint = &fileCheckpoint{Key: key}
+		checkpoints.Checkpoints = append(checkpoints.Checkpoints, checkpoint)
+	}
+	value := date.Format(checkpointDateFormat)
+	i := sort.SearchStrings(checkpoint.Dates, value)
+	if i < len(checkpoint.Dates) && checkpoint.Dates[i] == value {
+		return nil
+	}
+	checkpoint.Dates = append(checkpoint.Dates, "")
+	copy(checkpoint.Dates[i+1:], checkpoint.Dates[i:])
+	checkpoint.Dates[i] = value
+	if err = s.write(checkpoints); err != nil {
+		return fmt.Errorf("FileCheckpointStore.Mark: %v", err)
+	}
+	return nil
+}
+
+//read Read checkpoint file, missing file has no checkpoints
+func (s *FileCheckpointStore) read() (*fileCheckpoints, error) {
+	checkpoints := &fileCheckpoints{}
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return checkpoints, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, checkpoints); err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+//write Write checkpoint file to temporary file and rename it over previous one
+func (s *FileCheckpointStore) write(checkpoints *fileCheckpoints) error {
+	data, err := json.MarshalIndent(checkpoints, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
@@ -0,0 +1,98 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/matisiekpl/appstore-sdk-go/sqlstore"
+	"time"
+)
+
+//CheckpointsTable name of checkpoints table
+const CheckpointsTable = "checkpoints"
+
+//SQLCheckpointStore checkpoint store of SQL database, may share database with sqlstore.Store
+type SQLCheckpointStore struct {
+	DB      *sql.DB
+	Dialect *sqlstore.Dialect
+	now     func() time.Time
+}
+
+//NewSQLCheckpointStore Create new checkpoint store of database
+func NewSQLCheckpointStore(db *sql.DB, dialect *sqlstore.Dialect) *SQLCheckpointStore {
+	return &SQLCheckpointStore{DB: db, Dialect: dialect, now: time.Now}
+}
+
+//Migrate Create checkpoints table when it does not exist
+func (s *SQLCheckpointStore) Migrate(ctx context.Context) error {
+	_, err := s.DB.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS "`+CheckpointsTable+`" (
+	"vendor_number" TEXT NOT NULL,
+	"report_type" TEXT NOT NULL,
+	"report_sub_type" TEXT NOT NULL,
+	"version" TEXT NOT NULL,
+	"frequency" TEXT NOT NULL,
+	"region_code" TEXT NOT NULL,
+	"report_date" TEXT NOT NULL,
+	"ingested_at" TIMESTAMP NOT NULL,
+	PRIMARY KEY ("vendor_number", "report_type", "report_sub_type", "version", "frequency", "region_code", "report_date")
+)`)
+	if err != nil {
+		return fmt.Errorf("SQLCheckpointStore.Migrate: %v", err)
+	}
+	return nil
+}
+
+//Dates Get ingested report dates of key in ascending order
+func (s *SQLCheckpointStore) Dates(ctx context.Context, key Key) ([]time.Time, error) {
+	query := fmt.Sprintf(`SELECT "report_date" FROM "%s" WHERE %s ORDER BY "report_date"`, CheckpointsTable, s.keyCondition())
+	rows, err := s.DB.QueryContext(ctx, query, keyArgs(key)...)
+	if err != nil {
+		return nil, fmt.Errorf("SQLCheckpointStore.Dates: %v", err)
+	}
+	defer rows.Close()
+	dates := make([]time.Time, 0)
+	for rows.Next() {
+		var value string
+		if err = rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("SQLCheckpointStore.Dates: %v", err)
+		}
+		date, err := time.Parse(checkpointDateFormat, value)
+		if err != nil {
+			return nil, fmt.Errorf("SQLCheckpointStore.Dates: %v", err)
+		}
+		dates = append(dates, date)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("SQLCheckpointStore.Dates: %v", err)
+	}
+	return dates, nil
+}
+
+//Mark Mark report date of key as ingested
+func (s *SQLCheckpointStore) Mark(ctx context.Context, key Key, date time.Time) error {
+	params := ""
+	for i := 1; i <= 8; i++ {
+		if i > 1 {
+			params += ", "
+		}
+		params += s.Dialect.Placeholder(i)
+	}
+	query := fmt.Sprintf(`INSERT INTO "%s" ("vendor_number", "report_type", "report_sub_type", "version", "frequency", "region_code", "report_date", "ingested_at") VALUES (%s) ON CONFLICT DO NOTHING`,
+		CheckpointsTable, params)
+	args := append(keyArgs(key), date.Format(checkpointDateFormat), s.now().UTC())
+	if _, err := s.DB.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("SQLCheckpointStore.Mark: %v", err)
+	}
+	return nil
+}
+
+//keyCondition Build where condition of key columns
+func (s *SQLCheckpointStore) keyCondition() string {
+	return fmt.Sprintf(`"vendor_number" = %s AND "report_type" = %s AND "report_sub_type" = %s AND "version" = %s AND "frequency" = %s AND "region_code" = %s`,
+		s.Dialect.Placeholder(1), s.Dialect.Placeholder(2), s.Dialect.Placeholder(3), s.Dialect.Placeholder(4), s.Dialect.Placeholder(5), s.Dialect.Placeholder(6))
+}
+
+//keyArgs Get query arguments of key columns
+func keyArgs(key Key) []interface{} {
+	return []interface{}{key.VendorNumber, key.ReportType, key.ReportSubType, key.Version, key.Frequency, key.RegionCode}
+}
@@ -0,0 +1,65 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"github.com/matisiekpl/appstore-sdk-go/sqlstore"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type SQLCheckpointStoreTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	db       *sql.DB
+	testable *SQLCheckpointStore
+	key      Key
+}
+
+func (suite *SQLCheckpointStoreTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.db, _ = sql.Open("sqlite3", ":memory:")
+	suite.db.SetMaxOpenConns(1)
+	suite.testable = NewSQLCheckpointStore(suite.db, sqlstore.DialectSQLite)
+	assert.NoError(suite.T(), suite.testable.Migrate(suite.ctx))
+	suite.key = Key{VendorNumber: "123", ReportType: "FINANCIAL", Frequency: "MONTHLY", RegionCode: "US"}
+}
+
+func (suite *SQLCheckpointStoreTestSuite) TearDownTest() {
+	_ = suite.db.Close()
+}
+
+func (suite *SQLCheckpointStoreTestSuite) TestMark() {
+	first := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+	second := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	dates, err := suite.testable.Dates(suite.ctx, suite.key)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), dates)
+	assert.NoError(suite.T(), suite.testable.Mark(suite.ctx, suite.key, second))
+	assert.NoError(suite.T(), suite.testable.Mark(suite.ctx, suite.key, first))
+	assert.NoError(suite.T(), suite.testable.Mark(suite.ctx, suite.key, second))
+	other := suite.key
+	other.RegionCode = "EU"
+	assert.NoError(suite.T(), suite.testable.Mark(suite.ctx, other, first))
+	dates, err = suite.testable.Dates(suite.ctx, suite.key)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []time.Time{first, second}, dates)
+	dates, _ = suite.testable.Dates(suite.ctx, other)
+	assert.Equal(suite.T(), []time.Time{first}, dates)
+}
+
+func (suite *SQLCheckpointStoreTestSuite) TestMigrated() {
+	store := NewSQLCheckpointStore(suite.db, sqlstore.DialectSQLite)
+	assert.NoError(suite.T(), store.Migrate(suite.ctx))
+	_, _ = suite.db.Exec(`DROP TABLE "checkpoints"`)
+	_, err := store.Dates(suite.ctx, suite.key)
+	assert.Error(suite.T(), err)
+	assert.Error(suite.T(), store.Mark(suite.ctx, suite.key, time.Now()))
+}
+
+func TestSQLCheckpointStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(SQLCheckpointStoreTestSuite))
+}
@@ -0,0 +1,74 @@
+package ingest
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type FileCheckpointStoreTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	dir      string
+	testable *FileCheckpointStore
+	key      Key
+}
+
+func (suite *FileCheckpointStoreTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.dir, _ = ioutil.TempDir("", "checkpoints")
+	suite.testable = NewFileCheckpointStore(filepath.Join(suite.dir, "checkpoints.json"))
+	suite.key = Key{VendorNumber: "123", ReportType: "SALES", ReportSubType: "SUMMARY", Version: "1_0", Frequency: "DAILY"}
+}
+
+func (suite *FileCheckpointStoreTestSuite) TearDownTest() {
+	_ = os.RemoveAll(suite.dir)
+}
+
+func (suite *FileCheckpointStoreTestSuite) TestDatesEmpty() {
+	dates, err := suite.testable.Dates(suite.ctx, suite.key)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), dates)
+}
+
+func (suite *FileCheckpointStoreTestSuite) TestMark() {
+	first := time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC)
+	second := time.Date(2020, 5, 6, 0, 0, 0, 0, time.UTC)
+	assert.NoError(suite.T(), suite.testable.Mark(suite.ctx, suite.key, second))
+	assert.NoError(suite.T(), suite.testable.Mark(suite.ctx, suite.key, first))
+	assert.NoError(suite.T(), suite.testable.Mark(suite.ctx, suite.key, second))
+	other := suite.key
+	other.Version = "1_1"
+	assert.NoError(suite.T(), suite.testable.Mark(suite.ctx, other, first))
+
+	//checkpoints are persisted
+	store := NewFileCheckpointStore(suite.testable.Path)
+	dates, err := store.Dates(suite.ctx, suite.key)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []time.Time{first, second}, dates)
+	dates, _ = store.Dates(suite.ctx, other)
+	assert.Equal(suite.T(), []time.Time{first}, dates)
+	files, _ := ioutil.ReadDir(suite.dir)
+	assert.Len(suite.T(), files, 1)
+}
+
+func (suite *FileCheckpointStoreTestSuite) TestInvalidFile() {
+	assert.NoError(suite.T(), ioutil.WriteFile(suite.testable.Path, []byte("foo"), 0644))
+	_, err := suite.testable.Dates(suite.ctx, suite.key)
+	assert.Error(suite.T(), err)
+	assert.Error(suite.T(), suite.testable.Mark(suite.ctx, suite.key, time.Now()))
+}
+
+func (suite *FileCheckpointStoreTestSuite) TestKeyString() {
+	assert.Equal(suite.T(), "123 SALES SUMMARY DAILY 1_0", suite.key.String())
+	assert.Equal(suite.T(), "123 FINANCIAL MONTHLY US", Key{VendorNumber: "123", ReportType: "FINANCIAL", Frequency: "MONTHLY", RegionCode: "US"}.String())
+}
+
+func TestFileCheckpointStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(FileCheckpointStoreTestSuite))
+}
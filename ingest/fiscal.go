@@ -0,0 +1,47 @@
+package ingest
+
+import (
+	"time"
+)
+
+//fiscalMonthWeeks weeks of Apple's fiscal months from October to September, every quarter is 5-4-4 weeks
+var fiscalMonthWeeks = [12]int{5, 4, 4, 5, 4, 4, 5, 4, 4, 5, 4, 4}
+
+//fiscalYearEnd Get last day of Apple's fiscal year, the last Saturday of September
+func fiscalYearEnd(year int) time.Time {
+	date := time.Date(year, time.September, 30, 0, 0, 0, 0, time.UTC)
+	return date.AddDate(0, 0, -(int(date.Weekday())-int(time.Saturday)+7)%7)
+}
+
+//FiscalMonthEnd Get last day of Apple's fiscal month, month is identified by year and month of its report date.
+//Fiscal year starts on Sunday after last Saturday of September, fiscal months are 5-4-4 weeks in every quarter and end on Saturday.
+//Fiscal year of 53 weeks has extra week in December, the last month of first quarter
+func FiscalMonthEnd(month time.Time) time.Time {
+	year := month.Year()
+	if month.Month() >= time.October {
+		year++
+	}
+	start := fiscalYearEnd(year-1).AddDate(0, 0, 1)
+	//1 for fiscal year of 53 weeks
+	extra := (int(fiscalYearEnd(year).Sub(start).Hours()/24)+1)/7 - 52
+	index := (int(month.Month()) - int(time.October) + 12) % 12
+	weeks := 0
+	for i := 0; i <= index; i++ {
+		weeks += fiscalMonthWeeks[i]
+		if i == 2 {
+			weeks += extra
+		}
+	}
+	return start.AddDate(0, 0, 7*weeks-1)
+}
+
+//LatestFiscalMonth Get report date of latest Apple's fiscal month which ended before today at now
+func LatestFiscalMonth(now time.Time) time.Time {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for !FiscalMonthEnd(month).Before(today) {
+		month = month.AddDate(0, -1, 0)
+	}
+	return month
+}
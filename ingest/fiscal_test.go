@@ -0,0 +1,54 @@
+package ingest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type FiscalTestSuite struct {
+	suite.Suite
+}
+
+func (suite *FiscalTestSuite) TestFiscalMonthEnd() {
+	ends := map[string]string{
+		"2019-10": "2019-11-02",
+		"2019-11": "2019-11-30",
+		"2019-12": "2019-12-28",
+		"2020-01": "2020-02-01",
+		"2020-05": "2020-05-30",
+		"2020-09": "2020-09-26",
+		//fiscal year 2023 has 53 weeks, December is 5 weeks long
+		"2022-10": "2022-10-29",
+		"2022-12": "2022-12-31",
+		"2023-01": "2023-02-04",
+		"2023-09": "2023-09-30",
+		"2023-10": "2023-11-04",
+	}
+	for month, end := range ends {
+		date, _ := time.Parse("2006-01", month)
+		assert.Equal(suite.T(), end, FiscalMonthEnd(date).Format("2006-01-02"), month)
+		assert.Equal(suite.T(), time.Saturday, FiscalMonthEnd(date).Weekday(), month)
+	}
+}
+
+func (suite *FiscalTestSuite) TestFiscalMonthsAreContiguous() {
+	month := time.Date(2015, 10, 1, 0, 0, 0, 0, time.UTC)
+	for ; month.Year() < 2030; month = month.AddDate(0, 1, 0) {
+		weeks := int(FiscalMonthEnd(month).Sub(FiscalMonthEnd(month.AddDate(0, -1, 0))).Hours()/24) / 7
+		assert.True(suite.T(), weeks == 4 || weeks == 5, month.Format("2006-01"))
+	}
+}
+
+func (suite *FiscalTestSuite) TestLatestFiscalMonth() {
+	assert.Equal(suite.T(), time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), LatestFiscalMonth(time.Date(2020, 5, 2, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(suite.T(), time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), LatestFiscalMonth(time.Date(2020, 5, 3, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(suite.T(), time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), LatestFiscalMonth(time.Date(2020, 5, 30, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(suite.T(), time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC), LatestFiscalMonth(time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(suite.T(), time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC), LatestFiscalMonth(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestFiscalTestSuite(t *testing.T) {
+	suite.Run(t, new(FiscalTestSuite))
+}
@@ -0,0 +1,271 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+//PublicationHourDefault const, UTC hour when Apple usually finishes publishing reports of previous day
+const PublicationHourDefault = 15
+
+//PublicationDayDefault const, day of calendar month when monthly and yearly reports are usually published
+const PublicationDayDefault = 5
+
+//FinancePublicationDelayDefault const, days after end of Apple's fiscal month when financial reports are usually published
+const FinancePublicationDelayDefault = 5
+
+//JitterDefault const, max random delay added to scheduled sync
+const JitterDefault = 15 * time.Minute
+
+//RetryIntervalDefault const, delay of sync retry after error or when latest report is not published yet
+const RetryIntervalDefault = time.Hour
+
+//Status sync status of stream
+type Status struct {
+	Key          Key       `json:"key"`
+	LastRun      time.Time `json:"last_run"`
+	LastSuccess  time.Time `json:"last_success"`
+	LastError    string    `json:"last_error,omitempty"`
+	NextRun      time.Time `json:"next_run"`
+	Ingested     int       `json:"ingested"`      //report dates ingested since scheduler start
+	NotAvailable []string  `json:"not_available"` //report dates not available by last run
+}
+
+//job scheduled stream
+type job struct {
+	stream *Stream
+	status Status
+}
+
+//Scheduler long running incremental sync of streams following Apple's publication cadence: daily reports are synced
+//every day after publication hour, weekly reports on Monday, monthly and yearly reports on publication day of month,
+//financial reports on publication delay days after end of fiscal month.
+//Streams are synced one by one, failed syncs and syncs missing latest report are retried after retry interval
+type Scheduler struct {
+	Syncer          *Syncer
+	PublicationHour int           //UTC hour of day
+	PublicationDay  int           //day of month
+	FinanceDelay    int           //days after end of fiscal month
+	Jitter          time.Duration //max random delay of scheduled syncs
+	RetryInterval   time.Duration
+	Logger          appstore.Logger //logger of sync results
+	jobs            []*job
+	mu              sync.Mutex
+	running         bool
+	now             func() time.Time
+	after           func(d time.Duration) <-chan time.Time
+	random          *rand.Rand
+}
+
+//NewScheduler Create new scheduler of streams, every stream is synced once right after start
+func NewScheduler(syncer *Syncer, streams ...*Stream) *Scheduler {
+	jobs := make([]*job, 0, len(streams))
+	for _, stream := range streams {
+		key, _ := syncer.key(stream)
+		jobs = append(jobs, &job{stream: stream, status: Status{Key: key}})
+	}
+	return &Scheduler{
+		Syncer:          syncer,
+		PublicationHour: PublicationHourDefault,
+		PublicationDay:  PublicationDayDefault,
+		FinanceDelay:    FinancePublicationDelayDefault,
+		Jitter:          JitterDefault,
+		RetryInterval:   RetryIntervalDefault,
+		Logger:          &appstore.NopLogger{},
+		jobs:            jobs,
+		now:             time.Now,
+		after:           time.After,
+		random:          rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+//Run Sync streams when they are due until context is canceled
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.mu.Lock()
+	s.running = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.running = false
+		s.mu.Unlock()
+	}()
+	if len(s.jobs) == 0 {
+		<-ctx.Done()
+		return nil
+	}
+	for {
+		wake := s.tick(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.after(wake.Sub(s.now())):
+		}
+	}
+}
+
+//tick Sync due streams, returns time of next due stream
+func (s *Scheduler) tick(ctx context.Context) time.Time {
+	var wake time.Time
+	for _, j := range s.jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		s.mu.Lock()
+		due := !j.status.NextRun.After(s.now())
+		s.mu.Unlock()
+		if due {
+			s.sync(ctx, j)
+		}
+		s.mu.Lock()
+		if wake.IsZero() || j.status.NextRun.Before(wake) {
+			wake = j.status.NextRun
+		}
+		s.mu.Unlock()
+	}
+	return wake
+}
+
+//sync Sync stream of job and schedule next run
+func (s *Scheduler) sync(ctx context.Context, j *job) {
+	started := s.now()
+	result, err := s.Syncer.Sync(ctx, j.stream)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j.status.LastRun = started
+	j.status.NotAvailable = make([]string, 0)
+	if result != nil {
+		j.status.Key = result.Key
+		j.status.Ingested += len(result.Ingested)
+		for _, date := range result.NotAvailable {
+			j.status.NotAvailable = append(j.status.NotAvailable, date.Format(checkpointDateFormat))
+		}
+	}
+	next := s.NextPublication(j.stream, started)
+	retry := started.Add(s.RetryInterval)
+	if err != nil {
+		j.status.LastError = err.Error()
+		if retry.Before(next) {
+			next = retry
+		}
+	} else {
+		j.status.LastError = ""
+		j.status.LastSuccess = started
+		//latest report is published late, older missing reports are retried by regular schedule
+		if s.latestNotAvailable(j.stream, result, started) && retry.Before(next) {
+			next = retry
+		}
+	}
+	if s.Jitter > 0 {
+		next = next.Add(time.Duration(s.random.Int63n(int64(s.Jitter))))
+	}
+	j.status.NextRun = next
+	ingested, empty := 0, 0
+	if result != nil {
+		ingested, empty = len(result.Ingested), len(result.Empty)
+	}
+	fields := []appstore.LogField{
+		{Key: "stream", Value: j.status.Key.String()},
+		{Key: "ingested", Value: ingested},
+		{Key: "not_available", Value: j.status.NotAvailable},
+		{Key: "empty", Value: empty},
+		{Key: "next_run", Value: next},
+	}
+	if err != nil {
+		s.Logger.Log(ctx, appstore.LogLevelError, "sync failed", append(fields, appstore.LogField{Key: "error", Value: err.Error()})...)
+		return
+	}
+	s.Logger.Log(ctx, appstore.LogLevelInfo, "sync finished", fields...)
+}
+
+//latestNotAvailable Check whether latest report date of stream was not available
+func (s *Scheduler) latestNotAvailable(stream *Stream, result *Result, now time.Time) bool {
+	if result == nil || len(result.NotAvailable) == 0 {
+		return false
+	}
+	return result.NotAvailable[len(result.NotAvailable)-1].Equal(stream.latest(now))
+}
+
+//NextPublication Get next time after which new report of stream is expected to be published, without jitter
+func (s *Scheduler) NextPublication(stream *Stream, after time.Time) time.Time {
+	after = after.UTC()
+	if stream.finance() {
+		month := time.Date(after.Year(), after.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+		for {
+			date := FiscalMonthEnd(month).AddDate(0, 0, s.FinanceDelay)
+			date = time.Date(date.Year(), date.Month(), date.Day(), s.PublicationHour, 0, 0, 0, time.UTC)
+			if date.After(after) {
+				return date
+			}
+			month = month.AddDate(0, 1, 0)
+		}
+	}
+	switch appstore.SalesReportFrequency(strings.ToUpper(stream.Frequency)) {
+	case appstore.SalesReportFrequencyWeekly:
+		date := time.Date(after.Year(), after.Month(), after.Day(), s.PublicationHour, 0, 0, 0, time.UTC)
+		date = date.AddDate(0, 0, (int(time.Monday)-int(date.Weekday())+7)%7)
+		if !date.After(after) {
+			date = date.AddDate(0, 0, 7)
+		}
+		return date
+	case appstore.SalesReportFrequencyMonthly, appstore.SalesReportFrequencyYearly:
+		date := time.Date(after.Year(), after.Month(), s.PublicationDay, s.PublicationHour, 0, 0, 0, time.UTC)
+		if !date.After(after) {
+			date = date.AddDate(0, 1, 0)
+		}
+		return date
+	}
+	date := time.Date(after.Year(), after.Month(), after.Day(), s.PublicationHour, 0, 0, 0, time.UTC)
+	if !date.After(after) {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+//Status Get sync status of streams
+func (s *Scheduler) Status() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make([]Status, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		status := j.status
+		status.NotAvailable = append([]string{}, j.status.NotAvailable...)
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+//Running Check whether scheduler is running
+func (s *Scheduler) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+//ServeHTTP implementation of http.Handler, serves /health and /status
+func (s *Scheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/health":
+		status, code := "ok", http.StatusOK
+		if !s.Running() {
+			status, code = "stopped", http.StatusServiceUnavailable
+		}
+		writeJSON(w, code, map[string]string{"status": status})
+	case "/status":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"running": s.Running(), "streams": s.Status()})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+//writeJSON Write JSON response
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,203 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/dgrijalva/jwt-go"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/matisiekpl/appstore-sdk-go/appstoretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type SchedulerTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	dir      string
+	server   *appstoretest.Server
+	now      time.Time
+	testable *Scheduler
+}
+
+func (suite *SchedulerTestSuite) SetupTest() {
+	//tokens are signed and validated by scheduler clock
+	jwt.TimeFunc = func() time.Time {
+		return suite.now
+	}
+	suite.now = time.Date(2020, 5, 8, 10, 0, 0, 0, time.UTC)
+	key, pemKey, _ := appstoretest.GenerateKey()
+	suite.server = appstoretest.NewServer(&key.PublicKey, "12345678")
+	client := appstore.NewClientFromConfig(suite.server.Config(pemKey), nil)
+	assert.NoError(suite.T(), client.Init())
+	suite.ctx = context.Background()
+	suite.dir, _ = ioutil.TempDir("", "scheduler")
+	syncer := NewSyncer(client, NewFileCheckpointStore(filepath.Join(suite.dir, "checkpoints.json")), nil)
+	syncer.now = func() time.Time {
+		return suite.now
+	}
+	suite.testable = NewScheduler(syncer,
+		&Stream{Key: Key{ReportType: "SALES", ReportSubType: "SUMMARY", Version: "1_0"}, Start: time.Date(2020, 5, 6, 0, 0, 0, 0, time.UTC)},
+		&Stream{Key: Key{ReportType: "FINANCIAL", RegionCode: "US"}, Start: time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)},
+	)
+	suite.testable.Jitter = 0
+	suite.testable.now = func() time.Time {
+		return suite.now
+	}
+}
+
+func (suite *SchedulerTestSuite) TearDownTest() {
+	jwt.TimeFunc = time.Now
+	suite.server.Close()
+	_ = os.RemoveAll(suite.dir)
+}
+
+func (suite *SchedulerTestSuite) addSalesReport() {
+	data, _ := ioutil.ReadFile("../stubs/reports/sales/sales.tsv")
+	filter := appstore.NewSalesReportsFilter()
+	filter.SubTypeSummary().Version10().Daily().SetReportDate(time.Date(2020, 5, 6, 0, 0, 0, 0, time.UTC))
+	suite.server.AddSalesReport(filter, data)
+}
+
+func (suite *SchedulerTestSuite) TestTick() {
+	suite.addSalesReport()
+	wake := suite.testable.tick(suite.ctx)
+	statuses := suite.testable.Status()
+	assert.Len(suite.T(), statuses, 2)
+	assert.Equal(suite.T(), "12345678", statuses[0].Key.VendorNumber)
+	assert.Equal(suite.T(), 2, statuses[0].Ingested)
+	assert.Empty(suite.T(), statuses[0].LastError)
+	assert.Equal(suite.T(), suite.now, statuses[0].LastSuccess)
+	assert.Equal(suite.T(), time.Date(2020, 5, 8, 15, 0, 0, 0, time.UTC), statuses[0].NextRun)
+	//financial report of previous month is not published yet, it is retried sooner
+	assert.Equal(suite.T(), []string{"2020-04-01"}, statuses[1].NotAvailable)
+	assert.Equal(suite.T(), suite.now.Add(time.Hour), statuses[1].NextRun)
+	assert.Equal(suite.T(), suite.now.Add(time.Hour), wake)
+
+	//streams which are not due are not synced
+	suite.now = suite.now.Add(time.Hour)
+	suite.testable.tick(suite.ctx)
+	statuses = suite.testable.Status()
+	assert.Equal(suite.T(), suite.now.Add(-time.Hour), statuses[0].LastRun)
+	assert.Equal(suite.T(), suite.now, statuses[1].LastRun)
+}
+
+func (suite *SchedulerTestSuite) TestTickTokenExpired() {
+	suite.addSalesReport()
+	suite.testable.tick(suite.ctx)
+	assert.Empty(suite.T(), suite.testable.Status()[0].LastError)
+
+	//token signed at first sync expired long ago, it is signed again
+	suite.now = suite.now.Add(24 * time.Hour)
+	suite.testable.tick(suite.ctx)
+	status := suite.testable.Status()[0]
+	assert.Empty(suite.T(), status.LastError)
+	assert.Equal(suite.T(), suite.now, status.LastSuccess)
+	assert.Equal(suite.T(), suite.now, suite.testable.Status()[1].LastRun)
+	assert.Empty(suite.T(), suite.testable.Status()[1].LastError)
+}
+
+func (suite *SchedulerTestSuite) TestTickError() {
+	suite.server.SimulateServerError(appstoretest.SalesReportsPath, 1)
+	suite.testable.Jitter = time.Minute
+	suite.testable.tick(suite.ctx)
+	status := suite.testable.Status()[0]
+	assert.NotEmpty(suite.T(), status.LastError)
+	assert.True(suite.T(), status.LastSuccess.IsZero())
+	assert.False(suite.T(), status.NextRun.Before(suite.now.Add(time.Hour)))
+	assert.True(suite.T(), status.NextRun.Before(suite.now.Add(time.Hour+time.Minute)))
+}
+
+func (suite *SchedulerTestSuite) TestTickLogger() {
+	logger := &recordingLogger{}
+	suite.testable.Logger = logger
+	suite.server.SimulateServerError(appstoretest.SalesReportsPath, 1)
+	suite.testable.tick(suite.ctx)
+	assert.Equal(suite.T(), []string{"ERROR sync failed", "INFO sync finished"}, logger.messages)
+	assert.Equal(suite.T(), "stream", logger.fields[0][0].Key)
+	assert.Equal(suite.T(), "error", logger.fields[0][len(logger.fields[0])-1].Key)
+	assert.Equal(suite.T(), []string{"2020-04-01"}, logger.fields[1][2].Value)
+}
+
+func (suite *SchedulerTestSuite) TestNextPublication() {
+	after := time.Date(2020, 5, 8, 16, 0, 0, 0, time.UTC) //Friday
+	daily := &Stream{Key: Key{Frequency: "DAILY"}}
+	assert.Equal(suite.T(), time.Date(2020, 5, 9, 15, 0, 0, 0, time.UTC), suite.testable.NextPublication(daily, after))
+	assert.Equal(suite.T(), time.Date(2020, 5, 8, 15, 0, 0, 0, time.UTC), suite.testable.NextPublication(daily, after.Add(-2*time.Hour)))
+	weekly := &Stream{Key: Key{Frequency: "weekly"}}
+	assert.Equal(suite.T(), time.Date(2020, 5, 11, 15, 0, 0, 0, time.UTC), suite.testable.NextPublication(weekly, after))
+	assert.Equal(suite.T(), time.Date(2020, 5, 18, 15, 0, 0, 0, time.UTC), suite.testable.NextPublication(weekly, time.Date(2020, 5, 11, 15, 0, 0, 0, time.UTC)))
+	monthly := &Stream{Key: Key{Frequency: "MONTHLY"}}
+	assert.Equal(suite.T(), time.Date(2020, 6, 5, 15, 0, 0, 0, time.UTC), suite.testable.NextPublication(monthly, after))
+	finance := &Stream{Key: Key{ReportType: "FINANCE_DETAIL"}}
+	//fiscal April 2020 ends on Saturday May 2, fiscal May on May 30
+	assert.Equal(suite.T(), time.Date(2020, 5, 7, 15, 0, 0, 0, time.UTC), suite.testable.NextPublication(finance, time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(suite.T(), time.Date(2020, 6, 4, 15, 0, 0, 0, time.UTC), suite.testable.NextPublication(finance, time.Date(2020, 5, 7, 15, 0, 0, 0, time.UTC)))
+	//fiscal December 2022 of 53 weeks year ends on December 31
+	assert.Equal(suite.T(), time.Date(2023, 1, 5, 15, 0, 0, 0, time.UTC), suite.testable.NextPublication(finance, time.Date(2022, 12, 29, 0, 0, 0, 0, time.UTC)))
+}
+
+func (suite *SchedulerTestSuite) TestRun() {
+	suite.addSalesReport()
+	ctx, cancel := context.WithCancel(suite.ctx)
+	suite.testable.after = func(d time.Duration) <-chan time.Time {
+		assert.Equal(suite.T(), time.Hour, d)
+		assert.True(suite.T(), suite.testable.Running())
+		cancel()
+		return make(chan time.Time)
+	}
+	assert.NoError(suite.T(), suite.testable.Run(ctx))
+	assert.False(suite.T(), suite.testable.Running())
+	assert.Equal(suite.T(), 2, suite.testable.Status()[0].Ingested)
+}
+
+func (suite *SchedulerTestSuite) TestServeHTTP() {
+	server := httptest.NewServer(suite.testable)
+	defer server.Close()
+	resp, err := http.Get(server.URL + "/health")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, resp.StatusCode)
+	_ = resp.Body.Close()
+	suite.testable.running = true
+	resp, _ = http.Get(server.URL + "/health")
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	suite.testable.tick(suite.ctx)
+	resp, _ = http.Get(server.URL + "/status")
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	assert.Equal(suite.T(), "application/json", resp.Header.Get("Content-Type"))
+	body := struct {
+		Running bool     `json:"running"`
+		Streams []Status `json:"streams"`
+	}{}
+	assert.NoError(suite.T(), json.NewDecoder(resp.Body).Decode(&body))
+	_ = resp.Body.Close()
+	assert.True(suite.T(), body.Running)
+	assert.Len(suite.T(), body.Streams, 2)
+	assert.Equal(suite.T(), "FINANCIAL", body.Streams[1].Key.ReportType)
+
+	resp, _ = http.Get(server.URL + "/foo")
+	assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	_ = resp.Body.Close()
+}
+
+type recordingLogger struct {
+	messages []string
+	fields   [][]appstore.LogField
+}
+
+func (l *recordingLogger) Log(ctx context.Context, level appstore.LogLevel, msg string, fields ...appstore.LogField) {
+	l.messages = append(l.messages, level.String()+" "+msg)
+	l.fields = append(l.fields, fields)
+}
+
+func TestSchedulerTestSuite(t *testing.T) {
+	suite.Run(t, new(SchedulerTestSuite))
+}
@@ -0,0 +1,300 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/matisiekpl/appstore-sdk-go/sqlstore"
+	"net/http"
+	"strings"
+	"time"
+)
+
+//Stream report stream synchronized by syncer, vendor number of key defaults to vendor number of client config
+type Stream struct {
+	Key
+	Start time.Time //first report date to sync
+}
+
+//finance Check whether stream is finances report stream
+func (s *Stream) finance() bool {
+	return s.ReportType == string(appstore.FinancesReportTypeFinancial) || s.ReportType == string(appstore.FinancesReportTypeFinanceDetail)
+}
+
+//latest Get latest report date of stream which may be available at now, financial reports follow Apple's fiscal calendar
+func (s *Stream) latest(now time.Time) time.Time {
+	if s.finance() {
+		return LatestFiscalMonth(now)
+	}
+	return Latest(s.Frequency, now)
+}
+
+//NotAvailableGraceDefault const, age relative to latest report date after which report that is still not published (404)
+//is not expected anymore, e.g. day without sales or month before account had financial reports
+const NotAvailableGraceDefault = 35 * 24 * time.Hour
+
+//Report downloaded report passed to handler
+type Report struct {
+	Key
+	Date time.Time
+	Rows interface{} //slice of report rows pointers, e.g. []*appstore.SalesReport
+}
+
+//Handler Store downloaded report, report date is marked as ingested only when handler succeeds
+type Handler func(ctx context.Context, report *Report) error
+
+//Result result of stream sync
+type Result struct {
+	Key          Key
+	Ingested     []time.Time //report dates downloaded and marked as ingested
+	NotAvailable []time.Time //report dates not available yet, they are retried by next sync
+	Empty        []time.Time //report dates not available past grace period, marked as ingested without handling
+}
+
+//Syncer incremental sync of report streams. Report dates from stream start to latest available report date
+//which are not marked in checkpoint store are downloaded in order and marked after successful handling
+type Syncer struct {
+	Client      *appstore.Client
+	Checkpoints CheckpointStore
+	Handler     Handler
+	//NotAvailableGrace age relative to latest report date within which not available reports are retried, zero retries them forever
+	NotAvailableGrace time.Duration
+	now               func() time.Time
+}
+
+//NewSyncer Create new syncer
+func NewSyncer(client *appstore.Client, checkpoints CheckpointStore, handler Handler) *Syncer {
+	return &Syncer{Client: client, Checkpoints: checkpoints, Handler: handler, NotAvailableGrace: NotAvailableGraceDefault, now: time.Now}
+}
+
+//Sync Download missing reports of stream. Sync stops on first failed report so it is retried by next sync,
+//reports which are not available yet (404) are skipped, reports still not available past grace period are marked as ingested
+func (s *Syncer) Sync(ctx context.Context, stream *Stream) (*Result, error) {
+	key, err := s.key(stream)
+	if err != nil {
+		return nil, fmt.Errorf("Syncer.Sync: %v", err)
+	}
+	result := &Result{Key: key}
+	ingested, err := s.Checkpoints.Dates(ctx, key)
+	if err != nil {
+		return result, fmt.Errorf("Syncer.Sync: %v", err)
+	}
+	now := s.now()
+	latest := stream.latest(now)
+	for _, date := range Missing(stream, ingested, now) {
+		rows, resp, err := fetch(ctx, s.Client, key, date)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			if s.NotAvailableGrace <= 0 || !date.Before(latest.Add(-s.NotAvailableGrace)) {
+				result.NotAvailable = append(result.NotAvailable, date)
+				continue
+			}
+			//reports are not published for periods without data, expired dates are not retried by every sync
+			if err = s.Checkpoints.Mark(ctx, key, date); err != nil {
+				return result, fmt.Errorf("Syncer.Sync: %v", err)
+			}
+			result.Empty = append(result.Empty, date)
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("Syncer.Sync %s %s: %v", key, date.Format(checkpointDateFormat), err)
+		}
+		if s.Handler != nil {
+			if err = s.Handler(ctx, &Report{Key: key, Date: date, Rows: rows}); err != nil {
+				return result, fmt.Errorf("Syncer.Sync %s %s handler: %v", key, date.Format(checkpointDateFormat), err)
+			}
+		}
+		if err = s.Checkpoints.Mark(ctx, key, date); err != nil {
+			return result, fmt.Errorf("Syncer.Sync: %v", err)
+		}
+		result.Ingested = append(result.Ingested, date)
+	}
+	return result, nil
+}
+
+//key Get checkpoint key of stream with defaults
+func (s *Syncer) key(stream *Stream) (Key, error) {
+	key := stream.Key
+	if key.VendorNumber == "" && s.Client != nil && s.Client.Cfg != nil {
+		key.VendorNumber = s.Client.Cfg.VendorNo
+	}
+	if key.VendorNumber == "" {
+		return key, fmt.Errorf("vendor number is required")
+	}
+	if stream.Start.IsZero() {
+		return key, fmt.Errorf("start date is required")
+	}
+	if stream.finance() {
+		key.Frequency = string(appstore.SalesReportFrequencyMonthly)
+		if key.RegionCode == "" {
+			return key, fmt.Errorf("region code is required")
+		}
+		return key, nil
+	}
+	key.Frequency = strings.ToUpper(key.Frequency)
+	if key.Frequency == "" {
+		key.Frequency = string(appstore.SalesReportFrequencyDaily)
+	}
+	switch appstore.SalesReportType(key.ReportType) {
+	case appstore.SalesReportTypeSales, appstore.SalesReportTypePreorder, appstore.SalesReportTypeSubscription,
+		appstore.SalesReportTypeSubscriptionEvent, appstore.SalesReportTypeSubscriber:
+	default:
+		return key, fmt.Errorf("report type %s is not supported", key.ReportType)
+	}
+	if key.ReportSubType == "" {
+		return key, fmt.Errorf("report sub type is required")
+	}
+	return key, nil
+}
+
+//Missing Get report dates of stream from start to latest available at now which are not ingested
+func Missing(stream *Stream, ingested []time.Time, now time.Time) []time.Time {
+	frequency := strings.ToUpper(stream.Frequency)
+	if stream.finance() {
+		frequency = string(appstore.SalesReportFrequencyMonthly)
+	}
+	done := make(map[string]bool, len(ingested))
+	for _, date := range ingested {
+		done[date.Format(checkpointDateFormat)] = true
+	}
+	missing := make([]time.Time, 0)
+	last := stream.latest(now)
+	for date := align(frequency, stream.Start); !date.After(last); date = next(frequency, date) {
+		if !done[date.Format(checkpointDateFormat)] {
+			missing = append(missing, date)
+		}
+	}
+	return missing
+}
+
+//Latest Get latest report date of frequency which may be available at now: yesterday for daily reports,
+//last Sunday before today for weekly reports, previous month or year for monthly and yearly reports.
+//Financial reports follow Apple's fiscal months, see LatestFiscalMonth
+func Latest(frequency string, now time.Time) time.Time {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch appstore.SalesReportFrequency(strings.ToUpper(frequency)) {
+	case appstore.SalesReportFrequencyWeekly:
+		days := int(today.Weekday())
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, -days)
+	case appstore.SalesReportFrequencyMonthly:
+		return time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+	case appstore.SalesReportFrequencyYearly:
+		return time.Date(now.Year()-1, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return today.AddDate(0, 0, -1)
+}
+
+//align Get first report date of frequency on or after date, weekly reports are dated by Sunday ending the week
+func align(frequency string, date time.Time) time.Time {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	switch appstore.SalesReportFrequency(frequency) {
+	case appstore.SalesReportFrequencyWeekly:
+		return date.AddDate(0, 0, (7-int(date.Weekday()))%7)
+	case appstore.SalesReportFrequencyMonthly:
+		if date.Day() > 1 {
+			return time.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		}
+	case appstore.SalesReportFrequencyYearly:
+		if date.YearDay() > 1 {
+			return time.Date(date.Year()+1, 1, 1, 0, 0, 0, 0, time.UTC)
+		}
+	}
+	return date
+}
+
+//next Get next report date of frequency
+func next(frequency string, date time.Time) time.Time {
+	switch appstore.SalesReportFrequency(frequency) {
+	case appstore.SalesReportFrequencyWeekly:
+		return date.AddDate(0, 0, 7)
+	case appstore.SalesReportFrequencyMonthly:
+		return date.AddDate(0, 1, 0)
+	case appstore.SalesReportFrequencyYearly:
+		return date.AddDate(1, 0, 0)
+	}
+	return date.AddDate(0, 0, 1)
+}
+
+//fetch Download report rows of key and date
+func fetch(ctx context.Context, client *appstore.Client, key Key, date time.Time) (interface{}, *http.Response, error) {
+	if key.ReportType == string(appstore.FinancesReportTypeFinancial) || key.ReportType == string(appstore.FinancesReportTypeFinanceDetail) {
+		filter := &appstore.FinancesReportsFilter{ReportDate: date, ReportType: appstore.FinancesReportType(key.ReportType), RegionCode: key.RegionCode}
+		result, resp, err := client.FinancesReports().GetFinancialReports(ctx, filter)
+		if err != nil {
+			return nil, resp, err
+		}
+		return result.Data, resp, nil
+	}
+	base := appstore.SalesReportsBaseFilter{
+		ReportDate:    date,
+		ReportType:    appstore.SalesReportType(key.ReportType),
+		ReportSubType: appstore.SalesReportSubType(key.ReportSubType),
+		Frequency:     appstore.SalesReportFrequency(key.Frequency),
+		Version:       appstore.SalesReportVersion(key.Version),
+	}
+	resource := client.SalesReports()
+	switch base.ReportType {
+	case appstore.SalesReportTypeSales:
+		result, resp, err := resource.GetSalesReports(ctx, &appstore.SalesReportsFilter{SalesReportsBaseFilter: base})
+		if err != nil {
+			return nil, resp, err
+		}
+		return result.Data, resp, nil
+	case appstore.SalesReportTypePreorder:
+		result, resp, err := resource.GetPreOrdersReports(ctx, &appstore.PreOrdersReportsFilter{SalesReportsBaseFilter: base})
+		if err != nil {
+			return nil, resp, err
+		}
+		return result.Data, resp, nil
+	case appstore.SalesReportTypeSubscription:
+		result, resp, err := resource.GetSubscriptionsReports(ctx, &appstore.SubscriptionsReportsFilter{SalesReportsBaseFilter: base})
+		if err != nil {
+			return nil, resp, err
+		}
+		return result.Data, resp, nil
+	case appstore.SalesReportTypeSubscriptionEvent:
+		result, resp, err := resource.GetSubscriptionsEventsReports(ctx, &appstore.SubscriptionsEventsReportsFilter{SalesReportsBaseFilter: base})
+		if err != nil {
+			return nil, resp, err
+		}
+		return result.Data, resp, nil
+	case appstore.SalesReportTypeSubscriber:
+		result, resp, err := resource.GetSubscribersReports(ctx, &appstore.SubscribersReportsFilter{SalesReportsBaseFilter: base})
+		if err != nil {
+			return nil, resp, err
+		}
+		return result.Data, resp, nil
+	}
+	return nil, nil, fmt.Errorf("report type %s is not supported", key.ReportType)
+}
+
+//Handlers Create handler passing report to every handler in order, stops on first error
+func Handlers(handlers ...Handler) Handler {
+	return func(ctx context.Context, report *Report) error {
+		for _, handler := range handlers {
+			if err := handler(ctx, report); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+//StoreHandler Create handler upserting reports into SQL store
+func StoreHandler(store *sqlstore.Store) Handler {
+	return func(ctx context.Context, report *Report) error {
+		load := &sqlstore.Load{
+			VendorNumber: report.VendorNumber,
+			ReportType:   report.ReportType,
+			Frequency:    report.Frequency,
+			Version:      report.Version,
+			RegionCode:   report.RegionCode,
+			ReportDate:   report.Date,
+		}
+		_, err := store.Upsert(ctx, load, report.Rows)
+		return err
+	}
+}
@@ -0,0 +1,232 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/matisiekpl/appstore-sdk-go/appstoretest"
+	"github.com/matisiekpl/appstore-sdk-go/sqlstore"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type SyncerTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	dir      string
+	server   *appstoretest.Server
+	reports  []*Report
+	testable *Syncer
+}
+
+func (suite *SyncerTestSuite) SetupTest() {
+	key, pemKey, _ := appstoretest.GenerateKey()
+	suite.server = appstoretest.NewServer(&key.PublicKey, "12345678")
+	client := appstore.NewClientFromConfig(suite.server.Config(pemKey), nil)
+	assert.NoError(suite.T(), client.Init())
+	suite.ctx = context.Background()
+	suite.dir, _ = ioutil.TempDir("", "ingest")
+	suite.reports = nil
+	suite.testable = NewSyncer(client, NewFileCheckpointStore(filepath.Join(suite.dir, "checkpoints.json")), func(ctx context.Context, report *Report) error {
+		suite.reports = append(suite.reports, report)
+		return nil
+	})
+	suite.testable.now = func() time.Time {
+		return time.Date(2020, 5, 8, 10, 0, 0, 0, time.UTC)
+	}
+}
+
+func (suite *SyncerTestSuite) TearDownTest() {
+	suite.server.Close()
+	_ = os.RemoveAll(suite.dir)
+}
+
+func (suite *SyncerTestSuite) salesStream() *Stream {
+	return &Stream{
+		Key:   Key{ReportType: "SALES", ReportSubType: "SUMMARY", Version: "1_0"},
+		Start: time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (suite *SyncerTestSuite) addSalesReport(date time.Time) {
+	data, _ := ioutil.ReadFile("../stubs/reports/sales/sales.tsv")
+	filter := appstore.NewSalesReportsFilter()
+	filter.SubTypeSummary().Version10().Daily().SetReportDate(date)
+	suite.server.AddSalesReport(filter, data)
+}
+
+func (suite *SyncerTestSuite) addFinancialReport(date time.Time) {
+	data, _ := ioutil.ReadFile("../stubs/reports/finances/financial.tsv")
+	filter := appstore.NewFinancesReportsFilter().SetReportDate(date).TypeFinancial().SetRegionCode("US")
+	suite.server.AddFinancesReport(filter, data)
+}
+
+func (suite *SyncerTestSuite) TestSync() {
+	suite.addSalesReport(time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC))
+	result, err := suite.testable.Sync(suite.ctx, suite.salesStream())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "12345678", result.Key.VendorNumber)
+	assert.Equal(suite.T(), "DAILY", result.Key.Frequency)
+	assert.Len(suite.T(), result.Ingested, 3)
+	assert.Empty(suite.T(), result.NotAvailable)
+	assert.Len(suite.T(), suite.reports, 3)
+	assert.Equal(suite.T(), time.Date(2020, 5, 7, 0, 0, 0, 0, time.UTC), suite.reports[2].Date)
+	assert.NotEmpty(suite.T(), suite.reports[0].Rows.([]*appstore.SalesReport))
+
+	//nothing is missing until next day
+	result, err = suite.testable.Sync(suite.ctx, suite.salesStream())
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Ingested)
+	suite.testable.now = func() time.Time {
+		return time.Date(2020, 5, 9, 10, 0, 0, 0, time.UTC)
+	}
+	result, err = suite.testable.Sync(suite.ctx, suite.salesStream())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []time.Time{time.Date(2020, 5, 8, 0, 0, 0, 0, time.UTC)}, result.Ingested)
+}
+
+func (suite *SyncerTestSuite) TestSyncNotAvailable() {
+	suite.addFinancialReport(time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC))
+	stream := &Stream{Key: Key{ReportType: "FINANCIAL", RegionCode: "US"}, Start: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)}
+	result, err := suite.testable.Sync(suite.ctx, stream)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "MONTHLY", result.Key.Frequency)
+	assert.Equal(suite.T(), []time.Time{time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)}, result.Ingested)
+	assert.Equal(suite.T(), []time.Time{time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)}, result.NotAvailable)
+
+	//not available reports are retried
+	suite.addFinancialReport(time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC))
+	result, err = suite.testable.Sync(suite.ctx, stream)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []time.Time{time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)}, result.Ingested)
+	assert.Equal(suite.T(), []time.Time{time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)}, result.NotAvailable)
+}
+
+func (suite *SyncerTestSuite) TestSyncNotAvailableExpired() {
+	suite.addFinancialReport(time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC))
+	stream := &Stream{Key: Key{ReportType: "FINANCIAL", RegionCode: "US"}, Start: time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC)}
+	result, err := suite.testable.Sync(suite.ctx, stream)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []time.Time{time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, result.Empty)
+	assert.Equal(suite.T(), []time.Time{time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)}, result.Ingested)
+	assert.Equal(suite.T(), []time.Time{time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)}, result.NotAvailable)
+	assert.Len(suite.T(), suite.reports, 1)
+
+	//expired dates are checkpointed, only recent ones are retried
+	result, err = suite.testable.Sync(suite.ctx, stream)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Empty)
+	assert.Len(suite.T(), result.NotAvailable, 2)
+
+	//without grace period not available reports are retried forever
+	suite.testable.NotAvailableGrace = 0
+	suite.testable.now = func() time.Time {
+		return time.Date(2021, 5, 8, 10, 0, 0, 0, time.UTC)
+	}
+	result, err = suite.testable.Sync(suite.ctx, stream)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Empty)
+	assert.Len(suite.T(), result.NotAvailable, 14)
+}
+
+func (suite *SyncerTestSuite) TestSyncError() {
+	suite.addSalesReport(time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC))
+	suite.server.SimulateServerError(appstoretest.SalesReportsPath, 1)
+	result, err := suite.testable.Sync(suite.ctx, suite.salesStream())
+	assert.Error(suite.T(), err)
+	assert.Empty(suite.T(), result.Ingested)
+	result, err = suite.testable.Sync(suite.ctx, suite.salesStream())
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Ingested, 3)
+}
+
+func (suite *SyncerTestSuite) TestSyncHandlerError() {
+	suite.addSalesReport(time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC))
+	suite.testable.Handler = func(ctx context.Context, report *Report) error {
+		return errors.New("foo")
+	}
+	result, err := suite.testable.Sync(suite.ctx, suite.salesStream())
+	assert.Error(suite.T(), err)
+	assert.Empty(suite.T(), result.Ingested)
+	dates, _ := suite.testable.Checkpoints.Dates(suite.ctx, result.Key)
+	assert.Empty(suite.T(), dates)
+}
+
+func (suite *SyncerTestSuite) TestSyncInvalid() {
+	stream := suite.salesStream()
+	stream.Start = time.Time{}
+	_, err := suite.testable.Sync(suite.ctx, stream)
+	assert.Error(suite.T(), err)
+	stream = suite.salesStream()
+	stream.ReportType = "NEWSSTAND"
+	_, err = suite.testable.Sync(suite.ctx, stream)
+	assert.Error(suite.T(), err)
+	stream = suite.salesStream()
+	stream.ReportSubType = ""
+	_, err = suite.testable.Sync(suite.ctx, stream)
+	assert.Error(suite.T(), err)
+	_, err = suite.testable.Sync(suite.ctx, &Stream{Key: Key{ReportType: "FINANCIAL"}, Start: stream.Start})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SyncerTestSuite) TestSyncStoreHandler() {
+	db, _ := sql.Open("sqlite3", ":memory:")
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	store := sqlstore.NewStore(db, sqlstore.DialectSQLite)
+	assert.NoError(suite.T(), store.Migrate(suite.ctx))
+	checkpoints := NewSQLCheckpointStore(db, sqlstore.DialectSQLite)
+	assert.NoError(suite.T(), checkpoints.Migrate(suite.ctx))
+	suite.testable.Checkpoints = checkpoints
+	suite.testable.Handler = StoreHandler(store)
+	suite.addSalesReport(time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC))
+	result, err := suite.testable.Sync(suite.ctx, suite.salesStream())
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Ingested, 3)
+	ingestions, err := store.Ingestions(suite.ctx, "12345678")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), ingestions, 3)
+	assert.Equal(suite.T(), "1_0", ingestions[0].Version)
+}
+
+func (suite *SyncerTestSuite) TestMissing() {
+	now := time.Date(2020, 5, 8, 10, 0, 0, 0, time.UTC)
+	stream := suite.salesStream()
+	missing := Missing(stream, []time.Time{time.Date(2020, 5, 6, 0, 0, 0, 0, time.UTC)}, now)
+	assert.Equal(suite.T(), []time.Time{time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC), time.Date(2020, 5, 7, 0, 0, 0, 0, time.UTC)}, missing)
+	stream.Frequency = "WEEKLY"
+	stream.Start = time.Date(2020, 4, 20, 0, 0, 0, 0, time.UTC)
+	missing = Missing(stream, nil, now)
+	assert.Equal(suite.T(), []time.Time{time.Date(2020, 4, 26, 0, 0, 0, 0, time.UTC), time.Date(2020, 5, 3, 0, 0, 0, 0, time.UTC)}, missing)
+	stream.Frequency = "MONTHLY"
+	stream.Start = time.Date(2020, 2, 15, 0, 0, 0, 0, time.UTC)
+	missing = Missing(stream, nil, now)
+	assert.Equal(suite.T(), []time.Time{time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)}, missing)
+	stream.Start = now
+	assert.Empty(suite.T(), Missing(stream, nil, now))
+
+	//fiscal April 2020 ends on May 2, fiscal month which has not ended is not missing
+	finance := &Stream{Key: Key{ReportType: "FINANCIAL", RegionCode: "US"}, Start: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)}
+	assert.Equal(suite.T(), []time.Time{time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)}, Missing(finance, nil, time.Date(2020, 5, 2, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(suite.T(), []time.Time{time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)}, Missing(finance, nil, now))
+}
+
+func (suite *SyncerTestSuite) TestLatest() {
+	now := time.Date(2020, 5, 10, 10, 0, 0, 0, time.UTC) //Sunday
+	assert.Equal(suite.T(), time.Date(2020, 5, 9, 0, 0, 0, 0, time.UTC), Latest("DAILY", now))
+	assert.Equal(suite.T(), time.Date(2020, 5, 3, 0, 0, 0, 0, time.UTC), Latest("weekly", now))
+	assert.Equal(suite.T(), time.Date(2020, 5, 10, 0, 0, 0, 0, time.UTC), Latest("WEEKLY", now.AddDate(0, 0, 1)))
+	assert.Equal(suite.T(), time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), Latest("MONTHLY", now))
+	assert.Equal(suite.T(), time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), Latest("YEARLY", now))
+}
+
+func TestSyncerTestSuite(t *testing.T) {
+	suite.Run(t, new(SyncerTestSuite))
+}
@@ -0,0 +1,451 @@
+package appstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+//ResourceLimitMax const, max number of resources per page
+const ResourceLimitMax = 200
+
+//ResourceLinks Self-links to requested resource
+type ResourceLinks struct {
+	Self string `json:"self,omitempty"`
+}
+
+//RelationshipLinks Links to related resources and to relationship itself
+type RelationshipLinks struct {
+	Self    string `json:"self,omitempty"`
+	Related string `json:"related,omitempty"`
+}
+
+//DocumentLinks Links related to response document, including paging links
+type DocumentLinks struct {
+	Self  string `json:"self,omitempty"`
+	First string `json:"first,omitempty"`
+	Next  string `json:"next,omitempty"` //empty on last page
+}
+
+//PagingInformation Paging details such as the total number of resources and the per-page limit
+type PagingInformation struct {
+	Total int `json:"total"`
+	Limit int `json:"limit"`
+}
+
+//DocumentMeta Metadata of response document or relationship
+type DocumentMeta struct {
+	Paging *PagingInformation `json:"paging,omitempty"`
+}
+
+//ResourceIdentifier Type and id of related resource
+type ResourceIdentifier struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+//Relationship Resource relationship, data holds one identifier for to-one and many for to-many relationships
+type Relationship struct {
+	Data  []*ResourceIdentifier `json:"-"`
+	Many  bool                  `json:"-"` //true for to-many relationship
+	Links *RelationshipLinks    `json:"links,omitempty"`
+	Meta  *DocumentMeta         `json:"meta,omitempty"`
+}
+
+//Identifier Get identifier of to-one relationship, nil when data is null or not included
+func (r *Relationship) Identifier() *ResourceIdentifier {
+	if r == nil || len(r.Data) == 0 {
+		return nil
+	}
+	return r.Data[0]
+}
+
+//MarshalJSON Relationship MarshalJSON
+func (r *Relationship) MarshalJSON() ([]byte, error) {
+	type relationship Relationship
+	raw := struct {
+		Data interface{} `json:"data,omitempty"`
+		*relationship
+	}{relationship: (*relationship)(r)}
+	if r.Many {
+		raw.Data = r.Data
+	} else if len(r.Data) > 0 {
+		raw.Data = r.Data[0]
+	}
+	return json.Marshal(raw)
+}
+
+//UnmarshalJSON Relationship UnmarshalJSON, data may be object, array or null
+func (r *Relationship) UnmarshalJSON(data []byte) error {
+	type relationship Relationship
+	raw := struct {
+		Data json.RawMessage `json:"data"`
+		*relationship
+	}{relationship: (*relationship)(r)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return errors.New("Relationship.UnmarshalJSON: " + err.Error())
+	}
+	r.Data = nil
+	r.Many = false
+	value := bytes.TrimSpace(raw.Data)
+	switch {
+	case len(value) == 0 || isJSONNull(value):
+		return nil
+	case value[0] == '[':
+		r.Many = true
+		r.Data = make([]*ResourceIdentifier, 0)
+		if err := json.Unmarshal(value, &r.Data); err != nil {
+			return errors.New("Relationship.UnmarshalJSON: " + err.Error())
+		}
+	default:
+		identifier := &ResourceIdentifier{}
+		if err := json.Unmarshal(value, identifier); err != nil {
+			return errors.New("Relationship.UnmarshalJSON: " + err.Error())
+		}
+		r.Data = []*ResourceIdentifier{identifier}
+	}
+	return nil
+}
+
+//NewToOneRelationship Create to-one relationship of request body
+func NewToOneRelationship(resourceType string, id string) *Relationship {
+	return &Relationship{Data: []*ResourceIdentifier{{Type: resourceType, Id: id}}}
+}
+
+//NewToManyRelationship Create to-many relationship of request body
+func NewToManyRelationship(resourceType string, ids ...string) *Relationship {
+	data := make([]*ResourceIdentifier, 0, len(ids))
+	for _, id := range ids {
+		data = append(data, &ResourceIdentifier{Type: resourceType, Id: id})
+	}
+	return &Relationship{Data: data, Many: true}
+}
+
+//NewResourceRequestBody Create JSON:API request body of resource, id is empty when resource is created
+func NewResourceRequestBody(resourceType string, id string, attributes map[string]interface{}, relationships map[string]*Relationship) map[string]interface{} {
+	data := map[string]interface{}{"type": resourceType}
+	if id != "" {
+		data["id"] = id
+	}
+	if len(attributes) > 0 {
+		data["attributes"] = attributes
+	}
+	if len(relationships) > 0 {
+		data["relationships"] = relationships
+	}
+	return map[string]interface{}{"data": data}
+}
+
+//Resource Untyped resource object, e.g. included resource of any type
+type Resource struct {
+	Type          string                   `json:"type"`
+	Id            string                   `json:"id"`
+	Attributes    json.RawMessage          `json:"attributes,omitempty"`
+	Relationships map[string]*Relationship `json:"relationships,omitempty"`
+	Links         *ResourceLinks           `json:"links,omitempty"`
+}
+
+//DecodeAttributes Unmarshal resource attributes into typed attributes struct
+func (r *Resource) DecodeAttributes(v interface{}) error {
+	if len(r.Attributes) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(r.Attributes, v); err != nil {
+		return fmt.Errorf("Resource.DecodeAttributes %s %s: %v", r.Type, r.Id, err)
+	}
+	return nil
+}
+
+//Decode Unmarshal whole resource object into typed resource struct
+func (r *Resource) Decode(v interface{}) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("Resource.Decode %s %s: %v", r.Type, r.Id, err)
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("Resource.Decode %s %s: %v", r.Type, r.Id, err)
+	}
+	return nil
+}
+
+//Document JSON:API response document, typed responses embed it next to their data
+type Document struct {
+	ResponseBody
+	Included []*Resource    `json:"included,omitempty"`
+	Links    *DocumentLinks `json:"links,omitempty"`
+	Meta     *DocumentMeta  `json:"meta,omitempty"`
+}
+
+//getDocument method
+func (d *Document) getDocument() *Document {
+	return d
+}
+
+//Total Get total number of resources of collection, 0 when document has no paging information
+func (d *Document) Total() int {
+	if d.Meta == nil || d.Meta.Paging == nil {
+		return 0
+	}
+	return d.Meta.Paging.Total
+}
+
+//NextPage Get url of next page, empty on last page
+func (d *Document) NextPage() string {
+	if d.Links == nil {
+		return ""
+	}
+	return d.Links.Next
+}
+
+//FindIncluded Find included resource by identifier
+func (d *Document) FindIncluded(identifier *ResourceIdentifier) *Resource {
+	if identifier == nil {
+		return nil
+	}
+	for _, resource := range d.Included {
+		if resource.Type == identifier.Type && resource.Id == identifier.Id {
+			return resource
+		}
+	}
+	return nil
+}
+
+//FindIncludedRelated Find included resources of relationship
+func (d *Document) FindIncludedRelated(relationship *Relationship) []*Resource {
+	resources := make([]*Resource, 0)
+	if relationship == nil {
+		return resources
+	}
+	for _, identifier := range relationship.Data {
+		if resource := d.FindIncluded(identifier); resource != nil {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+//DecodeIncluded Decode included resources of relationship into slice of typed resources, e.g. *[]*AppInfo
+func (d *Document) DecodeIncluded(relationship *Relationship, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice || rv.Elem().Type().Elem().Kind() != reflect.Ptr {
+		return fmt.Errorf("Document.DecodeIncluded: %T is not pointer to slice of pointers", v)
+	}
+	slice := reflect.MakeSlice(rv.Elem().Type(), 0, 0)
+	for _, resource := range d.FindIncludedRelated(relationship) {
+		item := reflect.New(rv.Elem().Type().Elem().Elem())
+		if err := resource.Decode(item.Interface()); err != nil {
+			return fmt.Errorf("Document.DecodeIncluded: %v", err)
+		}
+		slice = reflect.Append(slice, item)
+	}
+	rv.Elem().Set(slice)
+	return nil
+}
+
+//statusHolder response with http status
+type statusHolder interface {
+	setStatus(status int)
+	IsSuccess() bool
+	GetError() string
+}
+
+//documentHolder typed response embedding JSON:API document
+type documentHolder interface {
+	statusHolder
+	getDocument() *Document
+}
+
+//ResourceQuery JSON:API query params: included relationships, sparse fieldsets, limits, sort and filters
+type ResourceQuery struct {
+	include []string
+	fields  map[string][]string
+	limits  map[string]int
+	sort    []string
+	filters map[string][]string
+}
+
+//Include Include related resources in response
+func (q *ResourceQuery) Include(relationships ...string) *ResourceQuery {
+	q.include = append(q.include, relationships...)
+	return q
+}
+
+//Fields Return only given fields of resource type, e.g. Fields("apps", "name", "bundleId")
+func (q *ResourceQuery) Fields(resourceType string, fields ...string) *ResourceQuery {
+	q.fields[resourceType] = append(q.fields[resourceType], fields...)
+	return q
+}
+
+//Limit Set number of resources per page
+func (q *ResourceQuery) Limit(value int) *ResourceQuery {
+	q.limits[""] = value
+	return q
+}
+
+//LimitIncluded Set number of included resources of relationship
+func (q *ResourceQuery) LimitIncluded(relationship string, value int) *ResourceQuery {
+	q.limits[relationship] = value
+	return q
+}
+
+//Sort Sort resources by fields, prefix field with - for descending order
+func (q *ResourceQuery) Sort(fields ...string) *ResourceQuery {
+	q.sort = append(q.sort, fields...)
+	return q
+}
+
+//Filter Filter resources by field matching any of values
+func (q *ResourceQuery) Filter(field string, values ...string) *ResourceQuery {
+	q.filters[field] = append(q.filters[field], values...)
+	return q
+}
+
+//IsValid Validate query params
+func (q *ResourceQuery) IsValid() error {
+	for relationship, limit := range q.limits {
+		if limit < 0 || limit > ResourceLimitMax {
+			name := "limit"
+			if relationship != "" {
+				name = "limit[" + relationship + "]"
+			}
+			return fmt.Errorf("ResourceQuery.IsValid: %s must be between 1 and %d", name, ResourceLimitMax)
+		}
+	}
+	for field, values := range q.filters {
+		if len(values) == 0 {
+			return fmt.Errorf("ResourceQuery.IsValid: filter[%s] has no values", field)
+		}
+	}
+	return nil
+}
+
+//toQueryParamsMap Convert query to query params
+func (q *ResourceQuery) toQueryParamsMap() map[string]interface{} {
+	qs := make(map[string]interface{})
+	if len(q.include) > 0 {
+		qs["include"] = strings.Join(q.include, ",")
+	}
+	for resourceType, fields := range q.fields {
+		qs["fields["+resourceType+"]"] = strings.Join(fields, ",")
+	}
+	for relationship, limit := range q.limits {
+		if limit <= 0 {
+			continue
+		}
+		if relationship == "" {
+			qs["limit"] = limit
+		} else {
+			qs["limit["+relationship+"]"] = limit
+		}
+	}
+	if len(q.sort) > 0 {
+		qs["sort"] = strings.Join(q.sort, ",")
+	}
+	for field, values := range q.filters {
+		qs["filter["+field+"]"] = strings.Join(values, ",")
+	}
+	return qs
+}
+
+//merge Merge query params of query into params, query takes precedence
+func (q *ResourceQuery) merge(params map[string]interface{}) map[string]interface{} {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	if q == nil {
+		return params
+	}
+	for k, v := range q.toQueryParamsMap() {
+		params[k] = v
+	}
+	return params
+}
+
+//NewResourceQuery Create new JSON:API query
+func NewResourceQuery() *ResourceQuery {
+	return &ResourceQuery{fields: make(map[string][]string), limits: make(map[string]int), filters: make(map[string][]string)}
+}
+
+//PageIterator Iterate pages of resources collection following links.next cursor
+type PageIterator struct {
+	resource ResourceAbstract
+	path     string
+	query    map[string]interface{}
+	next     string
+	started  bool
+	err      error
+	resp     *http.Response
+}
+
+//Next Fetch next page into typed collection response embedding Document.
+//Returns false after last page or on error, check Err afterwards
+func (it *PageIterator) Next(ctx context.Context, page documentHolder) bool {
+	if it.err != nil || (it.started && it.next == "") {
+		return false
+	}
+	path, query := it.path, it.query
+	if it.started {
+		u, err := url.Parse(it.next)
+		if err != nil {
+			it.err = fmt.Errorf("PageIterator.Next parse next link: %v", err)
+			return false
+		}
+		path, query = strings.TrimPrefix(u.Path, "/"), make(map[string]interface{})
+		for k, values := range u.Query() {
+			query[k] = strings.Join(values, ",")
+		}
+	}
+	it.started = true
+	//reset page, decoding into previous page would reuse its resources
+	v := reflect.ValueOf(page).Elem()
+	v.Set(reflect.Zero(v.Type()))
+	it.resp, it.err = it.resource.send(ctx, http.MethodGet, path, query, nil, page)
+	if it.err != nil {
+		it.err = fmt.Errorf("PageIterator.Next error: %v", it.err)
+		return false
+	}
+	it.next = page.getDocument().NextPage()
+	return true
+}
+
+//Err Get error of last fetched page
+func (it *PageIterator) Err() error {
+	return it.err
+}
+
+//Response Get raw response of last fetched page
+func (it *PageIterator) Response() *http.Response {
+	return it.resp
+}
+
+//newPageIterator Create iterator of collection path with query params of first page
+func (ra *ResourceAbstract) newPageIterator(path string, query map[string]interface{}) *PageIterator {
+	return &PageIterator{resource: *ra, path: path, query: query}
+}
+
+//send Send request and unmarshal JSON response into result, returns error of unsuccessful response
+func (ra *ResourceAbstract) send(ctx context.Context, method string, path string, query map[string]interface{}, body map[string]interface{}, result statusHolder) (*http.Response, error) {
+	resp, err := ra.transport.SendRequest(ctx, method, path, query, body)
+	if err != nil {
+		return nil, err
+	}
+	result.setStatus(resp.StatusCode)
+	//deleted and some updated resources respond without body
+	if resp.StatusCode == http.StatusNoContent {
+		_ = resp.Body.Close()
+		return resp, nil
+	}
+	if err = ra.unmarshalResponse(resp, result, false); err != nil {
+		return resp, err
+	}
+	if !result.IsSuccess() {
+		return resp, errors.New(result.GetError())
+	}
+	return resp, nil
+}
@@ -0,0 +1,211 @@
+package appstore
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+type stubApp struct {
+	Type          string                   `json:"type"`
+	Id            string                   `json:"id"`
+	Attributes    *stubAppAttributes       `json:"attributes"`
+	Relationships map[string]*Relationship `json:"relationships"`
+}
+
+type stubAppAttributes struct {
+	Name     string `json:"name"`
+	BundleId string `json:"bundleId"`
+}
+
+type stubAppResponse struct {
+	Document
+	Data *stubApp `json:"data"`
+}
+
+type stubAppsResponse struct {
+	Document
+	Data []*stubApp `json:"data"`
+}
+
+type JsonApiTestSuite struct {
+	suite.Suite
+	document *stubAppResponse
+}
+
+func (suite *JsonApiTestSuite) SetupTest() {
+	data, _ := ioutil.ReadFile("stubs/jsonapi/document.json")
+	suite.document = &stubAppResponse{}
+	assert.NoError(suite.T(), json.Unmarshal(data, suite.document))
+}
+
+func (suite *JsonApiTestSuite) TestRelationshipUnmarshalJson() {
+	relationships := suite.document.Data.Relationships
+	assert.True(suite.T(), relationships["appInfos"].Many)
+	assert.Equal(suite.T(), []*ResourceIdentifier{{Type: "appInfos", Id: "info-1"}}, relationships["appInfos"].Data)
+	assert.Equal(suite.T(), 1, relationships["appInfos"].Meta.Paging.Total)
+	assert.Equal(suite.T(), "https://api.appstoreconnect.apple.com/v1/apps/1234567890/appInfos", relationships["appInfos"].Links.Related)
+	assert.Nil(suite.T(), relationships["endUserLicenseAgreement"].Identifier())
+	assert.False(suite.T(), relationships["endUserLicenseAgreement"].Many)
+	assert.Empty(suite.T(), relationships["preReleaseVersions"].Data)
+	assert.NotEmpty(suite.T(), relationships["preReleaseVersions"].Links.Related)
+	app := suite.document.Included[0].Relationships["app"]
+	assert.False(suite.T(), app.Many)
+	assert.Equal(suite.T(), &ResourceIdentifier{Type: "apps", Id: "1234567890"}, app.Identifier())
+	assert.Error(suite.T(), json.Unmarshal([]byte(`{"data":"foo"}`), &Relationship{}))
+}
+
+func (suite *JsonApiTestSuite) TestRelationshipMarshalJson() {
+	data, _ := json.Marshal(suite.document.Included[0].Relationships["app"])
+	assert.Equal(suite.T(), `{"data":{"type":"apps","id":"1234567890"}}`, string(data))
+	data, _ = json.Marshal(&Relationship{Many: true, Data: []*ResourceIdentifier{}})
+	assert.Equal(suite.T(), `{"data":[]}`, string(data))
+}
+
+func (suite *JsonApiTestSuite) TestNewResourceRequestBody() {
+	body := NewResourceRequestBody("appInfoLocalizations", "", map[string]interface{}{"locale": "en-US", "name": "Foo"}, map[string]*Relationship{
+		"appInfo": NewToOneRelationship("appInfos", "info-1"),
+		"builds":  NewToManyRelationship("builds", "1", "2"),
+	})
+	data, _ := json.Marshal(body)
+	expected := `{"data":{"attributes":{"locale":"en-US","name":"Foo"},"relationships":{"appInfo":{"data":{"type":"appInfos","id":"info-1"}},"builds":{"data":[{"type":"builds","id":"1"},{"type":"builds","id":"2"}]}},"type":"appInfoLocalizations"}}`
+	assert.Equal(suite.T(), expected, string(data))
+	data, _ = json.Marshal(NewResourceRequestBody("apps", "123", nil, nil))
+	assert.Equal(suite.T(), `{"data":{"id":"123","type":"apps"}}`, string(data))
+}
+
+func (suite *JsonApiTestSuite) TestResourceDecode() {
+	included := suite.document.Included[0]
+	attributes := struct {
+		AppStoreState string `json:"appStoreState"`
+	}{}
+	assert.NoError(suite.T(), included.DecodeAttributes(&attributes))
+	assert.Equal(suite.T(), "READY_FOR_SALE", attributes.AppStoreState)
+	app := &stubApp{}
+	assert.NoError(suite.T(), included.Decode(app))
+	assert.Equal(suite.T(), "info-1", app.Id)
+	assert.Equal(suite.T(), "1234567890", app.Relationships["app"].Identifier().Id)
+	assert.Error(suite.T(), (&Resource{Attributes: json.RawMessage(`[]`)}).DecodeAttributes(&attributes))
+}
+
+func (suite *JsonApiTestSuite) TestDocument() {
+	assert.Equal(suite.T(), "FooBarApp", suite.document.Data.Attributes.Name)
+	assert.Equal(suite.T(), 0, suite.document.Total())
+	assert.Empty(suite.T(), suite.document.NextPage())
+	assert.Equal(suite.T(), suite.document.Included[0], suite.document.FindIncluded(&ResourceIdentifier{Type: "appInfos", Id: "info-1"}))
+	assert.Nil(suite.T(), suite.document.FindIncluded(&ResourceIdentifier{Type: "apps", Id: "info-1"}))
+	assert.Len(suite.T(), suite.document.FindIncludedRelated(suite.document.Data.Relationships["appInfos"]), 1)
+	assert.Empty(suite.T(), suite.document.FindIncludedRelated(nil))
+}
+
+func (suite *JsonApiTestSuite) TestDecodeIncluded() {
+	apps := make([]*stubApp, 0)
+	assert.NoError(suite.T(), suite.document.DecodeIncluded(suite.document.Data.Relationships["appInfos"], &apps))
+	assert.Len(suite.T(), apps, 1)
+	assert.Equal(suite.T(), "info-1", apps[0].Id)
+	assert.NoError(suite.T(), suite.document.DecodeIncluded(nil, &apps))
+	assert.Empty(suite.T(), apps)
+	assert.Error(suite.T(), suite.document.DecodeIncluded(nil, apps))
+	assert.Error(suite.T(), suite.document.DecodeIncluded(nil, &[]stubApp{}))
+}
+
+func (suite *JsonApiTestSuite) TestResourceQuery() {
+	query := NewResourceQuery().Include("appInfos", "appStoreVersions").Fields("apps", "name", "bundleId").
+		Limit(50).LimitIncluded("appStoreVersions", 5).Sort("name", "-bundleId").Filter("bundleId", "foo", "bar")
+	assert.NoError(suite.T(), query.IsValid())
+	expected := map[string]interface{}{
+		"include":                 "appInfos,appStoreVersions",
+		"fields[apps]":            "name,bundleId",
+		"limit":                   50,
+		"limit[appStoreVersions]": 5,
+		"sort":                    "name,-bundleId",
+		"filter[bundleId]":        "foo,bar",
+	}
+	assert.Equal(suite.T(), expected, query.toQueryParamsMap())
+	params := query.merge(map[string]interface{}{"limit": 10, "foo": "bar"})
+	assert.Equal(suite.T(), 50, params["limit"])
+	assert.Equal(suite.T(), "bar", params["foo"])
+	var empty *ResourceQuery
+	assert.Empty(suite.T(), empty.merge(nil))
+	assert.Equal(suite.T(), "ResourceQuery.IsValid: limit[appStoreVersions] must be between 1 and 200", query.LimitIncluded("appStoreVersions", 201).IsValid().Error())
+	assert.Equal(suite.T(), "ResourceQuery.IsValid: filter[sku] has no values", NewResourceQuery().Filter("sku").IsValid().Error())
+}
+
+func TestJsonApiTestSuite(t *testing.T) {
+	suite.Run(t, new(JsonApiTestSuite))
+}
+
+type PageIteratorTestSuite struct {
+	suite.Suite
+	cfg      *Config
+	ctx      context.Context
+	testable *ResourceAbstract
+}
+
+func (suite *PageIteratorTestSuite) SetupTest() {
+	suite.cfg = buildStubConfig()
+	suite.ctx = context.Background()
+	resource := newResourceAbstract(buildStubHttpTransport(), suite.cfg)
+	suite.testable = &resource
+	httpmock.Activate()
+}
+
+func (suite *PageIteratorTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+}
+
+func (suite *PageIteratorTestSuite) register(query string, status int, stub string) {
+	resp := buildStubResponseFromFile(status, stub)
+	resp.Header.Set("Content-Type", ResponseContentTypeJson)
+	httpmock.RegisterResponderWithQuery("GET", suite.cfg.Uri+"/v1/apps", query, httpmock.ResponderFromResponse(resp))
+}
+
+func (suite *PageIteratorTestSuite) TestNext() {
+	suite.register("limit=2", http.StatusOK, "stubs/jsonapi/page.json")
+	suite.register("cursor=Ag.AKM&limit=2", http.StatusOK, "stubs/jsonapi/page_last.json")
+	iterator := suite.testable.newPageIterator("v1/apps", map[string]interface{}{"limit": 2})
+	apps := make([]*stubApp, 0)
+	page := &stubAppsResponse{}
+	totals := make([]int, 0)
+	for iterator.Next(suite.ctx, page) {
+		apps = append(apps, page.Data...)
+		totals = append(totals, page.Total())
+	}
+	assert.NoError(suite.T(), iterator.Err())
+	assert.Equal(suite.T(), http.StatusOK, iterator.Response().StatusCode)
+	assert.Len(suite.T(), apps, 3)
+	assert.Equal(suite.T(), "1234567890", apps[0].Id)
+	assert.Equal(suite.T(), "1234567891", apps[1].Id)
+	assert.Equal(suite.T(), "1234567892", apps[2].Id)
+	assert.Equal(suite.T(), []int{3, 3}, totals)
+	assert.False(suite.T(), iterator.Next(suite.ctx, page))
+}
+
+func (suite *PageIteratorTestSuite) TestNextError() {
+	suite.register("", http.StatusBadRequest, "stubs/errors/invalid.parameter.json")
+	iterator := suite.testable.newPageIterator("v1/apps", nil)
+	page := &stubAppsResponse{}
+	assert.False(suite.T(), iterator.Next(suite.ctx, page))
+	assert.Error(suite.T(), iterator.Err())
+	assert.Equal(suite.T(), "PageIterator.Next error: "+page.GetError(), iterator.Err().Error())
+	assert.Equal(suite.T(), http.StatusBadRequest, iterator.Response().StatusCode)
+	assert.False(suite.T(), iterator.Next(suite.ctx, page))
+}
+
+func (suite *PageIteratorTestSuite) TestSendNoContent() {
+	httpmock.RegisterResponder("DELETE", suite.cfg.Uri+"/v1/apps/1234567890", httpmock.NewStringResponder(http.StatusNoContent, ""))
+	result := &ResponseBody{}
+	resp, err := suite.testable.send(suite.ctx, http.MethodDelete, "v1/apps/1234567890", nil, nil, result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNoContent, resp.StatusCode)
+	assert.True(suite.T(), result.IsSuccess())
+}
+
+func TestPageIteratorTestSuite(t *testing.T) {
+	suite.Run(t, new(PageIteratorTestSuite))
+}
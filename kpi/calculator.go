@@ -0,0 +1,227 @@
+package kpi
+
+import (
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"sort"
+	"strings"
+	"time"
+)
+
+//Calculator subscription KPIs calculator over time series of subscriptions, subscriptions events and subscribers reports.
+//Amounts of key must be in one currency, convert reports with appstore.CurrencyConverter or group by territory
+type Calculator struct {
+	dimensions map[Dimension]bool
+	rows       map[Key]*Row
+	snapshots  map[time.Time]bool
+}
+
+//AddSubscriptions Add subscriptions report rows of date, report rows have no date column
+func (c *Calculator) AddSubscriptions(date time.Time, reports []*appstore.SubscriptionsReport) error {
+	date = day(date)
+	c.snapshots[date] = true
+	for i, report := range reports {
+		if report == nil {
+			continue
+		}
+		row := c.row(date, report.AppAppleID.Value(), report.SubscriptionAppleID.Value(), report.Country, report.AppName, report.SubscriptionName)
+		paid := report.ActiveStandardPriceSubscriptions.Value() +
+			report.ActivePayUpFrontIntroductoryOfferSubscriptions.Value() +
+			report.ActivePayAsYouGoIntroductoryOfferSubscriptions.Value() +
+			report.PayUpFrontPromotionalOfferSubscriptions.Value() +
+			report.PayAsYouGoPromotionalOfferSubscriptions.Value() +
+			report.PayUpFrontOfferCodeSubscriptions.Value() +
+			report.PayAsYouGoOfferCodeSubscriptions.Value()
+		trial := report.ActiveFreeTrialIntroductoryOfferSubscriptions.Value() +
+			report.FreeTrialPromotionalOfferSubscriptions.Value() +
+			report.FreeTrialOfferCodeSubscriptions.Value()
+		row.PaidSubscriptions += paid
+		row.TrialSubscriptions += trial
+		row.ActiveSubscriptions += paid + trial
+		row.BillingRetry += report.BillingRetry.Value()
+		row.GracePeriod += report.GracePeriod.Value()
+		if paid == 0 {
+			continue
+		}
+		mrr, err := monthly(report.DeveloperProceeds, paid, report.StandardSubscriptionDuration)
+		if err != nil {
+			return fmt.Errorf("Calculator.AddSubscriptions row %d: %v", i, err)
+		}
+		if row.MRR, err = row.MRR.Add(mrr); err != nil {
+			return fmt.Errorf("Calculator.AddSubscriptions row %d: MRR %v", i, err)
+		}
+		gross, err := monthly(report.CustomerPrice, paid, report.StandardSubscriptionDuration)
+		if err != nil {
+			return fmt.Errorf("Calculator.AddSubscriptions row %d: %v", i, err)
+		}
+		if row.GrossMRR, err = row.GrossMRR.Add(gross); err != nil {
+			return fmt.Errorf("Calculator.AddSubscriptions row %d: GrossMRR %v", i, err)
+		}
+	}
+	return nil
+}
+
+//AddEvents Add subscriptions events report rows
+func (c *Calculator) AddEvents(reports []*appstore.SubscriptionsEventsReport) error {
+	for i, report := range reports {
+		if report == nil {
+			continue
+		}
+		if report.EventDate.Value().IsZero() {
+			return fmt.Errorf("Calculator.AddEvents row %d: blank event date", i)
+		}
+		row := c.row(report.EventDate.Value(), report.AppAppleID.Value(), report.SubscriptionAppleID.Value(), report.Country, report.AppName, report.SubscriptionName)
+		quantity := report.Quantity.Value()
+		switch {
+		case isTrialStart(report.Event):
+			row.TrialStarts += quantity
+		case report.Event.IsConversion():
+			row.TrialConversions += quantity
+		case report.Event.IsChurn():
+			row.Churned += quantity
+		case report.Event.IsRefund():
+			row.Refunds += quantity
+		}
+	}
+	return nil
+}
+
+//AddSubscribers Add subscribers report rows
+func (c *Calculator) AddSubscribers(reports []*appstore.SubscribersReport) error {
+	for i, report := range reports {
+		if report == nil {
+			continue
+		}
+		if report.EventDate.Value().IsZero() {
+			return fmt.Errorf("Calculator.AddSubscribers row %d: blank event date", i)
+		}
+		row := c.row(report.EventDate.Value(), report.AppAppleID.Value(), report.SubscriptionAppleID.Value(), report.Country, report.AppName, report.SubscriptionName)
+		units := report.Units.Value()
+		proceeds := report.DeveloperProceeds
+		if isRefund(report) {
+			//refunded proceeds are reported as positive amount with zero or negative units
+			if units < 0 {
+				units = -units
+			}
+			if units == 0 {
+				units = 1
+			}
+			proceeds = appstore.NewMoney(proceeds.Amount.Abs().Neg(), proceeds.Currency)
+		} else if units > 0 {
+			row.subscribers[report.SubscriberID.Value()] = true
+		}
+		var err error
+		if row.Revenue, err = row.Revenue.Add(proceeds.MulInt(units)); err != nil {
+			return fmt.Errorf("Calculator.AddSubscribers row %d: Revenue %v", i, err)
+		}
+	}
+	return nil
+}
+
+//Rows Get KPI rows sorted by date and key
+func (c *Calculator) Rows() []*Row {
+	snapshots := make([]time.Time, 0, len(c.snapshots))
+	for date := range c.snapshots {
+		snapshots = append(snapshots, date)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Before(snapshots[j])
+	})
+	rows := make([]*Row, 0, len(c.rows))
+	for key, row := range c.rows {
+		if previous, ok := previousSnapshot(snapshots, key.Date); ok {
+			opening := key
+			opening.Date = previous
+			if openingRow, ok := c.rows[opening]; ok {
+				row.OpeningPaidSubscriptions = openingRow.PaidSubscriptions
+			}
+		}
+		row.PayingSubscribers = len(row.subscribers)
+		row.MRR = row.MRR.Round()
+		row.GrossMRR = row.GrossMRR.Round()
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i].Key, rows[j].Key
+		switch {
+		case !a.Date.Equal(b.Date):
+			return a.Date.Before(b.Date)
+		case a.AppAppleID != b.AppAppleID:
+			return a.AppAppleID < b.AppAppleID
+		case a.SubscriptionAppleID != b.SubscriptionAppleID:
+			return a.SubscriptionAppleID < b.SubscriptionAppleID
+		}
+		return a.Country < b.Country
+	})
+	return rows
+}
+
+//row Get KPI row of report row, created on first use
+func (c *Calculator) row(date time.Time, appAppleID int, subscriptionAppleID int, country string, appName string, subscriptionName string) *Row {
+	key := Key{Date: day(date)}
+	if c.dimensions[DimensionApp] {
+		key.AppAppleID = appAppleID
+	}
+	if c.dimensions[DimensionSubscription] {
+		key.SubscriptionAppleID = subscriptionAppleID
+	}
+	if c.dimensions[DimensionTerritory] {
+		key.Country = strings.ToUpper(strings.Trim(country, " "))
+	}
+	row, ok := c.rows[key]
+	if !ok {
+		row = &Row{Key: key, subscribers: make(map[int]bool)}
+		if c.dimensions[DimensionApp] {
+			row.AppName = appName
+		}
+		if c.dimensions[DimensionSubscription] {
+			row.SubscriptionName = subscriptionName
+		}
+		c.rows[key] = row
+	}
+	return row
+}
+
+//isTrialStart Check event starts free trial or offer
+func isTrialStart(event appstore.SubscriptionEvent) bool {
+	return event.IsStart() && !strings.EqualFold(strings.TrimSpace(string(event)), string(appstore.SubscriptionEventSubscribe))
+}
+
+//isRefund Check subscribers report row is refund
+func isRefund(report *appstore.SubscribersReport) bool {
+	return strings.EqualFold(strings.Trim(report.Refund, " "), "Yes")
+}
+
+//previousSnapshot Get latest snapshot date before date
+func previousSnapshot(snapshots []time.Time, date time.Time) (time.Time, bool) {
+	var previous time.Time
+	found := false
+	for _, snapshot := range snapshots {
+		if !snapshot.Before(date) {
+			break
+		}
+		previous, found = snapshot, true
+	}
+	return previous, found
+}
+
+//day Get start of day of date
+func day(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+//NewCalculator Create KPI calculator grouping rows by day and dimensions, by all dimensions if none given
+func NewCalculator(dimensions ...Dimension) *Calculator {
+	if len(dimensions) == 0 {
+		dimensions = []Dimension{DimensionApp, DimensionSubscription, DimensionTerritory}
+	}
+	c := &Calculator{
+		dimensions: make(map[Dimension]bool, len(dimensions)),
+		rows:       make(map[Key]*Row),
+		snapshots:  make(map[time.Time]bool),
+	}
+	for _, dimension := range dimensions {
+		c.dimensions[dimension] = true
+	}
+	return c
+}
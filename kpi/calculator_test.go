@@ -0,0 +1,197 @@
+package kpi
+
+import (
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+type CalculatorTestSuite struct {
+	suite.Suite
+	date time.Time
+}
+
+func (suite *CalculatorTestSuite) SetupTest() {
+	suite.date = time.Date(2020, 10, 5, 0, 0, 0, 0, time.UTC)
+}
+
+func (suite *CalculatorTestSuite) loadStub(path string, out interface{}) {
+	data, err := ioutil.ReadFile("../stubs/reports/sales/" + path)
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), appstore.UnmarshalCSV(data, out))
+}
+
+func (suite *CalculatorTestSuite) findRow(rows []*Row, key Key) *Row {
+	for _, row := range rows {
+		if row.Key == key {
+			return row
+		}
+	}
+	return nil
+}
+
+func (suite *CalculatorTestSuite) TestAddSubscriptions() {
+	calculator := NewCalculator()
+	err := calculator.AddSubscriptions(suite.date, []*appstore.SubscriptionsReport{
+		{
+			AppAppleID:                       appstore.CustomInteger{Integer: 1},
+			SubscriptionAppleID:              appstore.CustomInteger{Integer: 10},
+			AppName:                          "Foo",
+			SubscriptionName:                 "Foo Weekly",
+			Country:                          "US",
+			StandardSubscriptionDuration:     "7 Days",
+			CustomerPrice:                    appstore.NewMoney(decimal.New(499, -2), "USD"),
+			DeveloperProceeds:                appstore.NewMoney(decimal.New(349, -2), "USD"),
+			ActiveStandardPriceSubscriptions: appstore.CustomInteger{Integer: 10},
+			ActivePayAsYouGoIntroductoryOfferSubscriptions: appstore.CustomInteger{Integer: 2},
+			ActiveFreeTrialIntroductoryOfferSubscriptions:  appstore.CustomInteger{Integer: 5},
+			BillingRetry: appstore.CustomInteger{Integer: 1},
+		},
+		{
+			AppAppleID:                       appstore.CustomInteger{Integer: 1},
+			SubscriptionAppleID:              appstore.CustomInteger{Integer: 10},
+			Country:                          "US",
+			StandardSubscriptionDuration:     "1 Year",
+			CustomerPrice:                    appstore.NewMoney(decimal.New(4999, -2), "USD"),
+			DeveloperProceeds:                appstore.NewMoney(decimal.New(3499, -2), "USD"),
+			ActiveStandardPriceSubscriptions: appstore.CustomInteger{Integer: 3},
+		},
+	})
+	assert.NoError(suite.T(), err)
+	rows := calculator.Rows()
+	assert.Len(suite.T(), rows, 1)
+	row := rows[0]
+	assert.Equal(suite.T(), Key{Date: suite.date, AppAppleID: 1, SubscriptionAppleID: 10, Country: "US"}, row.Key)
+	assert.Equal(suite.T(), "Foo", row.AppName)
+	assert.Equal(suite.T(), 15, row.PaidSubscriptions)
+	assert.Equal(suite.T(), 5, row.TrialSubscriptions)
+	assert.Equal(suite.T(), 20, row.ActiveSubscriptions)
+	assert.Equal(suite.T(), 1, row.BillingRetry)
+	//3.49 * 12 * 365 / 84 + 34.99 * 3 / 12
+	assert.Equal(suite.T(), "190.73 USD", row.MRR.String())
+	//4.99 * 12 * 365 / 84 + 49.99 * 3 / 12
+	assert.Equal(suite.T(), "272.69 USD", row.GrossMRR.String())
+}
+
+func (suite *CalculatorTestSuite) TestAddSubscriptionsInvalidDuration() {
+	calculator := NewCalculator()
+	err := calculator.AddSubscriptions(suite.date, []*appstore.SubscriptionsReport{
+		{StandardSubscriptionDuration: "forever", ActiveStandardPriceSubscriptions: appstore.CustomInteger{Integer: 1}},
+	})
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "Calculator.AddSubscriptions row 0: invalid subscription duration forever", err.Error())
+}
+
+func (suite *CalculatorTestSuite) TestAddSubscriptionsMixedCurrencies() {
+	calculator := NewCalculator(DimensionApp)
+	err := calculator.AddSubscriptions(suite.date, []*appstore.SubscriptionsReport{
+		{StandardSubscriptionDuration: "1 Month", DeveloperProceeds: appstore.NewMoney(decimal.New(1, 0), "USD"), ActiveStandardPriceSubscriptions: appstore.CustomInteger{Integer: 1}},
+		{StandardSubscriptionDuration: "1 Month", DeveloperProceeds: appstore.NewMoney(decimal.New(1, 0), "EUR"), ActiveStandardPriceSubscriptions: appstore.CustomInteger{Integer: 1}},
+	})
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "Calculator.AddSubscriptions row 1: MRR Money.Add: currency mismatch USD and EUR", err.Error())
+}
+
+func (suite *CalculatorTestSuite) TestAddEvents() {
+	calculator := NewCalculator(DimensionApp)
+	date := appstore.CustomDate{Date: suite.date}
+	err := calculator.AddEvents([]*appstore.SubscriptionsEventsReport{
+		{EventDate: date, AppAppleID: appstore.CustomInteger{Integer: 1}, Event: appstore.SubscriptionEventStartFreeTrial, Quantity: appstore.CustomInteger{Integer: 10}},
+		{EventDate: date, AppAppleID: appstore.CustomInteger{Integer: 1}, Event: appstore.SubscriptionEventSubscribe, Quantity: appstore.CustomInteger{Integer: 7}},
+		{EventDate: date, AppAppleID: appstore.CustomInteger{Integer: 1}, Event: appstore.SubscriptionEventPaidSubscriptionFromFreeTrial, Quantity: appstore.CustomInteger{Integer: 4}},
+		{EventDate: date, AppAppleID: appstore.CustomInteger{Integer: 1}, Event: appstore.SubscriptionEventCancel, Quantity: appstore.CustomInteger{Integer: 2}},
+		{EventDate: date, AppAppleID: appstore.CustomInteger{Integer: 1}, Event: appstore.SubscriptionEventCanceledFromBillingRetry, Quantity: appstore.CustomInteger{Integer: 1}},
+		{EventDate: date, AppAppleID: appstore.CustomInteger{Integer: 1}, Event: appstore.SubscriptionEventRefund, Quantity: appstore.CustomInteger{Integer: 1}},
+		{EventDate: date, AppAppleID: appstore.CustomInteger{Integer: 1}, Event: appstore.SubscriptionEventRenew, Quantity: appstore.CustomInteger{Integer: 30}},
+	})
+	assert.NoError(suite.T(), err)
+	rows := calculator.Rows()
+	assert.Len(suite.T(), rows, 1)
+	assert.Equal(suite.T(), Key{Date: suite.date, AppAppleID: 1}, rows[0].Key)
+	assert.Equal(suite.T(), 10, rows[0].TrialStarts)
+	assert.Equal(suite.T(), 4, rows[0].TrialConversions)
+	assert.Equal(suite.T(), 3, rows[0].Churned)
+	assert.Equal(suite.T(), 1, rows[0].Refunds)
+	assert.Equal(suite.T(), 0.4, rows[0].TrialConversionRate())
+}
+
+func (suite *CalculatorTestSuite) TestAddEventsBlankDate() {
+	err := NewCalculator().AddEvents([]*appstore.SubscriptionsEventsReport{{Event: appstore.SubscriptionEventRenew}})
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "Calculator.AddEvents row 0: blank event date", err.Error())
+}
+
+func (suite *CalculatorTestSuite) TestAddSubscribers() {
+	calculator := NewCalculator(DimensionTerritory)
+	date := appstore.CustomDate{Date: suite.date}
+	proceeds := appstore.NewMoney(decimal.New(349, -2), "USD")
+	err := calculator.AddSubscribers([]*appstore.SubscribersReport{
+		{EventDate: date, Country: "US", SubscriberID: appstore.CustomInteger{Integer: 1}, DeveloperProceeds: proceeds, Units: appstore.CustomInteger{Integer: 1}},
+		{EventDate: date, Country: "US", SubscriberID: appstore.CustomInteger{Integer: 1}, DeveloperProceeds: proceeds, Units: appstore.CustomInteger{Integer: 1}},
+		{EventDate: date, Country: "US", SubscriberID: appstore.CustomInteger{Integer: 2}, DeveloperProceeds: proceeds, Units: appstore.CustomInteger{Integer: 1}},
+		{EventDate: date, Country: "US", SubscriberID: appstore.CustomInteger{Integer: 3}, DeveloperProceeds: proceeds, Units: appstore.CustomInteger{Integer: 0}, Refund: "Yes"},
+	})
+	assert.NoError(suite.T(), err)
+	rows := calculator.Rows()
+	assert.Len(suite.T(), rows, 1)
+	assert.Equal(suite.T(), "US", rows[0].Country)
+	assert.Equal(suite.T(), 2, rows[0].PayingSubscribers)
+	assert.Equal(suite.T(), "6.98 USD", rows[0].Revenue.String())
+	assert.Equal(suite.T(), "3.49 USD", rows[0].ARPPU().String())
+}
+
+func (suite *CalculatorTestSuite) TestOpeningPaidSubscriptions() {
+	calculator := NewCalculator(DimensionApp)
+	report := func(paid int) []*appstore.SubscriptionsReport {
+		return []*appstore.SubscriptionsReport{{
+			AppAppleID:                       appstore.CustomInteger{Integer: 1},
+			StandardSubscriptionDuration:     "1 Month",
+			ActiveStandardPriceSubscriptions: appstore.CustomInteger{Integer: paid},
+		}}
+	}
+	assert.NoError(suite.T(), calculator.AddSubscriptions(suite.date.AddDate(0, 0, -2), report(100)))
+	assert.NoError(suite.T(), calculator.AddSubscriptions(suite.date, report(90)))
+	assert.NoError(suite.T(), calculator.AddEvents([]*appstore.SubscriptionsEventsReport{
+		{EventDate: appstore.CustomDate{Date: suite.date}, AppAppleID: appstore.CustomInteger{Integer: 1}, Event: appstore.SubscriptionEventCancel, Quantity: appstore.CustomInteger{Integer: 10}},
+	}))
+	rows := calculator.Rows()
+	assert.Len(suite.T(), rows, 2)
+	assert.Equal(suite.T(), 0, rows[0].OpeningPaidSubscriptions)
+	assert.Equal(suite.T(), 100, rows[1].OpeningPaidSubscriptions)
+	assert.Equal(suite.T(), 0.1, rows[1].ChurnRate())
+}
+
+func (suite *CalculatorTestSuite) TestStubReports() {
+	subscriptions := []*appstore.SubscriptionsReport{}
+	events := []*appstore.SubscriptionsEventsReport{}
+	subscribers := []*appstore.SubscribersReport{}
+	suite.loadStub("subscriptions.tsv", &subscriptions)
+	suite.loadStub("subscriptions-events.tsv", &events)
+	suite.loadStub("subscribers.tsv", &subscribers)
+	calculator := NewCalculator(DimensionTerritory)
+	assert.NoError(suite.T(), calculator.AddSubscriptions(suite.date.AddDate(0, 0, -1), subscriptions))
+	assert.NoError(suite.T(), calculator.AddSubscriptions(suite.date, subscriptions))
+	assert.NoError(suite.T(), calculator.AddEvents(events))
+	assert.NoError(suite.T(), calculator.AddSubscribers(subscribers))
+	row := suite.findRow(calculator.Rows(), Key{Date: suite.date, Country: "RU"})
+	assert.NotNil(suite.T(), row)
+	assert.Equal(suite.T(), 183, row.PaidSubscriptions)
+	assert.Equal(suite.T(), 183, row.OpeningPaidSubscriptions)
+	assert.Equal(suite.T(), 74, row.BillingRetry)
+	//2065.00 * 20 / 12 + 419.30 * 160 * 365 / 84
+	assert.Equal(suite.T(), "294955.00 RUB", row.MRR.String())
+	assert.Equal(suite.T(), 45, row.TrialStarts)
+	assert.Equal(suite.T(), 28, row.Churned)
+	assert.InDelta(suite.T(), 28.0/183.0, row.ChurnRate(), 0.000001)
+	assert.Equal(suite.T(), 41, row.PayingSubscribers)
+	assert.Equal(suite.T(), "13247.20 RUB", row.Revenue.String())
+	assert.Equal(suite.T(), "323.10 RUB", row.ARPPU().String())
+}
+
+func TestCalculatorTestSuite(t *testing.T) {
+	suite.Run(t, new(CalculatorTestSuite))
+}
@@ -0,0 +1,173 @@
+package kpi
+
+import (
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/shopspring/decimal"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Dimension KPI grouping dimension, rows are always grouped by day
+type Dimension string
+
+const (
+	//DimensionApp const, group by App Apple ID
+	DimensionApp Dimension = "app"
+	//DimensionSubscription const, group by Subscription Apple ID
+	DimensionSubscription Dimension = "subscription"
+	//DimensionTerritory const, group by Country
+	DimensionTerritory Dimension = "territory"
+)
+
+//Key KPI row key, fields of dimensions not grouped by are zero
+type Key struct {
+	Date                time.Time
+	AppAppleID          int
+	SubscriptionAppleID int
+	Country             string
+}
+
+//Row subscription KPIs of key.
+//
+//Stocks are taken from subscriptions report of the day:
+//
+//	PaidSubscriptions = standard price + pay up front and pay as you go introductory, promotional and offer code subscriptions
+//	TrialSubscriptions = free trial introductory, promotional and offer code subscriptions
+//	ActiveSubscriptions = PaidSubscriptions + TrialSubscriptions
+//	MRR = sum(Developer Proceeds * paid subscriptions * periods per month of standard subscription duration), e.g. 1/12 for 1 Year, 365/12/7 for 7 Days
+//	GrossMRR = same as MRR with Customer Price
+//
+//Flows are summed from subscriptions events report (Quantity) and subscribers report (Units):
+//
+//	TrialStarts = Start Free Trial, Start Introductory Offer, Start Promotional Offer and Start Offer Code events
+//	TrialConversions = Paid Subscription from Free Trial, Introductory Offer, Promotional Offer and Offer Code events
+//	Churned = Cancel and Canceled from Billing Retry events
+//	Revenue = sum(Developer Proceeds * Units), refunds are subtracted
+//	PayingSubscribers = distinct Subscriber IDs with positive units, refunds excluded
+type Row struct {
+	Key
+	AppName                  string
+	SubscriptionName         string
+	ActiveSubscriptions      int
+	PaidSubscriptions        int
+	TrialSubscriptions       int
+	BillingRetry             int
+	GracePeriod              int
+	OpeningPaidSubscriptions int //paid subscriptions of previous subscriptions report, 0 without previous report
+	MRR                      appstore.Money
+	GrossMRR                 appstore.Money
+	TrialStarts              int
+	TrialConversions         int
+	Churned                  int
+	Refunds                  int
+	Revenue                  appstore.Money
+	PayingSubscribers        int
+	subscribers              map[int]bool
+}
+
+//TrialConversionRate Get trial conversions per trial start, TrialConversions / TrialStarts, zero without trial starts.
+//Conversions of trials started before the period are counted, so rate is meaningful over periods longer than trial
+func (r *Row) TrialConversionRate() float64 {
+	if r.TrialStarts == 0 {
+		return 0
+	}
+	return float64(r.TrialConversions) / float64(r.TrialStarts)
+}
+
+//ChurnRate Get churned subscriptions per opening paid subscription, Churned / OpeningPaidSubscriptions, zero without opening subscriptions
+func (r *Row) ChurnRate() float64 {
+	if r.OpeningPaidSubscriptions == 0 {
+		return 0
+	}
+	return float64(r.Churned) / float64(r.OpeningPaidSubscriptions)
+}
+
+//ARPPU Get average revenue per paying subscriber, Revenue / PayingSubscribers rounded to currency minor units
+func (r *Row) ARPPU() appstore.Money {
+	if r.PayingSubscribers == 0 {
+		return appstore.NewMoney(decimal.Zero, r.Revenue.Currency)
+	}
+	amount := r.Revenue.Amount.Div(decimal.New(int64(r.PayingSubscribers), 0))
+	return appstore.NewMoney(amount, r.Revenue.Currency).Round()
+}
+
+//Total Combine rows of period, e.g. days of month. Stocks are summed from rows of the last day,
+//opening subscriptions from rows of the first day and flows from all rows.
+//PayingSubscribers are summed per day, subscribers paying on several days are counted several times
+func Total(rows []*Row) (*Row, error) {
+	total := &Row{}
+	var first, last time.Time
+	for _, row := range rows {
+		if first.IsZero() || row.Date.Before(first) {
+			first = row.Date
+		}
+		if last.IsZero() || row.Date.After(last) {
+			last = row.Date
+		}
+	}
+	var err error
+	for _, row := range rows {
+		if row.Date.Equal(first) {
+			total.OpeningPaidSubscriptions += row.OpeningPaidSubscriptions
+		}
+		if row.Date.Equal(last) {
+			total.ActiveSubscriptions += row.ActiveSubscriptions
+			total.PaidSubscriptions += row.PaidSubscriptions
+			total.TrialSubscriptions += row.TrialSubscriptions
+			total.BillingRetry += row.BillingRetry
+			total.GracePeriod += row.GracePeriod
+			if total.MRR, err = total.MRR.Add(row.MRR); err != nil {
+				return nil, fmt.Errorf("Total: MRR %v", err)
+			}
+			if total.GrossMRR, err = total.GrossMRR.Add(row.GrossMRR); err != nil {
+				return nil, fmt.Errorf("Total: GrossMRR %v", err)
+			}
+		}
+		total.TrialStarts += row.TrialStarts
+		total.TrialConversions += row.TrialConversions
+		total.Churned += row.Churned
+		total.Refunds += row.Refunds
+		total.PayingSubscribers += row.PayingSubscribers
+		if total.Revenue, err = total.Revenue.Add(row.Revenue); err != nil {
+			return nil, fmt.Errorf("Total: Revenue %v", err)
+		}
+	}
+	total.Date = first
+	return total, nil
+}
+
+//periodsPerMonth Get number of subscription periods per month of standard subscription duration as fraction,
+//e.g. 7 Days is 365/84, 1 Month is 1/1, 1 Year is 1/12
+func periodsPerMonth(duration string) (int64, int64, error) {
+	parts := strings.Fields(strings.ToLower(duration))
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid subscription duration %s", duration)
+	}
+	count, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || count <= 0 {
+		return 0, 0, fmt.Errorf("invalid subscription duration %s", duration)
+	}
+	switch strings.TrimSuffix(parts[1], "s") {
+	case "day":
+		return 365, 12 * count, nil
+	case "week":
+		return 52, 12 * count, nil
+	case "month":
+		return 1, count, nil
+	case "year":
+		return 1, 12 * count, nil
+	}
+	return 0, 0, fmt.Errorf("invalid subscription duration %s", duration)
+}
+
+//monthly Get monthly amount of price per subscription period
+func monthly(price appstore.Money, quantity int, duration string) (appstore.Money, error) {
+	num, den, err := periodsPerMonth(duration)
+	if err != nil {
+		return appstore.Money{}, err
+	}
+	amount := price.Amount.Mul(decimal.New(int64(quantity)*num, 0)).Div(decimal.New(den, 0))
+	return appstore.NewMoney(amount, price.Currency), nil
+}
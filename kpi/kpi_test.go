@@ -0,0 +1,77 @@
+package kpi
+
+import (
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type KpiTestSuite struct {
+	suite.Suite
+}
+
+func (suite *KpiTestSuite) TestPeriodsPerMonth() {
+	cases := map[string][2]int64{
+		"7 Days":   {365, 84},
+		"1 Week":   {52, 12},
+		"1 Month":  {1, 1},
+		"2 Months": {1, 2},
+		"3 Months": {1, 3},
+		"6 Months": {1, 6},
+		"1 Year":   {1, 12},
+	}
+	for duration, expected := range cases {
+		num, den, err := periodsPerMonth(duration)
+		assert.NoError(suite.T(), err, duration)
+		assert.Equal(suite.T(), expected, [2]int64{num, den}, duration)
+	}
+	for _, duration := range []string{"", "Month", "0 Months", "1 Century"} {
+		_, _, err := periodsPerMonth(duration)
+		assert.Error(suite.T(), err, duration)
+	}
+}
+
+func (suite *KpiTestSuite) TestRates() {
+	row := &Row{}
+	assert.Equal(suite.T(), 0.0, row.TrialConversionRate())
+	assert.Equal(suite.T(), 0.0, row.ChurnRate())
+	assert.Equal(suite.T(), "0", row.ARPPU().Amount.String())
+	row = &Row{TrialStarts: 8, TrialConversions: 2, OpeningPaidSubscriptions: 50, Churned: 5, PayingSubscribers: 3, Revenue: appstore.NewMoney(decimal.New(10, 0), "USD")}
+	assert.Equal(suite.T(), 0.25, row.TrialConversionRate())
+	assert.Equal(suite.T(), 0.1, row.ChurnRate())
+	assert.Equal(suite.T(), "3.33 USD", row.ARPPU().String())
+}
+
+func (suite *KpiTestSuite) TestTotal() {
+	first := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 0, 1)
+	usd := func(amount int64) appstore.Money {
+		return appstore.NewMoney(decimal.New(amount, 0), "USD")
+	}
+	rows := []*Row{
+		{Key: Key{Date: first, Country: "US"}, OpeningPaidSubscriptions: 100, PaidSubscriptions: 98, MRR: usd(980), Churned: 2, TrialStarts: 5, Revenue: usd(10), PayingSubscribers: 2},
+		{Key: Key{Date: first, Country: "CA"}, OpeningPaidSubscriptions: 50, PaidSubscriptions: 50, MRR: usd(500), TrialStarts: 5, Revenue: usd(20), PayingSubscribers: 4},
+		{Key: Key{Date: last, Country: "US"}, OpeningPaidSubscriptions: 98, PaidSubscriptions: 95, MRR: usd(950), Churned: 3, TrialConversions: 2, Revenue: usd(30), PayingSubscribers: 6},
+		{Key: Key{Date: last, Country: "CA"}, OpeningPaidSubscriptions: 50, PaidSubscriptions: 51, MRR: usd(510), TrialConversions: 1, PayingSubscribers: 0},
+	}
+	total, err := Total(rows)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), first, total.Date)
+	assert.Equal(suite.T(), 150, total.OpeningPaidSubscriptions)
+	assert.Equal(suite.T(), 146, total.PaidSubscriptions)
+	assert.Equal(suite.T(), "1460 USD", total.MRR.Amount.String()+" "+total.MRR.Currency)
+	assert.Equal(suite.T(), 5, total.Churned)
+	assert.InDelta(suite.T(), 5.0/150.0, total.ChurnRate(), 0.000001)
+	assert.Equal(suite.T(), 0.3, total.TrialConversionRate())
+	assert.Equal(suite.T(), "5.00 USD", total.ARPPU().String())
+	rows[3].MRR = appstore.NewMoney(decimal.New(1, 0), "EUR")
+	_, err = Total(rows)
+	assert.Error(suite.T(), err)
+}
+
+func TestKpiTestSuite(t *testing.T) {
+	suite.Run(t, new(KpiTestSuite))
+}
@@ -0,0 +1,285 @@
+package appstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+//LogLevel type
+type LogLevel int
+
+const (
+	//LogLevelDebug const
+	LogLevelDebug LogLevel = iota
+	//LogLevelInfo const
+	LogLevelInfo
+	//LogLevelWarn const
+	LogLevelWarn
+	//LogLevelError const
+	LogLevelError
+)
+
+//LogRedactedValue const
+const LogRedactedValue = "[REDACTED]"
+
+//String Log level name
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	}
+	return "UNKNOWN"
+}
+
+//LogField structured log field
+type LogField struct {
+	Key   string
+	Value interface{}
+}
+
+//Logger structured logger interface
+type Logger interface {
+	Log(ctx context.Context, level LogLevel, msg string, fields ...LogField)
+}
+
+//NopLogger logger which discards everything
+type NopLogger struct {
+}
+
+//Log method
+func (l *NopLogger) Log(ctx context.Context, level LogLevel, msg string, fields ...LogField) {
+}
+
+//sensitiveWords words of header and query param names holding secrets, e.g. X-Api-Key, access_token or X-Amz-Signature
+var sensitiveWords = map[string]bool{
+	"authorization": true,
+	"cookie":        true,
+	"key":           true,
+	"apikey":        true,
+	"token":         true,
+	"secret":        true,
+	"signature":     true,
+	"credential":    true,
+	"credentials":   true,
+	"password":      true,
+	"passwd":        true,
+}
+
+//bearerRegexp bearer token pattern
+var bearerRegexp = regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-_=.]+`)
+
+//pemRegexp PEM block pattern
+var pemRegexp = regexp.MustCompile(`(?s)-----BEGIN [A-Z ]+-----.*?-----END [A-Z ]+-----`)
+
+//IsSensitiveKey Check header or query param name holds secret value. Name is split into words by separators and camel case,
+//it is sensitive when any word is sensitive, so X-Amz-Security-Token is redacted but Keep-Alive is not.
+//Bracketed params such as filter[primaryKey] are checked by their name before brackets
+func IsSensitiveKey(key string) bool {
+	if i := strings.IndexByte(key, '['); i > 0 {
+		key = key[:i]
+	}
+	for _, word := range nameWords(key) {
+		if sensitiveWords[word] {
+			return true
+		}
+	}
+	return false
+}
+
+//nameWords Split header or query param name into lower-cased words by non-alphanumeric separators and camel case
+func nameWords(name string) []string {
+	words := make([]string, 0)
+	word := make([]rune, 0, len(name))
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	for i, r := range name {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0 && len(word) > 0 && !unicode.IsUpper(word[len(word)-1]):
+			flush()
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
+
+//RedactSecrets Replace bearer tokens and PEM keys in string
+func RedactSecrets(str string) string {
+	str = bearerRegexp.ReplaceAllString(str, "Bearer "+LogRedactedValue)
+	return pemRegexp.ReplaceAllString(str, LogRedactedValue)
+}
+
+//RedactHeaders Copy headers with sensitive values redacted
+func RedactHeaders(headers http.Header) map[string]string {
+	result := make(map[string]string, len(headers))
+	for k := range headers {
+		if IsSensitiveKey(k) {
+			result[k] = LogRedactedValue
+		} else {
+			result[k] = RedactSecrets(headers.Get(k))
+		}
+	}
+	return result
+}
+
+//RedactHeaderValues Copy headers keeping every value of multi-valued headers, sensitive values are redacted
+func RedactHeaderValues(headers http.Header) http.Header {
+	result := make(http.Header, len(headers))
+	for k, values := range headers {
+		redacted := make([]string, 0, len(values))
+		for _, value := range values {
+			if IsSensitiveKey(k) {
+				redacted = append(redacted, LogRedactedValue)
+			} else {
+				redacted = append(redacted, RedactSecrets(value))
+			}
+		}
+		result[k] = redacted
+	}
+	return result
+}
+
+//RedactQuery Copy query params with sensitive values redacted
+func RedactQuery(query url.Values) map[string]string {
+	result := make(map[string]string, len(query))
+	for k := range query {
+		if IsSensitiveKey(k) {
+			result[k] = LogRedactedValue
+		} else {
+			result[k] = RedactSecrets(query.Get(k))
+		}
+	}
+	return result
+}
+
+//RedactQueryValues Copy query params keeping every value of repeated params, sensitive values are redacted
+func RedactQueryValues(query url.Values) url.Values {
+	result := make(url.Values, len(query))
+	for k, values := range query {
+		redacted := make([]string, 0, len(values))
+		for _, value := range values {
+			if IsSensitiveKey(k) {
+				redacted = append(redacted, LogRedactedValue)
+			} else {
+				redacted = append(redacted, RedactSecrets(value))
+			}
+		}
+		result[k] = redacted
+	}
+	return result
+}
+
+//RedactRequestQuery Copy query params of request with sensitive values redacted, every value is redacted for download requests
+func RedactRequestQuery(req *http.Request) url.Values {
+	query := req.URL.Query()
+	if !IsDownloadRequest(req) {
+		return RedactQueryValues(query)
+	}
+	for k, values := range query {
+		for i := range values {
+			values[i] = LogRedactedValue
+		}
+		query[k] = values
+	}
+	return query
+}
+
+//RedactRequestUrl Get url of request with query redacted by RedactRequestQuery
+func RedactRequestUrl(req *http.Request) string {
+	u := *req.URL
+	u.RawQuery = RedactRequestQuery(req).Encode()
+	return u.String()
+}
+
+//loggedBody response body counting bytes read, response is logged once the body is closed
+type loggedBody struct {
+	io.ReadCloser
+	size int64
+	once sync.Once
+	log  func(size int64)
+}
+
+//Read method
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+	return n, err
+}
+
+//Close method
+func (b *loggedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.log(b.size)
+	})
+	return err
+}
+
+//NewLoggerMiddleware create middleware which logs every request and response.
+//Successful responses are logged when their body is closed, response_size is the number of bytes actually read
+func NewLoggerMiddleware(logger Logger) Middleware {
+	if logger == nil {
+		logger = &NopLogger{}
+	}
+	return func(next RequestHandlerFunc) RequestHandlerFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			started := time.Now()
+			resp, err := next(req)
+			fields := []LogField{
+				{Key: "method", Value: req.Method},
+				{Key: "path", Value: req.URL.Path},
+				{Key: "query", Value: RedactQuery(RedactRequestQuery(req))},
+				{Key: "request_headers", Value: RedactHeaders(req.Header)},
+				{Key: "duration", Value: time.Since(started)},
+			}
+			if err != nil {
+				//client errors quote full url, which holds signature of pre-signed urls
+				msg := strings.Replace(err.Error(), req.URL.String(), RedactRequestUrl(req), -1)
+				fields = append(fields, LogField{Key: "error", Value: RedactSecrets(msg)})
+				logger.Log(req.Context(), LogLevelError, "appstore http request failed", fields...)
+				return resp, err
+			}
+			fields = append(fields,
+				LogField{Key: "status", Value: resp.StatusCode},
+				LogField{Key: "content_type", Value: resp.Header.Get("Content-Type")},
+			)
+			if rateLimit := resp.Header.Get(HttpHeaderRateLimit); rateLimit != "" {
+				fields = append(fields, LogField{Key: "rate_limit", Value: rateLimit})
+			}
+			level := LogLevelInfo
+			if resp.StatusCode >= http.StatusBadRequest {
+				level = LogLevelWarn
+			}
+			log := func(size int64) {
+				logger.Log(req.Context(), level, "appstore http request", append(fields, LogField{Key: "response_size", Value: size})...)
+			}
+			if resp.Body == nil {
+				log(0)
+				return resp, nil
+			}
+			//content length is -1 for chunked and gzip-transferred reports, count bytes actually read instead
+			resp.Body = &loggedBody{ReadCloser: resp.Body, log: log}
+			return resp, nil
+		}
+	}
+}
@@ -0,0 +1,44 @@
+//go:build go1.21
+// +build go1.21
+
+package appstore
+
+import (
+	"context"
+	"log/slog"
+)
+
+//SlogLogger log/slog adapter
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+//Log method
+func (l *SlogLogger) Log(ctx context.Context, level LogLevel, msg string, fields ...LogField) {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, field := range fields {
+		attrs = append(attrs, slog.Any(field.Key, field.Value))
+	}
+	l.logger.LogAttrs(ctx, l.level(level), msg, attrs...)
+}
+
+//level convert log level
+func (l *SlogLogger) level(level LogLevel) slog.Level {
+	switch level {
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelWarn:
+		return slog.LevelWarn
+	case LogLevelError:
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
+
+//NewSlogLogger Create new log/slog adapter, default slog logger is used if nil
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogLogger{logger: logger}
+}
@@ -0,0 +1,29 @@
+//go:build go1.21
+// +build go1.21
+
+package appstore
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"log/slog"
+	"testing"
+)
+
+type SlogLoggerTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SlogLoggerTestSuite) TestLog() {
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	logger.Log(context.Background(), LogLevelWarn, "foo", LogField{Key: "bar", Value: "baz"})
+	assert.Contains(suite.T(), buf.String(), "level=WARN")
+	assert.Contains(suite.T(), buf.String(), "msg=foo bar=baz")
+}
+
+func TestSlogLoggerTestSuite(t *testing.T) {
+	suite.Run(t, new(SlogLoggerTestSuite))
+}
@@ -0,0 +1,157 @@
+package appstore
+
+import (
+	"context"
+	"errors"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+type stubLogEntry struct {
+	level  LogLevel
+	msg    string
+	fields map[string]interface{}
+}
+
+type stubLogger struct {
+	entries []*stubLogEntry
+}
+
+func (l *stubLogger) Log(ctx context.Context, level LogLevel, msg string, fields ...LogField) {
+	entry := &stubLogEntry{level: level, msg: msg, fields: make(map[string]interface{})}
+	for _, field := range fields {
+		entry.fields[field.Key] = field.Value
+	}
+	l.entries = append(l.entries, entry)
+}
+
+type LoggerTestSuite struct {
+	suite.Suite
+	cfg      *Config
+	ctx      context.Context
+	logger   *stubLogger
+	testable *Transport
+}
+
+func (suite *LoggerTestSuite) SetupTest() {
+	suite.cfg = buildStubConfig()
+	suite.ctx = context.Background()
+	suite.logger = &stubLogger{}
+	suite.testable = buildStubHttpTransport()
+	suite.testable.Use(NewLoggerMiddleware(suite.logger))
+	httpmock.Activate()
+}
+
+func (suite *LoggerTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+}
+
+func (suite *LoggerTestSuite) TestLogSuccess() {
+	resp := httpmock.NewStringResponse(http.StatusOK, "foo")
+	resp.Header.Set(HttpHeaderRateLimit, "user-hour-lim:3600;user-hour-rem:3599;")
+	resp.ContentLength = -1
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/foo", httpmock.ResponderFromResponse(resp))
+	result, err := suite.testable.Get(suite.ctx, "foo", map[string]interface{}{"filter[vendorNumber]": "baz"})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), suite.logger.entries)
+	_, _ = ioutil.ReadAll(result.Body)
+	_ = result.Body.Close()
+	_ = result.Body.Close()
+	assert.Len(suite.T(), suite.logger.entries, 1)
+	entry := suite.logger.entries[0]
+	assert.Equal(suite.T(), LogLevelInfo, entry.level)
+	assert.Equal(suite.T(), "GET", entry.fields["method"])
+	assert.Equal(suite.T(), "/foo", entry.fields["path"])
+	assert.Equal(suite.T(), map[string]string{"filter[vendorNumber]": "baz"}, entry.fields["query"])
+	assert.Equal(suite.T(), http.StatusOK, entry.fields["status"])
+	assert.Equal(suite.T(), int64(3), entry.fields["response_size"])
+	assert.Equal(suite.T(), "user-hour-lim:3600;user-hour-rem:3599;", entry.fields["rate_limit"])
+	assert.NotEmpty(suite.T(), entry.fields["duration"])
+	headers := entry.fields["request_headers"].(map[string]string)
+	assert.Equal(suite.T(), LogRedactedValue, headers["Authorization"])
+}
+
+func (suite *LoggerTestSuite) TestLogNotSuccessStatus() {
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/foo", httpmock.NewStringResponder(http.StatusNotFound, ""))
+	result, err := suite.testable.Get(suite.ctx, "foo", nil)
+	assert.NoError(suite.T(), err)
+	_ = result.Body.Close()
+	assert.Equal(suite.T(), LogLevelWarn, suite.logger.entries[0].level)
+	assert.Equal(suite.T(), int64(0), suite.logger.entries[0].fields["response_size"])
+}
+
+func (suite *LoggerTestSuite) TestLogError() {
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/foo", httpmock.NewErrorResponder(errors.New("Bearer secret.token failed")))
+	_, err := suite.testable.Get(suite.ctx, "foo", nil)
+	assert.Error(suite.T(), err)
+	entry := suite.logger.entries[0]
+	assert.Equal(suite.T(), LogLevelError, entry.level)
+	assert.NotContains(suite.T(), entry.fields["error"], "secret.token")
+}
+
+func (suite *LoggerTestSuite) TestLogDownloadRedactsQuery() {
+	uri := "https://example.com/segment.csv.gz?X-Amz-Date=20200505T000000Z&X-Amz-Signature=secret&X-Amz-Security-Token=secret"
+	httpmock.RegisterResponder("GET", "https://example.com/segment.csv.gz", httpmock.NewStringResponder(http.StatusOK, "foo"))
+	result, err := suite.testable.Download(suite.ctx, uri)
+	assert.NoError(suite.T(), err)
+	_ = result.Body.Close()
+	query := suite.logger.entries[0].fields["query"].(map[string]string)
+	assert.Equal(suite.T(), map[string]string{"X-Amz-Date": LogRedactedValue, "X-Amz-Signature": LogRedactedValue, "X-Amz-Security-Token": LogRedactedValue}, query)
+
+	httpmock.RegisterResponder("GET", "https://example.com/failed.csv.gz", httpmock.NewErrorResponder(errors.New("foo")))
+	_, err = suite.testable.Download(suite.ctx, "https://example.com/failed.csv.gz?X-Amz-Signature=secret")
+	assert.Error(suite.T(), err)
+	assert.NotContains(suite.T(), suite.logger.entries[1].fields["error"], "secret")
+	assert.Contains(suite.T(), suite.logger.entries[1].fields["error"], "failed.csv.gz")
+}
+
+func TestLoggerTestSuite(t *testing.T) {
+	suite.Run(t, new(LoggerTestSuite))
+}
+
+type LoggerRedactTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LoggerRedactTestSuite) TestIsSensitiveKey() {
+	assert.True(suite.T(), IsSensitiveKey("Authorization"))
+	assert.True(suite.T(), IsSensitiveKey("private_key"))
+	assert.True(suite.T(), IsSensitiveKey("access_token"))
+	assert.True(suite.T(), IsSensitiveKey("X-Api-Key"))
+	assert.True(suite.T(), IsSensitiveKey("Signature"))
+	assert.True(suite.T(), IsSensitiveKey("X-Amz-Signature"))
+	assert.True(suite.T(), IsSensitiveKey("X-Amz-Credential"))
+	assert.True(suite.T(), IsSensitiveKey("X-Amz-Security-Token"))
+	assert.True(suite.T(), IsSensitiveKey("clientSecret"))
+	assert.True(suite.T(), IsSensitiveKey("Set-Cookie"))
+	assert.False(suite.T(), IsSensitiveKey("X-Amz-SignedHeaders"))
+	assert.False(suite.T(), IsSensitiveKey("X-Amz-Date"))
+	assert.False(suite.T(), IsSensitiveKey("filter[reportType]"))
+	assert.False(suite.T(), IsSensitiveKey("filter[primaryKey]"))
+	assert.False(suite.T(), IsSensitiveKey("Keep-Alive"))
+}
+
+func (suite *LoggerRedactTestSuite) TestRedactSecrets() {
+	data, _ := loadStubResponseData(StubAuthKeyPath)
+	assert.Equal(suite.T(), "key: "+LogRedactedValue, RedactSecrets("key: "+string(data)))
+	assert.Equal(suite.T(), "Bearer "+LogRedactedValue+" foo", RedactSecrets("Bearer eyJ.foo.bar foo"))
+}
+
+func (suite *LoggerRedactTestSuite) TestRedactQuery() {
+	result := RedactQuery(url.Values{"token": []string{"foo"}, "bar": []string{"baz"}})
+	assert.Equal(suite.T(), map[string]string{"token": LogRedactedValue, "bar": "baz"}, result)
+}
+
+func (suite *LoggerRedactTestSuite) TestRedactQueryValues() {
+	result := RedactQueryValues(url.Values{"token": []string{"foo", "bar"}, "include": []string{"apps", "builds"}})
+	assert.Equal(suite.T(), url.Values{"token": []string{LogRedactedValue, LogRedactedValue}, "include": []string{"apps", "builds"}}, result)
+}
+
+func TestLoggerRedactTestSuite(t *testing.T) {
+	suite.Run(t, new(LoggerRedactTestSuite))
+}
@@ -0,0 +1,113 @@
+package appstore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+//HttpUserAgentDefault const
+const HttpUserAgentDefault = "appstore-sdk-go"
+
+//HttpHeaderRequestId const
+const HttpHeaderRequestId = "X-Request-Id"
+
+//RequestHandlerFunc sends the request and returns the response
+type RequestHandlerFunc func(req *http.Request) (*http.Response, error)
+
+//Middleware wraps the next request handler in the chain
+type Middleware func(next RequestHandlerFunc) RequestHandlerFunc
+
+//RequestInterceptor is called with every outgoing request before it is sent
+type RequestInterceptor func(req *http.Request) error
+
+//ResponseInterceptor is called with every received response before it is returned
+type ResponseInterceptor func(req *http.Request, resp *http.Response) error
+
+//MiddlewareChain ordered list of middlewares
+type MiddlewareChain []Middleware
+
+//Then build request handler from chain, first middleware is the outermost
+func (mc MiddlewareChain) Then(handler RequestHandlerFunc) RequestHandlerFunc {
+	for i := len(mc) - 1; i >= 0; i-- {
+		handler = mc[i](handler)
+	}
+	return handler
+}
+
+//RoundTrip implementation of http.RoundTripper
+func (f RequestHandlerFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+//NewRoundTripper wrap http.RoundTripper with middlewares
+func NewRoundTripper(rt http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return MiddlewareChain(middlewares).Then(rt.RoundTrip)
+}
+
+//NewRequestInterceptorMiddleware create middleware from request interceptor
+func NewRequestInterceptorMiddleware(interceptor RequestInterceptor) Middleware {
+	return func(next RequestHandlerFunc) RequestHandlerFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := interceptor(req); err != nil {
+				return nil, err
+			}
+			return next(req)
+		}
+	}
+}
+
+//NewResponseInterceptorMiddleware create middleware from response interceptor
+func NewResponseInterceptorMiddleware(interceptor ResponseInterceptor) Middleware {
+	return func(next RequestHandlerFunc) RequestHandlerFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if err != nil {
+				return resp, err
+			}
+			if err = interceptor(req, resp); err != nil {
+				return resp, err
+			}
+			return resp, nil
+		}
+	}
+}
+
+//NewHeaderMiddleware create middleware which sets header value to every request
+func NewHeaderMiddleware(key string, value string) Middleware {
+	return NewRequestInterceptorMiddleware(func(req *http.Request) error {
+		req.Header.Set(key, value)
+		return nil
+	})
+}
+
+//NewUserAgentMiddleware create middleware which sets User-Agent header
+func NewUserAgentMiddleware(userAgent string) Middleware {
+	if userAgent == "" {
+		userAgent = HttpUserAgentDefault
+	}
+	return NewHeaderMiddleware("User-Agent", userAgent)
+}
+
+//NewRequestIdMiddleware create middleware which sets X-Request-Id header if it is not set yet
+func NewRequestIdMiddleware(generator func() string) Middleware {
+	if generator == nil {
+		generator = GenerateRequestId
+	}
+	return NewRequestInterceptorMiddleware(func(req *http.Request) error {
+		if req.Header.Get(HttpHeaderRequestId) == "" {
+			req.Header.Set(HttpHeaderRequestId, generator())
+		}
+		return nil
+	})
+}
+
+//GenerateRequestId Generate random request id
+func GenerateRequestId() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
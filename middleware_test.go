@@ -0,0 +1,123 @@
+package appstore
+
+import (
+	"context"
+	"errors"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"testing"
+)
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+	cfg      *Config
+	ctx      context.Context
+	testable *Transport
+}
+
+func (suite *MiddlewareTestSuite) SetupTest() {
+	suite.cfg = buildStubConfig()
+	suite.ctx = context.Background()
+	suite.testable = buildStubHttpTransport()
+	httpmock.Activate()
+}
+
+func (suite *MiddlewareTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+}
+
+func (suite *MiddlewareTestSuite) TestChainOrder() {
+	calls := []string{}
+	buildMiddleware := func(name string) Middleware {
+		return func(next RequestHandlerFunc) RequestHandlerFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+".before")
+				resp, err := next(req)
+				calls = append(calls, name+".after")
+				return resp, err
+			}
+		}
+	}
+	chain := MiddlewareChain{buildMiddleware("foo"), buildMiddleware("bar")}
+	handler := chain.Then(func(req *http.Request) (*http.Response, error) {
+		calls = append(calls, "handler")
+		return buildStubResponseFromString(http.StatusOK, ""), nil
+	})
+	req, _ := http.NewRequest(http.MethodGet, "https://github.com", nil)
+	_, err := handler(req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"foo.before", "bar.before", "handler", "bar.after", "foo.after"}, calls)
+}
+
+func (suite *MiddlewareTestSuite) TestUserAgentAndRequestId() {
+	var captured *http.Request
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/foo", func(req *http.Request) (*http.Response, error) {
+		captured = req
+		return httpmock.NewStringResponse(http.StatusOK, ""), nil
+	})
+	suite.testable.Use(NewUserAgentMiddleware("foo/1.0"), NewRequestIdMiddleware(func() string { return "bar" }))
+	_, err := suite.testable.Get(suite.ctx, "foo", nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "foo/1.0", captured.Header.Get("User-Agent"))
+	assert.Equal(suite.T(), "bar", captured.Header.Get(HttpHeaderRequestId))
+}
+
+func (suite *MiddlewareTestSuite) TestRequestIdByDefault() {
+	req, _ := http.NewRequest(http.MethodGet, "https://github.com", nil)
+	handler := NewRequestIdMiddleware(nil)(func(req *http.Request) (*http.Response, error) {
+		return nil, nil
+	})
+	_, _ = handler(req)
+	assert.Len(suite.T(), req.Header.Get(HttpHeaderRequestId), 32)
+}
+
+func (suite *MiddlewareTestSuite) TestRequestInterceptorError() {
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/foo", httpmock.NewStringResponder(http.StatusOK, ""))
+	suite.testable.Use(NewRequestInterceptorMiddleware(func(req *http.Request) error {
+		return errors.New("foo")
+	}))
+	resp, err := suite.testable.Get(suite.ctx, "foo", nil)
+	assert.Nil(suite.T(), resp)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), 0, httpmock.GetTotalCallCount())
+}
+
+func (suite *MiddlewareTestSuite) TestResponseInterceptor() {
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/foo", httpmock.NewStringResponder(http.StatusTeapot, ""))
+	status := 0
+	suite.testable.Use(NewResponseInterceptorMiddleware(func(req *http.Request, resp *http.Response) error {
+		status = resp.StatusCode
+		return nil
+	}))
+	resp, err := suite.testable.Get(suite.ctx, "foo", nil)
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), resp)
+	assert.Equal(suite.T(), http.StatusTeapot, status)
+}
+
+func (suite *MiddlewareTestSuite) TestNewRoundTripper() {
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/foo", func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(http.StatusOK, req.Header.Get("X-Foo")), nil
+	})
+	cl := &http.Client{Transport: NewRoundTripper(nil, NewHeaderMiddleware("X-Foo", "bar"))}
+	resp, err := cl.Get(suite.cfg.Uri + "/foo")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+}
+
+func (suite *MiddlewareTestSuite) TestClientUse() {
+	client := NewClientFromConfig(suite.cfg, &http.Client{})
+	client.Use(NewUserAgentMiddleware(""))
+	assert.Len(suite.T(), client.middlewares, 1)
+	err := client.Init()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), client.transport.middlewares, 1)
+	client.Use(NewRequestIdMiddleware(nil))
+	assert.Len(suite.T(), client.transport.middlewares, 2)
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(MiddlewareTestSuite))
+}
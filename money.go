@@ -0,0 +1,322 @@
+package appstore
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"reflect"
+	"strings"
+)
+
+//MoneyCurrencyTag struct tag with name of field containing currency of money field
+const MoneyCurrencyTag = "currency"
+
+//CurrencyMinorUnitsDefault const
+const CurrencyMinorUnitsDefault = 2
+
+//currencyMinorUnits ISO 4217 minor units of currencies which differ from CurrencyMinorUnitsDefault
+var currencyMinorUnits = map[string]int{
+	"BHD": 3,
+	"BIF": 0,
+	"CLP": 0,
+	"DJF": 0,
+	"GNF": 0,
+	"IQD": 3,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KMF": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"LYD": 3,
+	"OMR": 3,
+	"PYG": 0,
+	"RWF": 0,
+	"TND": 3,
+	"UGX": 0,
+	"VND": 0,
+	"VUV": 0,
+	"XAF": 0,
+	"XOF": 0,
+	"XPF": 0,
+}
+
+//CurrencyMinorUnits Get number of minor units of ISO currency
+func CurrencyMinorUnits(currency string) int {
+	if units, ok := currencyMinorUnits[strings.ToUpper(currency)]; ok {
+		return units
+	}
+	return CurrencyMinorUnitsDefault
+}
+
+//Money decimal amount with ISO currency
+type Money struct {
+	Amount   decimal.Decimal
+	Currency string
+	Null     bool //true when value was blank in CSV, null in JSON or NULL in database
+}
+
+//NewMoney Create new money
+func NewMoney(amount decimal.Decimal, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+//NewMoneyFromString Create new money from decimal string
+func NewMoneyFromString(amount string, currency string) (Money, error) {
+	d, err := decimal.NewFromString(strings.Trim(amount, " "))
+	if err != nil {
+		return Money{}, fmt.Errorf("NewMoneyFromString: %v", err)
+	}
+	return NewMoney(d, currency), nil
+}
+
+//SumMoney Sum amounts of same currency
+func SumMoney(items ...Money) (Money, error) {
+	total := Money{Amount: decimal.Zero}
+	var err error
+	for _, item := range items {
+		total, err = total.Add(item)
+		if err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+//Value Money get amount
+func (m *Money) Value() decimal.Decimal {
+	return m.Amount
+}
+
+//IsPresent Money value is present
+func (m *Money) IsPresent() bool {
+	return !m.Null
+}
+
+//Float64 Money get amount as float, precision may be lost
+func (m Money) Float64() float64 {
+	f, _ := m.Amount.Float64()
+	return f
+}
+
+//IsZero Money amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+//Add Add money of same currency, money without currency takes currency of other
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, fmt.Errorf("Money.Add: %v", err)
+	}
+	return Money{Amount: m.Amount.Add(other.Amount), Currency: currency}, nil
+}
+
+//Sub Subtract money of same currency, money without currency takes currency of other
+func (m Money) Sub(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, fmt.Errorf("Money.Sub: %v", err)
+	}
+	return Money{Amount: m.Amount.Sub(other.Amount), Currency: currency}, nil
+}
+
+//Mul Multiply amount by factor
+func (m Money) Mul(factor decimal.Decimal) Money {
+	return Money{Amount: m.Amount.Mul(factor), Currency: m.Currency}
+}
+
+//MulInt Multiply amount by quantity
+func (m Money) MulInt(quantity int) Money {
+	return m.Mul(decimal.New(int64(quantity), 0))
+}
+
+//Neg Negate amount
+func (m Money) Neg() Money {
+	return Money{Amount: m.Amount.Neg(), Currency: m.Currency}
+}
+
+//Round Round amount to currency minor units, half away from zero
+func (m Money) Round() Money {
+	return Money{Amount: m.Amount.Round(int32(CurrencyMinorUnits(m.Currency))), Currency: m.Currency}
+}
+
+//Equal Money amounts and currencies are equal
+func (m Money) Equal(other Money) bool {
+	return m.Currency == other.Currency && m.Amount.Equal(other.Amount)
+}
+
+//String Format money as amount with currency
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.format()
+	}
+	return m.format() + " " + m.Currency
+}
+
+//format Format amount with at least currency minor units, digits are never dropped
+func (m Money) format() string {
+	places := -int(m.Amount.Exponent())
+	if m.Currency != "" && places < CurrencyMinorUnits(m.Currency) {
+		places = CurrencyMinorUnits(m.Currency)
+	}
+	if places < 0 {
+		places = 0
+	}
+	return m.Amount.StringFixed(int32(places))
+}
+
+//commonCurrency Get currency of both operands
+func (m Money) commonCurrency(other Money) (string, error) {
+	switch {
+	case m.Currency == "":
+		return other.Currency, nil
+	case other.Currency == "" || strings.EqualFold(m.Currency, other.Currency):
+		return m.Currency, nil
+	}
+	return "", fmt.Errorf("currency mismatch %s and %s", m.Currency, other.Currency)
+}
+
+//bindCurrency Set currency and pad amount to currency minor units
+func (m *Money) bindCurrency(currency string) {
+	m.Currency = strings.Trim(currency, " ")
+	*m = m.pad()
+}
+
+//pad Pad amount to currency minor units, digits are never dropped
+func (m Money) pad() Money {
+	if m.Null || m.Currency == "" {
+		return m
+	}
+	places := int32(CurrencyMinorUnits(m.Currency))
+	if -m.Amount.Exponent() < places {
+		m.Amount = m.Amount.Round(places)
+	}
+	return m
+}
+
+//UnmarshalCSV Money UnmarshalCSV, currency is bound from report row
+func (m *Money) UnmarshalCSV(csv string) error {
+	csv = strings.Trim(csv, " ")
+	*m = Money{Amount: decimal.Zero, Currency: m.Currency, Null: csv == ""}
+	if m.Null {
+		return nil
+	}
+	var err error
+	m.Amount, err = decimal.NewFromString(csv)
+	if err != nil {
+		return fmt.Errorf("Money.UnmarshalCSV Parse decimal: %v", err)
+	}
+	return nil
+}
+
+//MarshalCSV Money MarshalCSV
+func (m *Money) MarshalCSV() (string, error) {
+	if m.Null {
+		return "", nil
+	}
+	return m.format(), nil
+}
+
+//MarshalJSON Money MarshalJSON, amount is marshalled as exact JSON number
+func (m *Money) MarshalJSON() ([]byte, error) {
+	if m.Null {
+		return jsonNull, nil
+	}
+	return []byte(m.format()), nil
+}
+
+//UnmarshalJSON Money UnmarshalJSON, accepts JSON number or string
+func (m *Money) UnmarshalJSON(data []byte) error {
+	*m = Money{Amount: decimal.Zero, Currency: m.Currency, Null: isJSONNull(data)}
+	if m.Null {
+		return nil
+	}
+	var number json.Number
+	err := json.Unmarshal(data, &number)
+	if err != nil {
+		return errors.New("Money.UnmarshalJSON: " + err.Error())
+	}
+	m.Amount, err = decimal.NewFromString(number.String())
+	if err != nil {
+		return fmt.Errorf("Money.UnmarshalJSON Parse decimal: %v", err)
+	}
+	return nil
+}
+
+//Scan Money implementation of sql.Scanner, currency is kept
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*m = Money{Amount: decimal.Zero, Currency: m.Currency, Null: true}
+		return nil
+	case float64:
+		*m = Money{Amount: decimal.NewFromFloat(v), Currency: m.Currency}
+		return nil
+	case int64:
+		*m = Money{Amount: decimal.New(v, 0), Currency: m.Currency}
+		return nil
+	}
+	str, ok := scanString(src)
+	if !ok {
+		return fmt.Errorf("Money.Scan: unsupported type %T", src)
+	}
+	err := m.UnmarshalCSV(str)
+	if err != nil {
+		return fmt.Errorf("Money.Scan: %v", err)
+	}
+	return nil
+}
+
+//Valuer Money driver.Valuer, amount is stored as decimal string
+func (m *Money) Valuer() driver.Valuer {
+	return driverValuerFunc(func() (driver.Value, error) {
+		if m.Null {
+			return nil, nil
+		}
+		return m.format(), nil
+	})
+}
+
+//bindMoneyCurrencies Set currencies of money fields of report row or slice of rows from fields named in MoneyCurrencyTag
+func bindMoneyCurrencies(in interface{}) {
+	rv := reflect.Indirect(reflect.ValueOf(in))
+	switch rv.Kind() {
+	case reflect.Slice:
+		for i := 0; i < rv.Len(); i++ {
+			row := rv.Index(i)
+			if row.Kind() == reflect.Ptr {
+				if row.IsNil() {
+					continue
+				}
+				row = row.Elem()
+			}
+			bindRowMoneyCurrencies(row)
+		}
+	case reflect.Struct:
+		bindRowMoneyCurrencies(rv)
+	}
+}
+
+//bindRowMoneyCurrencies Set currencies of money fields of report row
+func bindRowMoneyCurrencies(row reflect.Value) {
+	if row.Kind() != reflect.Struct || !row.CanAddr() {
+		return
+	}
+	rowType := row.Type()
+	for i := 0; i < rowType.NumField(); i++ {
+		name := rowType.Field(i).Tag.Get(MoneyCurrencyTag)
+		if name == "" {
+			continue
+		}
+		money, ok := row.Field(i).Addr().Interface().(*Money)
+		currency := row.FieldByName(name)
+		if !ok || !currency.IsValid() || currency.Kind() != reflect.String {
+			continue
+		}
+		money.bindCurrency(currency.String())
+	}
+}
@@ -0,0 +1,189 @@
+package appstore
+
+import (
+	"encoding/json"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"testing"
+)
+
+type MoneyTestSuite struct {
+	suite.Suite
+	testable *Money
+}
+
+func (suite *MoneyTestSuite) SetupTest() {
+	suite.testable = &Money{}
+}
+
+func (suite *MoneyTestSuite) TestCurrencyMinorUnits() {
+	assert.Equal(suite.T(), 2, CurrencyMinorUnits("USD"))
+	assert.Equal(suite.T(), 0, CurrencyMinorUnits("jpy"))
+	assert.Equal(suite.T(), 3, CurrencyMinorUnits("KWD"))
+}
+
+func (suite *MoneyTestSuite) TestNewMoneyFromString() {
+	result, err := NewMoneyFromString("0.1", "USD")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "0.10 USD", result.String())
+	_, err = NewMoneyFromString("foo", "USD")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *MoneyTestSuite) TestSumIsExact() {
+	items := make([]Money, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		m, _ := NewMoneyFromString("0.1", "USD")
+		items = append(items, m)
+	}
+	total, err := SumMoney(items...)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "100.00 USD", total.String())
+}
+
+func (suite *MoneyTestSuite) TestAddCurrencyMismatch() {
+	usd, _ := NewMoneyFromString("1", "USD")
+	eur, _ := NewMoneyFromString("1", "EUR")
+	_, err := usd.Add(eur)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "Money.Add: currency mismatch USD and EUR", err.Error())
+	_, err = usd.Sub(eur)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *MoneyTestSuite) TestArithmetic() {
+	price, _ := NewMoneyFromString("4.49", "USD")
+	assert.Equal(suite.T(), "13.47 USD", price.MulInt(3).String())
+	assert.Equal(suite.T(), "-4.49 USD", price.Neg().String())
+	diff, _ := price.Sub(price)
+	assert.True(suite.T(), diff.IsZero())
+	assert.Equal(suite.T(), 4.49, price.Float64())
+}
+
+func (suite *MoneyTestSuite) TestRound() {
+	usd, _ := NewMoneyFromString("3.14159", "USD")
+	assert.Equal(suite.T(), "3.14 USD", usd.Round().String())
+	jpy, _ := NewMoneyFromString("120.5", "JPY")
+	assert.Equal(suite.T(), "121 JPY", jpy.Round().String())
+	kwd, _ := NewMoneyFromString("1.23456", "KWD")
+	assert.Equal(suite.T(), "1.235 KWD", kwd.Round().String())
+}
+
+func (suite *MoneyTestSuite) TestUnmarshalCSV() {
+	err := suite.testable.UnmarshalCSV("209.30")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), suite.testable.Value().Equal(decimal.RequireFromString("209.3")))
+	result, _ := suite.testable.MarshalCSV()
+	assert.Equal(suite.T(), "209.30", result)
+	err = suite.testable.UnmarshalCSV("foo")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *MoneyTestSuite) TestNull() {
+	_ = suite.testable.UnmarshalCSV(" ")
+	assert.False(suite.T(), suite.testable.IsPresent())
+	result, _ := suite.testable.MarshalCSV()
+	assert.Equal(suite.T(), "", result)
+	data, _ := suite.testable.MarshalJSON()
+	assert.Equal(suite.T(), []byte(`null`), data)
+	value, _ := suite.testable.Valuer().Value()
+	assert.Nil(suite.T(), value)
+}
+
+func (suite *MoneyTestSuite) TestJSON() {
+	err := suite.testable.UnmarshalJSON([]byte(`"2065.10"`))
+	assert.NoError(suite.T(), err)
+	data, _ := suite.testable.MarshalJSON()
+	assert.Equal(suite.T(), []byte(`2065.10`), data)
+	err = suite.testable.UnmarshalJSON([]byte(`12.5`))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "12.5", suite.testable.String())
+	err = suite.testable.UnmarshalJSON([]byte(`true`))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *MoneyTestSuite) TestScan() {
+	suite.testable.Currency = "USD"
+	assert.NoError(suite.T(), suite.testable.Scan("4.49"))
+	assert.Equal(suite.T(), "4.49 USD", suite.testable.String())
+	value, _ := suite.testable.Valuer().Value()
+	assert.Equal(suite.T(), "4.49", value)
+	assert.NoError(suite.T(), suite.testable.Scan(int64(5)))
+	assert.Equal(suite.T(), "5.00 USD", suite.testable.String())
+	assert.NoError(suite.T(), suite.testable.Scan(nil))
+	assert.False(suite.T(), suite.testable.IsPresent())
+	assert.Error(suite.T(), suite.testable.Scan(true))
+}
+
+func (suite *MoneyTestSuite) TestReportJSONRoundTrip() {
+	reportData, _ := ioutil.ReadFile("stubs/reports/sales/sales.tsv")
+	reports := []*SalesReport{}
+	_ = UnmarshalCSV(reportData, &reports)
+	data, _ := json.Marshal(reports)
+	restored := []*SalesReport{}
+	err := json.Unmarshal(data, &restored)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), reports, restored)
+	assert.Equal(suite.T(), "RUB", restored[0].DeveloperProceeds.Currency)
+}
+
+func (suite *MoneyTestSuite) TestSubscriptionsReportJSONRoundTrip() {
+	report := &SubscriptionsReport{
+		CustomerPrice:     NewMoney(decimal.RequireFromString("120.4"), "JPY"),
+		CustomerCurrency:  "JPY",
+		DeveloperProceeds: NewMoney(decimal.RequireFromString("84.3"), "JPY"),
+		ProceedsCurrency:  "JPY",
+	}
+	data, _ := json.Marshal(report)
+	restored := &SubscriptionsReport{}
+	assert.NoError(suite.T(), json.Unmarshal(data, restored))
+	assert.Equal(suite.T(), "120 JPY", restored.CustomerPrice.Round().String())
+	assert.Equal(suite.T(), "84 JPY", restored.DeveloperProceeds.Round().String())
+}
+
+func (suite *MoneyTestSuite) TestSubscribersReportJSONRoundTrip() {
+	report := &SubscribersReport{
+		CustomerPrice:     NewMoney(decimal.RequireFromString("120.4"), "JPY"),
+		CustomerCurrency:  "JPY",
+		DeveloperProceeds: NewMoney(decimal.RequireFromString("84.3"), "JPY"),
+		ProceedsCurrency:  "JPY",
+	}
+	data, _ := json.Marshal(report)
+	restored := &SubscribersReport{}
+	assert.NoError(suite.T(), json.Unmarshal(data, restored))
+	assert.Equal(suite.T(), "120 JPY", restored.CustomerPrice.Round().String())
+	assert.Equal(suite.T(), "84 JPY", restored.DeveloperProceeds.Round().String())
+}
+
+func (suite *MoneyTestSuite) TestFinancialReportJSONRoundTrip() {
+	report := &FinancialReport{
+		PartnerShare:         NewMoney(decimal.RequireFromString("84.3"), "JPY"),
+		ExtendedPartnerShare: NewMoney(decimal.RequireFromString("168.6"), "JPY"),
+		PartnerShareCurrency: "JPY",
+		CustomerPrice:        NewMoney(decimal.RequireFromString("120.4"), "JPY"),
+		CustomerCurrency:     "JPY",
+	}
+	data, _ := json.Marshal(report)
+	restored := &FinancialReport{}
+	assert.NoError(suite.T(), json.Unmarshal(data, restored))
+	assert.Equal(suite.T(), "84 JPY", restored.PartnerShare.Round().String())
+	assert.Equal(suite.T(), "169 JPY", restored.ExtendedPartnerShare.Round().String())
+	assert.Equal(suite.T(), "120 JPY", restored.CustomerPrice.Round().String())
+}
+
+func (suite *MoneyTestSuite) TestFinancialReportReconciliation() {
+	reportData, _ := ioutil.ReadFile("stubs/reports/finances/financial.tsv")
+	reports := []*FinancialReport{}
+	_ = UnmarshalCSVWithFilterLines(reportData, &reports)
+	total := Money{}
+	for _, report := range reports {
+		total, _ = total.Add(report.ExtendedPartnerShare)
+	}
+	assert.Equal(suite.T(), "34.65 USD", total.String())
+}
+
+func TestMoneyTestSuite(t *testing.T) {
+	suite.Run(t, new(MoneyTestSuite))
+}
@@ -0,0 +1,192 @@
+package appstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//FieldNaming field naming of NDJSON encoder
+type FieldNaming string
+
+const (
+	//FieldNamingSnake const, json tags of report rows, e.g. developer_proceeds
+	FieldNamingSnake FieldNaming = "snake"
+	//FieldNamingCamel const, lower camel case of json tags, e.g. developerProceeds
+	FieldNamingCamel FieldNaming = "camel"
+)
+
+//name Get field name of snake case name
+func (n FieldNaming) name(snake string) string {
+	if n != FieldNamingCamel {
+		return snake
+	}
+	parts := strings.Split(snake, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+//ReportMetadata metadata of report file written to NDJSON envelope
+type ReportMetadata struct {
+	VendorNumber string
+	ReportType   string
+	ReportDate   time.Time
+	Version      string //omitted when empty
+}
+
+//ndjsonField json field of report row
+type ndjsonField struct {
+	index int
+	name  string //snake case name from json tag
+}
+
+//NDJSONEncoder streaming encoder of report rows to newline delimited JSON, one object per line.
+//Fields are written in struct order with names of json tags, or their camel case
+type NDJSONEncoder struct {
+	Fields   []string        //json tag names of fields to write in order, all fields when empty
+	Naming   FieldNaming     //field naming, FieldNamingSnake by default
+	Metadata *ReportMetadata //wrap every row in envelope with report metadata when set
+	out      io.Writer
+	fields   map[reflect.Type][]ndjsonField
+}
+
+//NewNDJSONEncoder Create new NDJSON encoder
+func NewNDJSONEncoder(out io.Writer) *NDJSONEncoder {
+	return &NDJSONEncoder{Naming: FieldNamingSnake, out: out, fields: make(map[reflect.Type][]ndjsonField)}
+}
+
+//Encode Write report row line, row is struct or pointer to struct
+func (e *NDJSONEncoder) Encode(row interface{}) error {
+	rv := reflect.ValueOf(row)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return fmt.Errorf("NDJSONEncoder.Encode: nil row")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("NDJSONEncoder.Encode: struct row expected, got %v", rv.Kind())
+	}
+	fields, err := e.rowFields(rv.Type())
+	if err != nil {
+		return fmt.Errorf("NDJSONEncoder.Encode: %v", err)
+	}
+	//values are marshaled by address, MarshalJSON of custom types has pointer receiver
+	addressable := reflect.New(rv.Type()).Elem()
+	addressable.Set(rv)
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	if e.Metadata != nil {
+		e.writeMetadata(&buf)
+		buf.WriteString(`,"` + e.Naming.name("row") + `":{`)
+	}
+	for i, field := range fields {
+		value, err := json.Marshal(addressable.Field(field.index).Addr().Interface())
+		if err != nil {
+			return fmt.Errorf("NDJSONEncoder.Encode field %s: %v", field.name, err)
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(e.Naming.name(field.name))
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	if e.Metadata != nil {
+		buf.WriteByte('}')
+	}
+	buf.WriteString("}\n")
+	if _, err = e.out.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("NDJSONEncoder.Encode: %v", err)
+	}
+	return nil
+}
+
+//EncodeRows Write slice of report rows, nil rows are skipped
+func (e *NDJSONEncoder) EncodeRows(rows interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(rows))
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("NDJSONEncoder.EncodeRows: slice expected, got %v", rv.Kind())
+	}
+	for i := 0; i < rv.Len(); i++ {
+		row := rv.Index(i)
+		if row.Kind() == reflect.Ptr && row.IsNil() {
+			continue
+		}
+		if err := e.Encode(row.Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//writeMetadata Write envelope metadata members
+func (e *NDJSONEncoder) writeMetadata(buf *bytes.Buffer) {
+	members := [][2]string{
+		{"vendor_number", e.Metadata.VendorNumber},
+		{"report_type", e.Metadata.ReportType},
+		{"report_date", e.Metadata.ReportDate.Format(CustomDateFormatDefault)},
+		{"version", e.Metadata.Version},
+	}
+	for i, member := range members {
+		if member[0] == "version" && member[1] == "" {
+			continue
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(e.Naming.name(member[0]))
+		value, _ := json.Marshal(member[1])
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+}
+
+//rowFields Get fields of row type to write, selected fields are validated once per type
+func (e *NDJSONEncoder) rowFields(rowType reflect.Type) ([]ndjsonField, error) {
+	if fields, ok := e.fields[rowType]; ok {
+		return fields, nil
+	}
+	all := make([]ndjsonField, 0, rowType.NumField())
+	byName := make(map[string]ndjsonField, rowType.NumField())
+	for i := 0; i < rowType.NumField(); i++ {
+		field := rowType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		all = append(all, ndjsonField{index: i, name: name})
+		byName[name] = all[len(all)-1]
+	}
+	fields := all
+	if len(e.Fields) > 0 {
+		fields = make([]ndjsonField, 0, len(e.Fields))
+		for _, name := range e.Fields {
+			field, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("%s has no field %s", rowType.Name(), name)
+			}
+			fields = append(fields, field)
+		}
+	}
+	e.fields[rowType] = fields
+	return fields, nil
+}
+
+//WriteNDJSON write report rows slice to out as newline delimited JSON
+func WriteNDJSON(out io.Writer, rows interface{}) error {
+	return NewNDJSONEncoder(out).EncodeRows(rows)
+}
@@ -0,0 +1,103 @@
+package appstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ndjsonFailingWriter struct{}
+
+func (w *ndjsonFailingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("foo")
+}
+
+type NDJSONEncoderTestSuite struct {
+	suite.Suite
+	buf      *bytes.Buffer
+	testable *NDJSONEncoder
+	rows     []*SubscriptionsOffersRedemptionReport
+}
+
+func (suite *NDJSONEncoderTestSuite) SetupTest() {
+	suite.buf = &bytes.Buffer{}
+	suite.testable = NewNDJSONEncoder(suite.buf)
+	suite.rows = []*SubscriptionsOffersRedemptionReport{
+		{
+			Date:        CustomDate{Date: time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC)},
+			AppName:     "Foo",
+			AppAppleID:  CustomInteger{Integer: 42},
+			Territory:   "US",
+			Redemptions: CustomInteger{Null: true},
+		},
+		nil,
+		{AppName: "Bar", Date: CustomDate{Null: true}},
+	}
+}
+
+func (suite *NDJSONEncoderTestSuite) lines() []string {
+	return strings.Split(strings.TrimSuffix(suite.buf.String(), "\n"), "\n")
+}
+
+func (suite *NDJSONEncoderTestSuite) TestEncodeRows() {
+	assert.NoError(suite.T(), suite.testable.EncodeRows(suite.rows))
+	lines := suite.lines()
+	assert.Len(suite.T(), lines, 2)
+	assert.Equal(suite.T(), `{"date":"2020-05-05","app_name":"Foo","app_apple_id":42,"subscription_name":"","subscription_apple_id":0,"offer_reference_name":"","offer_code":"","territory":"US","redemptions":null}`, lines[0])
+	row := &SubscriptionsOffersRedemptionReport{}
+	assert.NoError(suite.T(), json.Unmarshal([]byte(lines[1]), row))
+	assert.Equal(suite.T(), "Bar", row.AppName)
+	assert.True(suite.T(), row.Date.Null)
+}
+
+func (suite *NDJSONEncoderTestSuite) TestEncodeFieldsCamel() {
+	suite.testable.Fields = []string{"territory", "app_apple_id"}
+	suite.testable.Naming = FieldNamingCamel
+	assert.NoError(suite.T(), suite.testable.Encode(*suite.rows[0]))
+	assert.Equal(suite.T(), `{"territory":"US","appAppleId":42}`+"\n", suite.buf.String())
+}
+
+func (suite *NDJSONEncoderTestSuite) TestEncodeMetadata() {
+	suite.testable.Fields = []string{"app_name"}
+	suite.testable.Metadata = &ReportMetadata{VendorNumber: "123", ReportType: "SUBSCRIPTION_OFFER_CODE_REDEMPTION", ReportDate: time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC), Version: "1_0"}
+	assert.NoError(suite.T(), suite.testable.Encode(suite.rows[0]))
+	suite.testable.Naming = FieldNamingCamel
+	suite.testable.Metadata.Version = ""
+	assert.NoError(suite.T(), suite.testable.Encode(suite.rows[0]))
+	lines := suite.lines()
+	assert.Equal(suite.T(), `{"vendor_number":"123","report_type":"SUBSCRIPTION_OFFER_CODE_REDEMPTION","report_date":"2020-05-05","version":"1_0","row":{"app_name":"Foo"}}`, lines[0])
+	assert.Equal(suite.T(), `{"vendorNumber":"123","reportType":"SUBSCRIPTION_OFFER_CODE_REDEMPTION","reportDate":"2020-05-05","row":{"appName":"Foo"}}`, lines[1])
+}
+
+func (suite *NDJSONEncoderTestSuite) TestEncodeMoney() {
+	assert.NoError(suite.T(), WriteNDJSON(suite.buf, []SalesReport{{DeveloperProceeds: NewMoney(decimal.RequireFromString("0.70"), "USD")}}))
+	assert.Contains(suite.T(), suite.buf.String(), `"developer_proceeds":0.7`)
+	assert.True(suite.T(), json.Valid(suite.buf.Bytes()))
+}
+
+func (suite *NDJSONEncoderTestSuite) TestEncodeInvalid() {
+	assert.Error(suite.T(), suite.testable.Encode(nil))
+	assert.Error(suite.T(), suite.testable.Encode((*SalesReport)(nil)))
+	assert.Error(suite.T(), suite.testable.Encode("foo"))
+	assert.Error(suite.T(), suite.testable.EncodeRows(SalesReport{}))
+	suite.testable.Fields = []string{"foo"}
+	assert.Error(suite.T(), suite.testable.Encode(suite.rows[0]))
+	assert.Error(suite.T(), NewNDJSONEncoder(&ndjsonFailingWriter{}).Encode(suite.rows[0]))
+	assert.Empty(suite.T(), suite.buf.String())
+}
+
+func (suite *NDJSONEncoderTestSuite) TestFieldNaming() {
+	assert.Equal(suite.T(), "developerProceeds", FieldNamingCamel.name("developer_proceeds"))
+	assert.Equal(suite.T(), "sku", FieldNamingCamel.name("sku"))
+	assert.Equal(suite.T(), "developer_proceeds", FieldNamingSnake.name("developer_proceeds"))
+}
+
+func TestNDJSONEncoderTestSuite(t *testing.T) {
+	suite.Run(t, new(NDJSONEncoderTestSuite))
+}
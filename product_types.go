@@ -0,0 +1,310 @@
+package appstore
+
+import (
+	"strings"
+)
+
+//ProductTypeIdentifier Type of transaction of sales and financial report rows.
+// .see https://help.apple.com/app-store-connect/#/dev63c95e436
+type ProductTypeIdentifier string
+
+const (
+	//ProductTypeIdentifierIPhoneApp Free or paid app, iPhone and iPod touch (iOS)
+	ProductTypeIdentifierIPhoneApp ProductTypeIdentifier = "1"
+	//ProductTypeIdentifierAppBundle App bundle (iOS)
+	ProductTypeIdentifierAppBundle ProductTypeIdentifier = "1-B"
+	//ProductTypeIdentifierCustomIPhoneApp Paid app, custom iPhone and iPod touch (iOS)
+	ProductTypeIdentifierCustomIPhoneApp ProductTypeIdentifier = "1E"
+	//ProductTypeIdentifierCustomIPadApp Paid app, custom iPad (iOS)
+	ProductTypeIdentifierCustomIPadApp ProductTypeIdentifier = "1EP"
+	//ProductTypeIdentifierCustomUniversalApp Paid app, custom universal (iOS)
+	ProductTypeIdentifierCustomUniversalApp ProductTypeIdentifier = "1EU"
+	//ProductTypeIdentifierUniversalApp Free or paid app, universal, excluding tvOS (iOS)
+	ProductTypeIdentifierUniversalApp ProductTypeIdentifier = "1F"
+	//ProductTypeIdentifierIPadApp Free or paid app, iPad (iOS)
+	ProductTypeIdentifierIPadApp ProductTypeIdentifier = "1T"
+	//ProductTypeIdentifierMacApp Free or paid app, Mac app
+	ProductTypeIdentifierMacApp ProductTypeIdentifier = "F1"
+	//ProductTypeIdentifierMacAppBundle Mac app bundle
+	ProductTypeIdentifierMacAppBundle ProductTypeIdentifier = "F1-B"
+	//ProductTypeIdentifierIPhoneUpdate Update, iPhone and iPod touch (iOS)
+	ProductTypeIdentifierIPhoneUpdate ProductTypeIdentifier = "7"
+	//ProductTypeIdentifierUniversalUpdate Update, universal, excluding tvOS (iOS)
+	ProductTypeIdentifierUniversalUpdate ProductTypeIdentifier = "7F"
+	//ProductTypeIdentifierIPadUpdate Update, iPad (iOS)
+	ProductTypeIdentifierIPadUpdate ProductTypeIdentifier = "7T"
+	//ProductTypeIdentifierMacUpdate Update, Mac app
+	ProductTypeIdentifierMacUpdate ProductTypeIdentifier = "F7"
+	//ProductTypeIdentifierIPhoneRedownload Redownload of iPhone-only, or iOS and tvOS app
+	ProductTypeIdentifierIPhoneRedownload ProductTypeIdentifier = "3"
+	//ProductTypeIdentifierUniversalRedownload Redownload of universal app, excluding tvOS
+	ProductTypeIdentifierUniversalRedownload ProductTypeIdentifier = "3F"
+	//ProductTypeIdentifierIPadRedownload Redownload of iPad-only app
+	ProductTypeIdentifierIPadRedownload ProductTypeIdentifier = "3T"
+	//ProductTypeIdentifierMacRedownload Redownload of Mac app
+	ProductTypeIdentifierMacRedownload ProductTypeIdentifier = "F3"
+	//ProductTypeIdentifierMacInAppPurchase In-App Purchase, Mac app
+	ProductTypeIdentifierMacInAppPurchase ProductTypeIdentifier = "FI1"
+	//ProductTypeIdentifierInAppPurchase In-App Purchase (iOS)
+	ProductTypeIdentifierInAppPurchase ProductTypeIdentifier = "IA1"
+	//ProductTypeIdentifierInAppPurchaseMac In-App Purchase (Mac)
+	ProductTypeIdentifierInAppPurchaseMac ProductTypeIdentifier = "IA1-M"
+	//ProductTypeIdentifierInAppSubscription Non-renewing subscription In-App Purchase (iOS)
+	ProductTypeIdentifierInAppSubscription ProductTypeIdentifier = "IA9"
+	//ProductTypeIdentifierInAppSubscriptionMac Non-renewing subscription In-App Purchase (Mac)
+	ProductTypeIdentifierInAppSubscriptionMac ProductTypeIdentifier = "IA9-M"
+	//ProductTypeIdentifierInAppAutoRenewableSubscription Auto-renewable subscription In-App Purchase (iOS)
+	ProductTypeIdentifierInAppAutoRenewableSubscription ProductTypeIdentifier = "IAY"
+	//ProductTypeIdentifierInAppAutoRenewableSubscriptionMac Auto-renewable subscription In-App Purchase (Mac)
+	ProductTypeIdentifierInAppAutoRenewableSubscriptionMac ProductTypeIdentifier = "IAY-M"
+	//ProductTypeIdentifierInAppFreeSubscription Free subscription In-App Purchase (iOS)
+	ProductTypeIdentifierInAppFreeSubscription ProductTypeIdentifier = "IAC"
+	//ProductTypeIdentifierInAppFreeSubscriptionMac Free subscription In-App Purchase (Mac)
+	ProductTypeIdentifierInAppFreeSubscriptionMac ProductTypeIdentifier = "IAC-M"
+)
+
+//ProductKind kind of transaction
+type ProductKind string
+
+const (
+	//ProductKindUnknown const
+	ProductKindUnknown ProductKind = "unknown"
+	//ProductKindApp const, first time download of free or paid app
+	ProductKindApp ProductKind = "app"
+	//ProductKindBundle const, app bundle purchase
+	ProductKindBundle ProductKind = "bundle"
+	//ProductKindUpdate const
+	ProductKindUpdate ProductKind = "update"
+	//ProductKindRedownload const
+	ProductKindRedownload ProductKind = "redownload"
+	//ProductKindInAppPurchase const
+	ProductKindInAppPurchase ProductKind = "in_app_purchase"
+)
+
+//InAppPurchaseType type of In-App Purchase
+type InAppPurchaseType string
+
+const (
+	//InAppPurchaseTypeNone const, transaction is not In-App Purchase
+	InAppPurchaseTypeNone InAppPurchaseType = ""
+	//InAppPurchaseTypePurchase const, consumable or non-consumable
+	InAppPurchaseTypePurchase InAppPurchaseType = "purchase"
+	//InAppPurchaseTypeNonRenewingSubscription const
+	InAppPurchaseTypeNonRenewingSubscription InAppPurchaseType = "non_renewing_subscription"
+	//InAppPurchaseTypeAutoRenewableSubscription const
+	InAppPurchaseTypeAutoRenewableSubscription InAppPurchaseType = "auto_renewable_subscription"
+	//InAppPurchaseTypeFreeSubscription const
+	InAppPurchaseTypeFreeSubscription InAppPurchaseType = "free_subscription"
+)
+
+//Platform app platform
+type Platform string
+
+const (
+	//PlatformUnknown const
+	PlatformUnknown Platform = ""
+	//PlatformIOS const
+	PlatformIOS Platform = "iOS"
+	//PlatformMacOS const
+	PlatformMacOS Platform = "macOS"
+	//PlatformTVOS const
+	PlatformTVOS Platform = "tvOS"
+	//PlatformVisionOS const
+	PlatformVisionOS Platform = "visionOS"
+)
+
+//DeviceFamily device family of app
+type DeviceFamily string
+
+const (
+	//DeviceFamilyUnknown const
+	DeviceFamilyUnknown DeviceFamily = ""
+	//DeviceFamilyIPhone const, iPhone and iPod touch
+	DeviceFamilyIPhone DeviceFamily = "iphone"
+	//DeviceFamilyIPad const
+	DeviceFamilyIPad DeviceFamily = "ipad"
+	//DeviceFamilyUniversal const
+	DeviceFamilyUniversal DeviceFamily = "universal"
+	//DeviceFamilyMac const
+	DeviceFamilyMac DeviceFamily = "mac"
+)
+
+//productType classification of product type identifier
+type productType struct {
+	kind     ProductKind
+	platform Platform
+	family   DeviceFamily
+	iap      InAppPurchaseType
+	custom   bool
+}
+
+//productTypes Apple's product type identifiers table
+var productTypes = map[ProductTypeIdentifier]*productType{
+	ProductTypeIdentifierIPhoneApp:                         {kind: ProductKindApp, platform: PlatformIOS, family: DeviceFamilyIPhone},
+	ProductTypeIdentifierAppBundle:                         {kind: ProductKindBundle, platform: PlatformIOS},
+	ProductTypeIdentifierCustomIPhoneApp:                   {kind: ProductKindApp, platform: PlatformIOS, family: DeviceFamilyIPhone, custom: true},
+	ProductTypeIdentifierCustomIPadApp:                     {kind: ProductKindApp, platform: PlatformIOS, family: DeviceFamilyIPad, custom: true},
+	ProductTypeIdentifierCustomUniversalApp:                {kind: ProductKindApp, platform: PlatformIOS, family: DeviceFamilyUniversal, custom: true},
+	ProductTypeIdentifierUniversalApp:                      {kind: ProductKindApp, platform: PlatformIOS, family: DeviceFamilyUniversal},
+	ProductTypeIdentifierIPadApp:                           {kind: ProductKindApp, platform: PlatformIOS, family: DeviceFamilyIPad},
+	ProductTypeIdentifierMacApp:                            {kind: ProductKindApp, platform: PlatformMacOS, family: DeviceFamilyMac},
+	ProductTypeIdentifierMacAppBundle:                      {kind: ProductKindBundle, platform: PlatformMacOS, family: DeviceFamilyMac},
+	ProductTypeIdentifierIPhoneUpdate:                      {kind: ProductKindUpdate, platform: PlatformIOS, family: DeviceFamilyIPhone},
+	ProductTypeIdentifierUniversalUpdate:                   {kind: ProductKindUpdate, platform: PlatformIOS, family: DeviceFamilyUniversal},
+	ProductTypeIdentifierIPadUpdate:                        {kind: ProductKindUpdate, platform: PlatformIOS, family: DeviceFamilyIPad},
+	ProductTypeIdentifierMacUpdate:                         {kind: ProductKindUpdate, platform: PlatformMacOS, family: DeviceFamilyMac},
+	ProductTypeIdentifierIPhoneRedownload:                  {kind: ProductKindRedownload, platform: PlatformIOS, family: DeviceFamilyIPhone},
+	ProductTypeIdentifierUniversalRedownload:               {kind: ProductKindRedownload, platform: PlatformIOS, family: DeviceFamilyUniversal},
+	ProductTypeIdentifierIPadRedownload:                    {kind: ProductKindRedownload, platform: PlatformIOS, family: DeviceFamilyIPad},
+	ProductTypeIdentifierMacRedownload:                     {kind: ProductKindRedownload, platform: PlatformMacOS, family: DeviceFamilyMac},
+	ProductTypeIdentifierMacInAppPurchase:                  {kind: ProductKindInAppPurchase, platform: PlatformMacOS, family: DeviceFamilyMac, iap: InAppPurchaseTypePurchase},
+	ProductTypeIdentifierInAppPurchase:                     {kind: ProductKindInAppPurchase, platform: PlatformIOS, iap: InAppPurchaseTypePurchase},
+	ProductTypeIdentifierInAppPurchaseMac:                  {kind: ProductKindInAppPurchase, platform: PlatformMacOS, family: DeviceFamilyMac, iap: InAppPurchaseTypePurchase},
+	ProductTypeIdentifierInAppSubscription:                 {kind: ProductKindInAppPurchase, platform: PlatformIOS, iap: InAppPurchaseTypeNonRenewingSubscription},
+	ProductTypeIdentifierInAppSubscriptionMac:              {kind: ProductKindInAppPurchase, platform: PlatformMacOS, family: DeviceFamilyMac, iap: InAppPurchaseTypeNonRenewingSubscription},
+	ProductTypeIdentifierInAppAutoRenewableSubscription:    {kind: ProductKindInAppPurchase, platform: PlatformIOS, iap: InAppPurchaseTypeAutoRenewableSubscription},
+	ProductTypeIdentifierInAppAutoRenewableSubscriptionMac: {kind: ProductKindInAppPurchase, platform: PlatformMacOS, family: DeviceFamilyMac, iap: InAppPurchaseTypeAutoRenewableSubscription},
+	ProductTypeIdentifierInAppFreeSubscription:             {kind: ProductKindInAppPurchase, platform: PlatformIOS, iap: InAppPurchaseTypeFreeSubscription},
+	ProductTypeIdentifierInAppFreeSubscriptionMac:          {kind: ProductKindInAppPurchase, platform: PlatformMacOS, family: DeviceFamilyMac, iap: InAppPurchaseTypeFreeSubscription},
+}
+
+//info Get classification of product type identifier
+func (p ProductTypeIdentifier) info() *productType {
+	if info, ok := productTypes[ProductTypeIdentifier(strings.TrimSpace(string(p)))]; ok {
+		return info
+	}
+	return &productType{kind: ProductKindUnknown}
+}
+
+//IsKnown Product type identifier is in Apple's table
+func (p ProductTypeIdentifier) IsKnown() bool {
+	return p.info().kind != ProductKindUnknown
+}
+
+//Kind Get kind of transaction
+func (p ProductTypeIdentifier) Kind() ProductKind {
+	return p.info().kind
+}
+
+//Platform Get platform of transaction, tvOS and visionOS are not distinguished by identifier, see SalesReport.Platform
+func (p ProductTypeIdentifier) Platform() Platform {
+	return p.info().platform
+}
+
+//DeviceFamily Get device family of app
+func (p ProductTypeIdentifier) DeviceFamily() DeviceFamily {
+	return p.info().family
+}
+
+//InAppPurchaseType Get type of In-App Purchase
+func (p ProductTypeIdentifier) InAppPurchaseType() InAppPurchaseType {
+	return p.info().iap
+}
+
+//IsDownload First time download of free or paid app or app bundle
+func (p ProductTypeIdentifier) IsDownload() bool {
+	return p.Kind() == ProductKindApp || p.Kind() == ProductKindBundle
+}
+
+//IsUpdate App update
+func (p ProductTypeIdentifier) IsUpdate() bool {
+	return p.Kind() == ProductKindUpdate
+}
+
+//IsRedownload App redownload
+func (p ProductTypeIdentifier) IsRedownload() bool {
+	return p.Kind() == ProductKindRedownload
+}
+
+//IsInAppPurchase In-App Purchase of any type
+func (p ProductTypeIdentifier) IsInAppPurchase() bool {
+	return p.Kind() == ProductKindInAppPurchase
+}
+
+//IsSubscription Auto-renewable, non-renewing or free subscription
+func (p ProductTypeIdentifier) IsSubscription() bool {
+	switch p.InAppPurchaseType() {
+	case InAppPurchaseTypeAutoRenewableSubscription, InAppPurchaseTypeNonRenewingSubscription, InAppPurchaseTypeFreeSubscription:
+		return true
+	}
+	return false
+}
+
+//IsBundle App bundle
+func (p ProductTypeIdentifier) IsBundle() bool {
+	return p.Kind() == ProductKindBundle
+}
+
+//IsCustomApp Custom app distributed to organizations
+func (p ProductTypeIdentifier) IsCustomApp() bool {
+	return p.info().custom
+}
+
+//platformByDevice Get platform by report Device column
+func platformByDevice(device string, p ProductTypeIdentifier) Platform {
+	device = strings.ToLower(device)
+	switch {
+	case strings.Contains(device, "apple tv"):
+		return PlatformTVOS
+	case strings.Contains(device, "vision"):
+		return PlatformVisionOS
+	}
+	return p.Platform()
+}
+
+//Platform Get platform of sales report row, device is used for tvOS and visionOS
+func (r *SalesReport) Platform() Platform {
+	return platformByDevice(r.Device, r.ProductTypeIdentifier)
+}
+
+//IsFreeDownload First time download of free app
+func (r *SalesReport) IsFreeDownload() bool {
+	return r.ProductTypeIdentifier.IsDownload() && r.CustomerPrice.Amount.IsZero()
+}
+
+//IsPaidDownload First time download of paid app, refunds included
+func (r *SalesReport) IsPaidDownload() bool {
+	return r.ProductTypeIdentifier.IsDownload() && !r.CustomerPrice.Amount.IsZero()
+}
+
+//UnitsByKind units of sales report rows split by kind of transaction
+type UnitsByKind struct {
+	FreeDownloads  float64
+	PaidDownloads  float64
+	Updates        float64
+	Redownloads    float64
+	InAppPurchases float64
+	Subscriptions  float64 //subscription In-App Purchases, also counted in InAppPurchases
+	Unknown        float64
+}
+
+//SplitUnits Split units of sales report rows by kind of transaction
+func SplitUnits(rows []*SalesReport) *UnitsByKind {
+	units := &UnitsByKind{}
+	for _, row := range rows {
+		if row == nil {
+			continue
+		}
+		value := row.Units.Value()
+		switch row.ProductTypeIdentifier.Kind() {
+		case ProductKindApp, ProductKindBundle:
+			if row.IsFreeDownload() {
+				units.FreeDownloads += value
+			} else {
+				units.PaidDownloads += value
+			}
+		case ProductKindUpdate:
+			units.Updates += value
+		case ProductKindRedownload:
+			units.Redownloads += value
+		case ProductKindInAppPurchase:
+			units.InAppPurchases += value
+			if row.ProductTypeIdentifier.IsSubscription() {
+				units.Subscriptions += value
+			}
+		default:
+			units.Unknown += value
+		}
+	}
+	return units
+}
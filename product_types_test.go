@@ -0,0 +1,89 @@
+package appstore
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ProductTypeIdentifierTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ProductTypeIdentifierTestSuite) TestKind() {
+	assert.Equal(suite.T(), ProductKindApp, ProductTypeIdentifier("1F").Kind())
+	assert.Equal(suite.T(), ProductKindBundle, ProductTypeIdentifier("1-B").Kind())
+	assert.Equal(suite.T(), ProductKindUpdate, ProductTypeIdentifier("7T").Kind())
+	assert.Equal(suite.T(), ProductKindUpdate, ProductTypeIdentifier("F7").Kind())
+	assert.Equal(suite.T(), ProductKindRedownload, ProductTypeIdentifier("3F").Kind())
+	assert.Equal(suite.T(), ProductKindInAppPurchase, ProductTypeIdentifier("IAY").Kind())
+	assert.Equal(suite.T(), ProductKindUnknown, ProductTypeIdentifier("XX").Kind())
+	assert.Equal(suite.T(), ProductKindApp, ProductTypeIdentifier(" 1T ").Kind())
+}
+
+func (suite *ProductTypeIdentifierTestSuite) TestIsKnown() {
+	for identifier := range productTypes {
+		assert.True(suite.T(), identifier.IsKnown(), string(identifier))
+	}
+	assert.False(suite.T(), ProductTypeIdentifier("").IsKnown())
+}
+
+func (suite *ProductTypeIdentifierTestSuite) TestClassification() {
+	assert.True(suite.T(), ProductTypeIdentifierUniversalApp.IsDownload())
+	assert.True(suite.T(), ProductTypeIdentifierMacAppBundle.IsDownload())
+	assert.True(suite.T(), ProductTypeIdentifierMacAppBundle.IsBundle())
+	assert.True(suite.T(), ProductTypeIdentifierIPadUpdate.IsUpdate())
+	assert.True(suite.T(), ProductTypeIdentifierMacRedownload.IsRedownload())
+	assert.True(suite.T(), ProductTypeIdentifierCustomIPadApp.IsCustomApp())
+	assert.False(suite.T(), ProductTypeIdentifierIPadApp.IsCustomApp())
+	assert.True(suite.T(), ProductTypeIdentifierInAppPurchase.IsInAppPurchase())
+	assert.False(suite.T(), ProductTypeIdentifierInAppPurchase.IsSubscription())
+	assert.True(suite.T(), ProductTypeIdentifierInAppSubscription.IsSubscription())
+	assert.True(suite.T(), ProductTypeIdentifierInAppFreeSubscriptionMac.IsSubscription())
+	assert.Equal(suite.T(), InAppPurchaseTypeAutoRenewableSubscription, ProductTypeIdentifier("IAY-M").InAppPurchaseType())
+	assert.Equal(suite.T(), InAppPurchaseTypeNone, ProductTypeIdentifierIPhoneApp.InAppPurchaseType())
+	assert.Equal(suite.T(), DeviceFamilyIPad, ProductTypeIdentifierIPadRedownload.DeviceFamily())
+}
+
+func (suite *ProductTypeIdentifierTestSuite) TestPlatform() {
+	assert.Equal(suite.T(), PlatformIOS, ProductTypeIdentifierUniversalApp.Platform())
+	assert.Equal(suite.T(), PlatformMacOS, ProductTypeIdentifierMacUpdate.Platform())
+	assert.Equal(suite.T(), PlatformMacOS, ProductTypeIdentifierInAppPurchaseMac.Platform())
+	assert.Equal(suite.T(), PlatformUnknown, ProductTypeIdentifier("XX").Platform())
+	row := &SalesReport{ProductTypeIdentifier: ProductTypeIdentifierIPhoneApp, Device: "Apple TV"}
+	assert.Equal(suite.T(), PlatformTVOS, row.Platform())
+	row.Device = "Apple Vision"
+	assert.Equal(suite.T(), PlatformVisionOS, row.Platform())
+	row.Device = "iPhone"
+	assert.Equal(suite.T(), PlatformIOS, row.Platform())
+}
+
+func (suite *ProductTypeIdentifierTestSuite) TestSplitUnits() {
+	rows := []*SalesReport{
+		{ProductTypeIdentifier: "1F", Units: CustomFloat64{Float64: 10}},
+		{ProductTypeIdentifier: "1F", Units: CustomFloat64{Float64: 2}, CustomerPrice: NewMoney(decimal.New(499, -2), "USD")},
+		{ProductTypeIdentifier: "7", Units: CustomFloat64{Float64: 5}},
+		{ProductTypeIdentifier: "3T", Units: CustomFloat64{Float64: 3}},
+		{ProductTypeIdentifier: "IA1", Units: CustomFloat64{Float64: 4}, CustomerPrice: NewMoney(decimal.New(99, -2), "USD")},
+		{ProductTypeIdentifier: "IAY", Units: CustomFloat64{Float64: 6}, CustomerPrice: NewMoney(decimal.New(299, -2), "USD")},
+		{ProductTypeIdentifier: "XX", Units: CustomFloat64{Float64: 1}},
+		nil,
+	}
+	assert.True(suite.T(), rows[0].IsFreeDownload())
+	assert.False(suite.T(), rows[0].IsPaidDownload())
+	assert.True(suite.T(), rows[1].IsPaidDownload())
+	assert.False(suite.T(), rows[4].IsPaidDownload())
+	units := SplitUnits(rows)
+	assert.Equal(suite.T(), 10.0, units.FreeDownloads)
+	assert.Equal(suite.T(), 2.0, units.PaidDownloads)
+	assert.Equal(suite.T(), 5.0, units.Updates)
+	assert.Equal(suite.T(), 3.0, units.Redownloads)
+	assert.Equal(suite.T(), 10.0, units.InAppPurchases)
+	assert.Equal(suite.T(), 6.0, units.Subscriptions)
+	assert.Equal(suite.T(), 1.0, units.Unknown)
+}
+
+func TestProductTypeIdentifierTestSuite(t *testing.T) {
+	suite.Run(t, new(ProductTypeIdentifierTestSuite))
+}
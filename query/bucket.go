@@ -0,0 +1,49 @@
+package query
+
+import (
+	"fmt"
+	"time"
+)
+
+//Bucket time bucket
+type Bucket string
+
+const (
+	//BucketDay const
+	BucketDay Bucket = "day"
+	//BucketWeek const, weeks start on Monday
+	BucketWeek Bucket = "week"
+	//BucketMonth const
+	BucketMonth Bucket = "month"
+	//BucketQuarter const
+	BucketQuarter Bucket = "quarter"
+	//BucketYear const
+	BucketYear Bucket = "year"
+)
+
+//Truncate Get start of bucket containing t
+func (b Bucket) Truncate(t time.Time) time.Time {
+	switch b {
+	case BucketDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	case BucketWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case BucketMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case BucketQuarter:
+		return time.Date(t.Year(), t.Month()-(t.Month()-1)%3, 1, 0, 0, 0, 0, t.Location())
+	case BucketYear:
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+	}
+	return t
+}
+
+//IsValid Validate bucket
+func (b Bucket) IsValid() error {
+	switch b {
+	case BucketDay, BucketWeek, BucketMonth, BucketQuarter, BucketYear:
+		return nil
+	}
+	return fmt.Errorf("Bucket.IsValid: unknown bucket %s", string(b))
+}
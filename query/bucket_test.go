@@ -0,0 +1,39 @@
+package query
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type BucketTestSuite struct {
+	suite.Suite
+	date time.Time
+}
+
+func (suite *BucketTestSuite) SetupTest() {
+	suite.date = time.Date(2020, 8, 6, 15, 4, 5, 0, time.UTC) //Thursday
+}
+
+func (suite *BucketTestSuite) TestTruncate() {
+	assert.Equal(suite.T(), "2020-08-06", BucketDay.Truncate(suite.date).Format("2006-01-02"))
+	assert.Equal(suite.T(), "2020-08-03", BucketWeek.Truncate(suite.date).Format("2006-01-02"))
+	assert.Equal(suite.T(), "2020-08-03", BucketWeek.Truncate(suite.date.AddDate(0, 0, -3)).Format("2006-01-02"))
+	assert.Equal(suite.T(), "2020-08-03", BucketWeek.Truncate(suite.date.AddDate(0, 0, 3)).Format("2006-01-02"))
+	assert.Equal(suite.T(), "2020-08-01", BucketMonth.Truncate(suite.date).Format("2006-01-02"))
+	assert.Equal(suite.T(), "2020-07-01", BucketQuarter.Truncate(suite.date).Format("2006-01-02"))
+	assert.Equal(suite.T(), "2020-01-01", BucketYear.Truncate(suite.date).Format("2006-01-02"))
+	assert.Equal(suite.T(), suite.date, Bucket("foo").Truncate(suite.date))
+}
+
+func (suite *BucketTestSuite) TestIsValid() {
+	assert.NoError(suite.T(), BucketQuarter.IsValid())
+	err := Bucket("foo").IsValid()
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "Bucket.IsValid: unknown bucket foo", err.Error())
+}
+
+func TestBucketTestSuite(t *testing.T) {
+	suite.Run(t, new(BucketTestSuite))
+}
@@ -0,0 +1,85 @@
+package query
+
+import (
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/shopspring/decimal"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//field value of report row field
+type field struct {
+	text     string          //value as text, used for grouping and predicates
+	number   decimal.Decimal //numeric value
+	numeric  bool            //value is numeric
+	currency string          //currency of money value
+	date     time.Time       //date value
+	null     bool            //value is blank
+}
+
+//rowValue Get struct value of report row
+func rowValue(row interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(row)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return rv, fmt.Errorf("nil row")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return rv, fmt.Errorf("struct row expected, got %v", rv.Kind())
+	}
+	return rv, nil
+}
+
+//fieldOf Get field value of report row by field name
+func fieldOf(row interface{}, name string) (*field, error) {
+	rv, err := rowValue(row)
+	if err != nil {
+		return nil, err
+	}
+	fv := rv.FieldByName(name)
+	if !fv.IsValid() {
+		return nil, fmt.Errorf("field %s not found in %s", name, rv.Type().Name())
+	}
+	if !fv.CanAddr() {
+		copied := reflect.New(fv.Type()).Elem()
+		copied.Set(fv)
+		fv = copied
+	}
+	switch v := fv.Addr().Interface().(type) {
+	case *string:
+		return &field{text: strings.Trim(*v, " "), null: strings.Trim(*v, " ") == ""}, nil
+	case *appstore.Money:
+		text, _ := v.MarshalCSV()
+		return &field{text: text, number: v.Amount, numeric: true, currency: v.Currency, null: v.Null}, nil
+	case *appstore.CustomInteger:
+		text, _ := v.MarshalCSV()
+		return &field{text: text, number: decimal.New(int64(v.Integer), 0), numeric: true, null: v.Null}, nil
+	case *appstore.CustomFloat64:
+		//float is formatted with precision it was parsed with to avoid float error in sums
+		text, _ := v.MarshalCSV()
+		number, err := decimal.NewFromString(text)
+		if err != nil {
+			number = decimal.NewFromFloat(v.Float64)
+		}
+		return &field{text: text, number: number, numeric: true, null: v.Null}, nil
+	case *appstore.CustomDate:
+		text, _ := v.MarshalCSV()
+		return &field{text: text, date: v.Date, null: v.Null || v.Date.IsZero()}, nil
+	case *appstore.CustomTimestamp:
+		text, _ := v.MarshalCSV()
+		return &field{text: text, date: v.Timestamp, null: v.Null || v.Timestamp.IsZero()}, nil
+	case *appstore.CustomBoolean:
+		text, _ := v.MarshalCSV()
+		return &field{text: text, null: v.Null}, nil
+	}
+	if fv.Kind() == reflect.String {
+		//named string types, e.g. ProductTypeIdentifier
+		text := strings.Trim(fv.String(), " ")
+		return &field{text: text, null: text == ""}, nil
+	}
+	return &field{text: fmt.Sprintf("%v", fv.Interface())}, nil
+}
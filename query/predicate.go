@@ -0,0 +1,88 @@
+package query
+
+import (
+	"strings"
+	"time"
+)
+
+//Predicate report row filter, row is pointer to report struct
+type Predicate func(row interface{}) bool
+
+//Eq Field value equals value, case insensitive
+func Eq(name string, value string) Predicate {
+	return func(row interface{}) bool {
+		f, err := fieldOf(row, name)
+		return err == nil && strings.EqualFold(f.text, value)
+	}
+}
+
+//In Field value is one of values, case insensitive
+func In(name string, values ...string) Predicate {
+	return func(row interface{}) bool {
+		f, err := fieldOf(row, name)
+		if err != nil {
+			return false
+		}
+		for _, value := range values {
+			if strings.EqualFold(f.text, value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//NotBlank Field value is not blank
+func NotBlank(name string) Predicate {
+	return func(row interface{}) bool {
+		f, err := fieldOf(row, name)
+		return err == nil && !f.null
+	}
+}
+
+//Between Date field value is in [from, to] range
+func Between(name string, from time.Time, to time.Time) Predicate {
+	return func(row interface{}) bool {
+		f, err := fieldOf(row, name)
+		return err == nil && !f.null && !f.date.Before(from) && !f.date.After(to)
+	}
+}
+
+//Positive Numeric field value is greater than zero, e.g. to skip refunds
+func Positive(name string) Predicate {
+	return func(row interface{}) bool {
+		f, err := fieldOf(row, name)
+		return err == nil && f.numeric && f.number.IsPositive()
+	}
+}
+
+//Not Negate predicate
+func Not(p Predicate) Predicate {
+	return func(row interface{}) bool {
+		return !p(row)
+	}
+}
+
+//And All predicates match
+func And(predicates ...Predicate) Predicate {
+	return func(row interface{}) bool {
+		for _, p := range predicates {
+			if !p(row) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+//Or Any predicate matches
+func Or(predicates ...Predicate) Predicate {
+	return func(row interface{}) bool {
+		for _, p := range predicates {
+			if p(row) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,59 @@
+package query
+
+import (
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type PredicateTestSuite struct {
+	suite.Suite
+	row *appstore.SalesReport
+}
+
+func (suite *PredicateTestSuite) SetupTest() {
+	suite.row = buildStubSalesReport("foo", "US", "2020-10-05", 10, "0.70", "USD")
+}
+
+func (suite *PredicateTestSuite) TestEqIn() {
+	assert.True(suite.T(), Eq("SKU", "FOO")(suite.row))
+	assert.False(suite.T(), Eq("SKU", "bar")(suite.row))
+	assert.False(suite.T(), Eq("Foo", "bar")(suite.row))
+	assert.True(suite.T(), In("CountryCode", "DE", "US")(suite.row))
+	assert.False(suite.T(), In("CountryCode", "DE")(suite.row))
+	assert.True(suite.T(), Eq("Units", "10")(suite.row))
+}
+
+func (suite *PredicateTestSuite) TestNotBlank() {
+	assert.True(suite.T(), NotBlank("SKU")(suite.row))
+	assert.False(suite.T(), NotBlank("Title")(suite.row))
+	assert.False(suite.T(), NotBlank("EndDate")(suite.row))
+}
+
+func (suite *PredicateTestSuite) TestBetween() {
+	from := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	assert.True(suite.T(), Between("BeginDate", from, from.AddDate(0, 0, 4))(suite.row))
+	assert.False(suite.T(), Between("BeginDate", from, from.AddDate(0, 0, 3))(suite.row))
+	assert.False(suite.T(), Between("EndDate", from, from.AddDate(0, 0, 4))(suite.row))
+}
+
+func (suite *PredicateTestSuite) TestPositive() {
+	assert.True(suite.T(), Positive("Units")(suite.row))
+	suite.row.Units.Float64 = -1
+	assert.False(suite.T(), Positive("Units")(suite.row))
+	assert.False(suite.T(), Positive("SKU")(suite.row))
+}
+
+func (suite *PredicateTestSuite) TestCombinators() {
+	assert.True(suite.T(), And(Eq("SKU", "foo"), Eq("CountryCode", "US"))(suite.row))
+	assert.False(suite.T(), And(Eq("SKU", "foo"), Eq("CountryCode", "DE"))(suite.row))
+	assert.True(suite.T(), Or(Eq("SKU", "bar"), Eq("CountryCode", "US"))(suite.row))
+	assert.False(suite.T(), Or(Eq("SKU", "bar"), Eq("CountryCode", "DE"))(suite.row))
+	assert.True(suite.T(), Not(Eq("SKU", "bar"))(suite.row))
+}
+
+func TestPredicateTestSuite(t *testing.T) {
+	suite.Run(t, new(PredicateTestSuite))
+}
@@ -0,0 +1,266 @@
+package query
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+//AggregateFunc aggregate function
+type AggregateFunc string
+
+const (
+	//AggregateSum const
+	AggregateSum AggregateFunc = "sum"
+	//AggregateAvg const
+	AggregateAvg AggregateFunc = "avg"
+)
+
+//CountName name of rows count value
+const CountName = "count"
+
+//Aggregate aggregate of numeric field
+type Aggregate struct {
+	Func  AggregateFunc
+	Field string
+}
+
+//Name Get aggregate value name, e.g. sum(Units)
+func (a Aggregate) Name() string {
+	return string(a.Func) + "(" + a.Field + ")"
+}
+
+//Query aggregation query over slice of report rows
+type Query struct {
+	rows        interface{}
+	predicates  []Predicate
+	groupBy     []string
+	bucketField string
+	bucket      Bucket
+	aggregates  []Aggregate
+	orderBy     string
+	desc        bool
+	limit       int
+}
+
+//Where Add rows filter, all filters must match
+func (q *Query) Where(p Predicate) *Query {
+	q.predicates = append(q.predicates, p)
+	return q
+}
+
+//GroupBy Group rows by fields
+func (q *Query) GroupBy(fields ...string) *Query {
+	q.groupBy = append(q.groupBy, fields...)
+	return q
+}
+
+//BucketBy Group rows by time bucket of date field, e.g. BeginDate
+func (q *Query) BucketBy(field string, bucket Bucket) *Query {
+	q.bucketField = field
+	q.bucket = bucket
+	return q
+}
+
+//Sum Add sum of numeric field
+func (q *Query) Sum(field string) *Query {
+	q.aggregates = append(q.aggregates, Aggregate{Func: AggregateSum, Field: field})
+	return q
+}
+
+//Avg Add average of non blank values of numeric field
+func (q *Query) Avg(field string) *Query {
+	q.aggregates = append(q.aggregates, Aggregate{Func: AggregateAvg, Field: field})
+	return q
+}
+
+//OrderBy Order result by value name (count, sum(Units)), group field or bucket field
+func (q *Query) OrderBy(name string, desc bool) *Query {
+	q.orderBy = name
+	q.desc = desc
+	return q
+}
+
+//Limit Limit number of result rows, 0 means no limit
+func (q *Query) Limit(limit int) *Query {
+	q.limit = limit
+	return q
+}
+
+//Top Get n result rows with highest value
+func (q *Query) Top(n int, name string) *Query {
+	return q.OrderBy(name, true).Limit(n)
+}
+
+//Run Execute query
+func (q *Query) Run() (*Table, error) {
+	rv := reflect.Indirect(reflect.ValueOf(q.rows))
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("Query.Run: slice expected, got %v", rv.Kind())
+	}
+	if q.bucketField != "" {
+		if err := q.bucket.IsValid(); err != nil {
+			return nil, fmt.Errorf("Query.Run: %v", err)
+		}
+	}
+	table := &Table{GroupBy: q.groupBy, BucketField: q.bucketField, Aggregates: q.aggregates}
+	groups := make(map[string]*ResultRow)
+	for i := 0; i < rv.Len(); i++ {
+		item := rv.Index(i)
+		if item.Kind() == reflect.Ptr && item.IsNil() {
+			continue
+		}
+		if item.Kind() != reflect.Ptr && item.CanAddr() {
+			item = item.Addr()
+		}
+		row := item.Interface()
+		if !q.match(row) {
+			continue
+		}
+		result, err := q.group(row, groups, table)
+		if err != nil {
+			return nil, fmt.Errorf("Query.Run row %d: %v", i, err)
+		}
+		err = q.aggregate(row, result)
+		if err != nil {
+			return nil, fmt.Errorf("Query.Run row %d: %v", i, err)
+		}
+	}
+	for _, result := range table.Rows {
+		for _, a := range q.aggregates {
+			if a.Func == AggregateAvg && result.counts[a.Name()] > 0 {
+				result.Values[a.Name()] = result.Values[a.Name()].Div(decimal.New(int64(result.counts[a.Name()]), 0))
+			}
+		}
+	}
+	q.sort(table)
+	if q.limit > 0 && len(table.Rows) > q.limit {
+		table.Rows = table.Rows[:q.limit]
+	}
+	return table, nil
+}
+
+//match Check row matches all predicates
+func (q *Query) match(row interface{}) bool {
+	for _, p := range q.predicates {
+		if !p(row) {
+			return false
+		}
+	}
+	return true
+}
+
+//group Get result row of report row group
+func (q *Query) group(row interface{}, groups map[string]*ResultRow, table *Table) (*ResultRow, error) {
+	keys := make(map[string]string, len(q.groupBy))
+	parts := make([]string, 0, len(q.groupBy)+1)
+	for _, name := range q.groupBy {
+		f, err := fieldOf(row, name)
+		if err != nil {
+			return nil, err
+		}
+		keys[name] = f.text
+		parts = append(parts, f.text)
+	}
+	var bucket time.Time
+	if q.bucketField != "" {
+		f, err := fieldOf(row, q.bucketField)
+		if err != nil {
+			return nil, err
+		}
+		if !f.null {
+			bucket = q.bucket.Truncate(f.date)
+		}
+		parts = append(parts, bucket.Format(time.RFC3339))
+	}
+	key := strings.Join(parts, "\x00")
+	result, ok := groups[key]
+	if !ok {
+		result = &ResultRow{
+			Keys:       keys,
+			Bucket:     bucket,
+			Values:     make(map[string]decimal.Decimal, len(q.aggregates)),
+			Currencies: make(map[string]string),
+			counts:     make(map[string]int, len(q.aggregates)),
+		}
+		for _, a := range q.aggregates {
+			result.Values[a.Name()] = decimal.Zero
+		}
+		groups[key] = result
+		table.Rows = append(table.Rows, result)
+	}
+	result.Count++
+	return result, nil
+}
+
+//aggregate Add report row values to result row
+func (q *Query) aggregate(row interface{}, result *ResultRow) error {
+	for _, a := range q.aggregates {
+		f, err := fieldOf(row, a.Field)
+		if err != nil {
+			return err
+		}
+		if !f.numeric {
+			return fmt.Errorf("field %s is not numeric", a.Field)
+		}
+		if f.null {
+			continue
+		}
+		name := a.Name()
+		if f.currency != "" {
+			currency, ok := result.Currencies[name]
+			if ok && !strings.EqualFold(currency, f.currency) {
+				return fmt.Errorf("%s mixes currencies %s and %s, convert or group by currency first", name, currency, f.currency)
+			}
+			result.Currencies[name] = f.currency
+		}
+		result.Values[name] = result.Values[name].Add(f.number)
+		result.counts[name]++
+	}
+	return nil
+}
+
+//sort Sort result rows, by bucket and group keys by default
+func (q *Query) sort(table *Table) {
+	less := func(a, b *ResultRow) bool {
+		if !a.Bucket.Equal(b.Bucket) {
+			return a.Bucket.Before(b.Bucket)
+		}
+		for _, name := range q.groupBy {
+			if a.Keys[name] != b.Keys[name] {
+				return a.Keys[name] < b.Keys[name]
+			}
+		}
+		return false
+	}
+	sort.SliceStable(table.Rows, func(i, j int) bool {
+		return less(table.Rows[i], table.Rows[j])
+	})
+	if q.orderBy == "" {
+		return
+	}
+	sort.SliceStable(table.Rows, func(i, j int) bool {
+		a, b := table.Rows[i], table.Rows[j]
+		if q.desc {
+			a, b = b, a
+		}
+		switch {
+		case q.orderBy == q.bucketField:
+			return a.Bucket.Before(b.Bucket)
+		case q.orderBy == CountName:
+			return a.Count < b.Count
+		}
+		if _, ok := a.Values[q.orderBy]; ok {
+			return a.Values[q.orderBy].LessThan(b.Values[q.orderBy])
+		}
+		return a.Keys[q.orderBy] < b.Keys[q.orderBy]
+	})
+}
+
+//From Create query over slice of report rows
+func From(rows interface{}) *Query {
+	return &Query{rows: rows}
+}
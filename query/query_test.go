@@ -0,0 +1,118 @@
+package query
+
+import (
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+func buildStubSalesReport(sku string, country string, date string, units float64, proceeds string, currency string) *appstore.SalesReport {
+	beginDate, _ := time.Parse(appstore.CustomDateFormatDefault, date)
+	amount, _ := appstore.NewMoneyFromString(proceeds, currency)
+	return &appstore.SalesReport{
+		SKU:                sku,
+		CountryCode:        country,
+		BeginDate:          appstore.CustomDate{Date: beginDate},
+		Units:              appstore.CustomFloat64{Float64: units},
+		DeveloperProceeds:  amount,
+		CurrencyOfProceeds: currency,
+	}
+}
+
+func buildStubSalesReports() []*appstore.SalesReport {
+	return []*appstore.SalesReport{
+		buildStubSalesReport("foo", "US", "2020-10-05", 10, "0.70", "USD"),
+		buildStubSalesReport("bar", "US", "2020-10-06", 5, "1.40", "USD"),
+		buildStubSalesReport("foo", "US", "2020-11-02", 2, "0.70", "USD"),
+		buildStubSalesReport("foo", "DE", "2020-10-05", 1, "0.60", "EUR"),
+		buildStubSalesReport("baz", "US", "2020-10-07", 0.1, "0.10", "USD"),
+		nil,
+	}
+}
+
+type QueryTestSuite struct {
+	suite.Suite
+	rows []*appstore.SalesReport
+}
+
+func (suite *QueryTestSuite) SetupTest() {
+	suite.rows = buildStubSalesReports()
+}
+
+func (suite *QueryTestSuite) TestGroupBySum() {
+	table, err := From(suite.rows).Where(Eq("CountryCode", "us")).GroupBy("SKU").Sum("Units").Sum("DeveloperProceeds").Run()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), table.Rows, 3)
+	assert.Equal(suite.T(), "bar", table.Rows[0].Key("SKU"))
+	assert.Equal(suite.T(), "baz", table.Rows[1].Key("SKU"))
+	assert.Equal(suite.T(), "0.1", table.Rows[1].Sum("Units").String())
+	foo := table.Rows[2]
+	assert.Equal(suite.T(), 2, foo.Count)
+	assert.Equal(suite.T(), "12", foo.Sum("Units").String())
+	assert.Equal(suite.T(), "1.40 USD", foo.SumMoney("DeveloperProceeds").String())
+}
+
+func (suite *QueryTestSuite) TestAvg() {
+	table, err := From(suite.rows).Where(Eq("SKU", "foo")).Where(Eq("CountryCode", "US")).Avg("Units").Run()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), table.Rows, 1)
+	assert.Equal(suite.T(), "6", table.Rows[0].Avg("Units").String())
+}
+
+func (suite *QueryTestSuite) TestMixedCurrencies() {
+	_, err := From(suite.rows).Sum("DeveloperProceeds").Run()
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "sum(DeveloperProceeds) mixes currencies USD and EUR")
+	table, err := From(suite.rows).GroupBy("CurrencyOfProceeds").Sum("DeveloperProceeds").Run()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "0.60 EUR", table.Rows[0].SumMoney("DeveloperProceeds").String())
+	assert.Equal(suite.T(), "2.90 USD", table.Rows[1].SumMoney("DeveloperProceeds").String())
+}
+
+func (suite *QueryTestSuite) TestBucketBy() {
+	table, err := From(suite.rows).BucketBy("BeginDate", BucketMonth).Sum("Units").Run()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), table.Rows, 2)
+	assert.Equal(suite.T(), "2020-10-01", table.Rows[0].Bucket.Format(appstore.CustomDateFormatDefault))
+	assert.Equal(suite.T(), "16.1", table.Rows[0].Sum("Units").String())
+	assert.Equal(suite.T(), "2", table.Rows[1].Sum("Units").String())
+}
+
+func (suite *QueryTestSuite) TestTop() {
+	table, err := From(suite.rows).GroupBy("SKU").Sum("Units").Top(2, "sum(Units)").Run()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), table.Rows, 2)
+	assert.Equal(suite.T(), "foo", table.Rows[0].Key("SKU"))
+	assert.Equal(suite.T(), "bar", table.Rows[1].Key("SKU"))
+	table, _ = From(suite.rows).GroupBy("SKU").OrderBy(CountName, true).Limit(1).Run()
+	assert.Equal(suite.T(), "foo", table.Rows[0].Key("SKU"))
+	assert.Equal(suite.T(), decimal.New(3, 0), table.Rows[0].Value(CountName))
+	table, _ = From(suite.rows).GroupBy("SKU").OrderBy("SKU", true).Run()
+	assert.Equal(suite.T(), "foo", table.Rows[0].Key("SKU"))
+}
+
+func (suite *QueryTestSuite) TestStructSlice() {
+	rows := []appstore.SalesReport{*suite.rows[0], *suite.rows[1]}
+	table, err := From(rows).Sum("Units").Run()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "15", table.Rows[0].Sum("Units").String())
+}
+
+func (suite *QueryTestSuite) TestErrors() {
+	_, err := From(suite.rows[0]).Run()
+	assert.Error(suite.T(), err)
+	_, err = From(suite.rows).GroupBy("Foo").Run()
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "Query.Run row 0: field Foo not found in SalesReport", err.Error())
+	_, err = From(suite.rows).Sum("SKU").Run()
+	assert.Error(suite.T(), err)
+	_, err = From(suite.rows).BucketBy("BeginDate", Bucket("foo")).Run()
+	assert.Error(suite.T(), err)
+}
+
+func TestQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryTestSuite))
+}
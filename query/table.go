@@ -0,0 +1,186 @@
+package query
+
+import (
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/shopspring/decimal"
+	"sort"
+	"time"
+)
+
+//ResultRow aggregated group of report rows
+type ResultRow struct {
+	Keys       map[string]string          //group field values
+	Bucket     time.Time                  //start of time bucket, zero without bucketing
+	Count      int                        //number of report rows in group
+	Values     map[string]decimal.Decimal //aggregate values by name, e.g. sum(Units)
+	Currencies map[string]string          //currencies of money aggregates by name
+	counts     map[string]int             //number of non blank values by aggregate name
+}
+
+//Key Get group field value
+func (r *ResultRow) Key(field string) string {
+	return r.Keys[field]
+}
+
+//Value Get value by name, e.g. count or sum(Units)
+func (r *ResultRow) Value(name string) decimal.Decimal {
+	if name == CountName {
+		return decimal.New(int64(r.Count), 0)
+	}
+	return r.Values[name]
+}
+
+//Sum Get sum of field
+func (r *ResultRow) Sum(field string) decimal.Decimal {
+	return r.Values[Aggregate{Func: AggregateSum, Field: field}.Name()]
+}
+
+//Avg Get average of field
+func (r *ResultRow) Avg(field string) decimal.Decimal {
+	return r.Values[Aggregate{Func: AggregateAvg, Field: field}.Name()]
+}
+
+//SumMoney Get sum of money field with currency
+func (r *ResultRow) SumMoney(field string) appstore.Money {
+	name := Aggregate{Func: AggregateSum, Field: field}.Name()
+	return appstore.NewMoney(r.Values[name], r.Currencies[name])
+}
+
+//Table query result
+type Table struct {
+	GroupBy     []string
+	BucketField string
+	Aggregates  []Aggregate
+	Rows        []*ResultRow
+}
+
+//Columns Get column names, group fields, bucket field, count and aggregate names
+func (t *Table) Columns() []string {
+	columns := append([]string{}, t.GroupBy...)
+	if t.BucketField != "" {
+		columns = append(columns, t.BucketField)
+	}
+	columns = append(columns, CountName)
+	for _, a := range t.Aggregates {
+		columns = append(columns, a.Name())
+	}
+	return columns
+}
+
+//Records Get rows as text records in order of columns
+func (t *Table) Records() [][]string {
+	records := make([][]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		record := make([]string, 0, len(t.Columns()))
+		for _, name := range t.GroupBy {
+			record = append(record, row.Keys[name])
+		}
+		if t.BucketField != "" {
+			record = append(record, row.Bucket.Format(appstore.CustomDateFormatDefault))
+		}
+		record = append(record, fmt.Sprintf("%d", row.Count))
+		for _, a := range t.Aggregates {
+			record = append(record, row.Values[a.Name()].String())
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+//Pivot Build pivot table of value with row and column keys, keys are group fields or bucket field.
+//Values of result rows with same keys are summed, so averages can not be pivoted on partial keys.
+func (t *Table) Pivot(rowKey string, columnKey string, value string) (*Pivot, error) {
+	for _, key := range []string{rowKey, columnKey} {
+		if !t.isKey(key) {
+			return nil, fmt.Errorf("Table.Pivot: %s is not group or bucket field", key)
+		}
+	}
+	if !t.isValue(value) {
+		return nil, fmt.Errorf("Table.Pivot: value %s not found", value)
+	}
+	pivot := &Pivot{Cells: make(map[string]map[string]decimal.Decimal)}
+	rows := make(map[string]bool)
+	columns := make(map[string]bool)
+	for _, row := range t.Rows {
+		r, c := t.keyOf(row, rowKey), t.keyOf(row, columnKey)
+		if pivot.Cells[r] == nil {
+			pivot.Cells[r] = make(map[string]decimal.Decimal)
+		}
+		if _, ok := pivot.Cells[r][c]; ok && t.isAvg(value) {
+			return nil, fmt.Errorf("Table.Pivot: %s can not be summed, group by %s and %s only", value, rowKey, columnKey)
+		}
+		pivot.Cells[r][c] = pivot.Cells[r][c].Add(row.Value(value))
+		rows[r] = true
+		columns[c] = true
+	}
+	pivot.RowKeys = sortedKeys(rows)
+	pivot.ColumnKeys = sortedKeys(columns)
+	return pivot, nil
+}
+
+//isKey Check name is group or bucket field
+func (t *Table) isKey(name string) bool {
+	if name != "" && name == t.BucketField {
+		return true
+	}
+	for _, key := range t.GroupBy {
+		if key == name {
+			return true
+		}
+	}
+	return false
+}
+
+//isValue Check name is value name
+func (t *Table) isValue(name string) bool {
+	if name == CountName {
+		return true
+	}
+	for _, a := range t.Aggregates {
+		if a.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+//isAvg Check name is average value name
+func (t *Table) isAvg(name string) bool {
+	for _, a := range t.Aggregates {
+		if a.Name() == name {
+			return a.Func == AggregateAvg
+		}
+	}
+	return false
+}
+
+//keyOf Get key value of result row
+func (t *Table) keyOf(row *ResultRow, name string) string {
+	if name == t.BucketField {
+		return row.Bucket.Format(appstore.CustomDateFormatDefault)
+	}
+	return row.Keys[name]
+}
+
+//Pivot pivot table
+type Pivot struct {
+	RowKeys    []string
+	ColumnKeys []string
+	Cells      map[string]map[string]decimal.Decimal
+}
+
+//Cell Get cell value, zero for missing cells
+func (p *Pivot) Cell(row string, column string) decimal.Decimal {
+	return p.Cells[row][column]
+}
+
+//sortedKeys Get sorted keys of set
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
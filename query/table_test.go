@@ -0,0 +1,55 @@
+package query
+
+import (
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type TableTestSuite struct {
+	suite.Suite
+	rows []*appstore.SalesReport
+}
+
+func (suite *TableTestSuite) SetupTest() {
+	suite.rows = buildStubSalesReports()
+}
+
+func (suite *TableTestSuite) TestColumnsAndRecords() {
+	table, _ := From(suite.rows).GroupBy("CountryCode").BucketBy("BeginDate", BucketMonth).Sum("Units").Run()
+	assert.Equal(suite.T(), []string{"CountryCode", "BeginDate", "count", "sum(Units)"}, table.Columns())
+	assert.Equal(suite.T(), [][]string{
+		{"DE", "2020-10-01", "1", "1"},
+		{"US", "2020-10-01", "3", "15.1"},
+		{"US", "2020-11-01", "1", "2"},
+	}, table.Records())
+}
+
+func (suite *TableTestSuite) TestPivot() {
+	table, _ := From(suite.rows).GroupBy("SKU", "CountryCode").BucketBy("BeginDate", BucketMonth).Sum("Units").Run()
+	pivot, err := table.Pivot("SKU", "BeginDate", "sum(Units)")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"bar", "baz", "foo"}, pivot.RowKeys)
+	assert.Equal(suite.T(), []string{"2020-10-01", "2020-11-01"}, pivot.ColumnKeys)
+	assert.Equal(suite.T(), "11", pivot.Cell("foo", "2020-10-01").String())
+	assert.Equal(suite.T(), "0", pivot.Cell("bar", "2020-11-01").String())
+	pivot, err = table.Pivot("CountryCode", "SKU", CountName)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2", pivot.Cell("US", "foo").String())
+}
+
+func (suite *TableTestSuite) TestPivotErrors() {
+	table, _ := From(suite.rows).GroupBy("SKU", "CountryCode").Avg("Units").Run()
+	_, err := table.Pivot("SKU", "Foo", "avg(Units)")
+	assert.Error(suite.T(), err)
+	_, err = table.Pivot("SKU", "CountryCode", "sum(Units)")
+	assert.Error(suite.T(), err)
+	_, err = table.Pivot("CountryCode", "CountryCode", "avg(Units)")
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "Table.Pivot: avg(Units) can not be summed, group by CountryCode and CountryCode only", err.Error())
+}
+
+func TestTableTestSuite(t *testing.T) {
+	suite.Run(t, new(TableTestSuite))
+}
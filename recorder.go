@@ -0,0 +1,191 @@
+package appstore
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+//RecorderMode type
+type RecorderMode string
+
+const (
+	//RecorderModeRecord const
+	RecorderModeRecord RecorderMode = "record"
+	//RecorderModeReplay const
+	RecorderModeReplay RecorderMode = "replay"
+)
+
+//CassetteRequest recorded request
+type CassetteRequest struct {
+	Method  string      `json:"method"`
+	Url     string      `json:"url"`
+	Headers http.Header `json:"headers"` //every value of multi-valued headers
+	Body    string      `json:"body,omitempty"`
+}
+
+//CassetteResponse recorded response
+type CassetteResponse struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers"` //every value of multi-valued headers, e.g. Set-Cookie or Link
+	Body    string      `json:"body"`    //base64 encoded raw body
+}
+
+//Cassette recorded request/response pair
+type Cassette struct {
+	Request  *CassetteRequest  `json:"request"`
+	Response *CassetteResponse `json:"response"`
+}
+
+//Recorder record/replay http.RoundTripper
+type Recorder struct {
+	mode      RecorderMode
+	dir       string
+	transport http.RoundTripper
+}
+
+//cassetteNameRegexp chars replaced in cassette file names
+var cassetteNameRegexp = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+//RoundTrip implementation of http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	return r.handle(req, r.transport.RoundTrip)
+}
+
+//Middleware Use recorder as transport middleware
+func (r *Recorder) Middleware() Middleware {
+	return func(next RequestHandlerFunc) RequestHandlerFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return r.handle(req, next)
+		}
+	}
+}
+
+//handle Record or replay request
+func (r *Recorder) handle(req *http.Request, next RequestHandlerFunc) (*http.Response, error) {
+	cassetteReq, err := r.buildCassetteRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("Recorder.handle build cassette request: %v", err)
+	}
+	path := filepath.Join(r.dir, r.cassetteName(cassetteReq))
+	if r.mode == RecorderModeReplay {
+		return r.replay(req, path)
+	}
+	resp, err := next(req)
+	if err != nil {
+		return resp, err
+	}
+	err = r.record(resp, cassetteReq, path)
+	if err != nil {
+		return nil, fmt.Errorf("Recorder.handle record: %v", err)
+	}
+	return resp, nil
+}
+
+//replay Load recorded response
+func (r *Recorder) replay(req *http.Request, path string) (*http.Response, error) {
+	data, err := readFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Recorder.replay unmatched request %s %s: %v", req.Method, RedactRequestUrl(req), err)
+	}
+	cassette := &Cassette{}
+	err = json.Unmarshal(data, cassette)
+	if err != nil {
+		return nil, fmt.Errorf("Recorder.replay unmarshal cassette: %v", err)
+	}
+	body, err := base64.StdEncoding.DecodeString(cassette.Response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Recorder.replay decode body: %v", err)
+	}
+	headers := http.Header{}
+	for k, values := range cassette.Response.Headers {
+		for _, v := range values {
+			headers.Add(k, v)
+		}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cassette.Response.Status, http.StatusText(cassette.Response.Status)),
+		StatusCode:    cassette.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+//record Save response to cassette
+func (r *Recorder) record(resp *http.Response, cassetteReq *CassetteRequest, path string) error {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	cassette := &Cassette{
+		Request: cassetteReq,
+		Response: &CassetteResponse{
+			Status:  resp.StatusCode,
+			Headers: RedactHeaderValues(resp.Header),
+			Body:    base64.StdEncoding.EncodeToString(body),
+		},
+	}
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(r.dir, 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+//buildCassetteRequest Build redacted request for matching.
+//Query of download requests is dropped, pre-signed urls are signed again with new expiry for every download
+func (r *Recorder) buildCassetteRequest(req *http.Request) (*CassetteRequest, error) {
+	u := *req.URL
+	u.RawQuery = RedactQueryValues(req.URL.Query()).Encode()
+	if IsDownloadRequest(req) {
+		u.RawQuery = ""
+	}
+	cassetteReq := &CassetteRequest{Method: req.Method, Url: u.String(), Headers: RedactHeaderValues(req.Header)}
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		cassetteReq.Body = RedactSecrets(string(body))
+	}
+	return cassetteReq, nil
+}
+
+//cassetteName Build cassette file name from method, url and body
+func (r *Recorder) cassetteName(req *CassetteRequest) string {
+	hash := sha1.Sum([]byte(req.Method + " " + req.Url + "\n" + req.Body))
+	u, _ := url.Parse(req.Url)
+	name := strings.Trim(cassetteNameRegexp.ReplaceAllString(u.Path, "_"), "_")
+	return strings.ToLower(req.Method) + "_" + name + "_" + hex.EncodeToString(hash[:])[:12] + ".json"
+}
+
+//NewRecorder Create new record/replay transport, cassettes are stored in dir
+func NewRecorder(dir string, mode RecorderMode, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{mode: mode, dir: dir, transport: transport}
+}
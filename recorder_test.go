@@ -0,0 +1,145 @@
+package appstore
+
+import (
+	"context"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type RecorderTestSuite struct {
+	suite.Suite
+	cfg *Config
+	ctx context.Context
+	dir string
+}
+
+func (suite *RecorderTestSuite) SetupTest() {
+	suite.cfg = buildStubConfig()
+	suite.ctx = context.Background()
+	suite.dir, _ = ioutil.TempDir("", "cassettes")
+	httpmock.Activate()
+}
+
+func (suite *RecorderTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+	_ = os.RemoveAll(suite.dir)
+}
+
+func (suite *RecorderTestSuite) buildResource(mode RecorderMode) *SalesReportsResource {
+	resource := buildStubSalesReportsResource()
+	resource.transport.Use(NewRecorder(suite.dir, mode, nil).Middleware())
+	return resource
+}
+
+func (suite *RecorderTestSuite) TestRecordAndReplay() {
+	rsp := buildStubResponseFromGzip(http.StatusOK, "stubs/reports/sales/sales.tsv")
+	rsp.Header.Set("Content-Type", ResponseContentTypeGzip)
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/v1/salesReports", httpmock.ResponderFromResponse(rsp))
+
+	filter := NewSalesReportsFilter()
+	filter.SubTypeSummary().Version11().Daily()
+	recorded, _, err := suite.buildResource(RecorderModeRecord).GetSalesReports(suite.ctx, filter)
+	assert.NoError(suite.T(), err)
+
+	files, _ := filepath.Glob(filepath.Join(suite.dir, "get_v1_salesReports_*.json"))
+	assert.Len(suite.T(), files, 1)
+	data, _ := ioutil.ReadFile(files[0])
+	assert.NotContains(suite.T(), string(data), buildStubAuthToken().Token)
+	assert.Contains(suite.T(), string(data), LogRedactedValue)
+
+	httpmock.Reset()
+	replayed, _, err := suite.buildResource(RecorderModeReplay).GetSalesReports(suite.ctx, filter)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, httpmock.GetTotalCallCount())
+	assert.Equal(suite.T(), recorded.Data, replayed.Data)
+}
+
+func (suite *RecorderTestSuite) TestReplayUnmatched() {
+	filter := NewSalesReportsFilter()
+	filter.SubTypeSummary().Version11().Daily()
+	_, _, err := suite.buildResource(RecorderModeReplay).GetSalesReports(suite.ctx, filter)
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "Recorder.replay unmatched request GET")
+	assert.Equal(suite.T(), 0, httpmock.GetTotalCallCount())
+}
+
+func (suite *RecorderTestSuite) TestRoundTrip() {
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/foo", httpmock.NewStringResponder(http.StatusOK, "bar"))
+	cl := &http.Client{Transport: NewRecorder(suite.dir, RecorderModeRecord, nil)}
+	_, err := cl.Get(suite.cfg.Uri + "/foo?token=secret")
+	assert.NoError(suite.T(), err)
+
+	cl = &http.Client{Transport: NewRecorder(suite.dir, RecorderModeReplay, nil)}
+	resp, err := cl.Get(suite.cfg.Uri + "/foo?token=secret")
+	assert.NoError(suite.T(), err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(suite.T(), "bar", string(body))
+	assert.Equal(suite.T(), 1, httpmock.GetTotalCallCount())
+}
+
+func (suite *RecorderTestSuite) TestReplayMultiValuedHeaders() {
+	rsp := httpmock.NewStringResponse(http.StatusOK, "bar")
+	rsp.Header.Add("Set-Cookie", "foo=1")
+	rsp.Header.Add("Set-Cookie", "bar=2")
+	rsp.Header.Add("Link", "<https://github.com/foo?page=2>; rel=\"next\"")
+	rsp.Header.Add("Link", "<https://github.com/foo?page=9>; rel=\"last\"")
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/foo", httpmock.ResponderFromResponse(rsp))
+	cl := &http.Client{Transport: NewRecorder(suite.dir, RecorderModeRecord, nil)}
+	_, err := cl.Get(suite.cfg.Uri + "/foo")
+	assert.NoError(suite.T(), err)
+
+	cl = &http.Client{Transport: NewRecorder(suite.dir, RecorderModeReplay, nil)}
+	resp, err := cl.Get(suite.cfg.Uri + "/foo")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{LogRedactedValue, LogRedactedValue}, resp.Header.Values("Set-Cookie"))
+	assert.Len(suite.T(), resp.Header.Values("Link"), 2)
+	assert.Contains(suite.T(), resp.Header.Values("Link")[1], "page=9")
+}
+
+func (suite *RecorderTestSuite) TestCassetteRequestRepeatedQuery() {
+	recorder := NewRecorder(suite.dir, RecorderModeReplay, nil)
+	first, _ := http.NewRequest(http.MethodGet, suite.cfg.Uri+"/foo?id=1&id=2&token=foo&token=bar", nil)
+	second, _ := http.NewRequest(http.MethodGet, suite.cfg.Uri+"/foo?id=1&id=3&token=foo&token=bar", nil)
+	firstReq, err := recorder.buildCassetteRequest(first)
+	assert.NoError(suite.T(), err)
+	secondReq, _ := recorder.buildCassetteRequest(second)
+	assert.Equal(suite.T(), suite.cfg.Uri+"/foo?id=1&id=2&token=%5BREDACTED%5D&token=%5BREDACTED%5D", firstReq.Url)
+	assert.NotEqual(suite.T(), recorder.cassetteName(firstReq), recorder.cassetteName(secondReq))
+}
+
+func (suite *RecorderTestSuite) TestRecordAndReplayDownload() {
+	httpmock.RegisterResponder("GET", "https://example.com/segment.csv.gz", httpmock.NewStringResponder(http.StatusOK, "foo"))
+	transport := buildStubHttpTransport()
+	transport.Use(NewRecorder(suite.dir, RecorderModeRecord, nil).Middleware())
+	resp, err := transport.Download(suite.ctx, "https://example.com/segment.csv.gz?X-Amz-Expires=300&X-Amz-Signature=first")
+	assert.NoError(suite.T(), err)
+	_ = resp.Body.Close()
+
+	files, _ := filepath.Glob(filepath.Join(suite.dir, "get_segment_csv_gz_*.json"))
+	assert.Len(suite.T(), files, 1)
+	data, _ := ioutil.ReadFile(files[0])
+	assert.NotContains(suite.T(), string(data), "X-Amz")
+
+	//url signed again for next download replays same cassette
+	transport = buildStubHttpTransport()
+	transport.Use(NewRecorder(suite.dir, RecorderModeReplay, nil).Middleware())
+	resp, err = transport.Download(suite.ctx, "https://example.com/segment.csv.gz?X-Amz-Expires=300&X-Amz-Signature=second")
+	assert.NoError(suite.T(), err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(suite.T(), "foo", string(body))
+	assert.Equal(suite.T(), 1, httpmock.GetTotalCallCount())
+
+	_, err = transport.Download(suite.ctx, "https://example.com/other.csv.gz?X-Amz-Signature=third")
+	assert.Error(suite.T(), err)
+	assert.NotContains(suite.T(), err.Error(), "third")
+}
+
+func TestRecorderTestSuite(t *testing.T) {
+	suite.Run(t, new(RecorderTestSuite))
+}
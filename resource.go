@@ -1,8 +1,11 @@
 package appstore
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"time"
 )
 
 //ResourceAbstract base resource
@@ -15,18 +18,56 @@ type ResourceAbstract struct {
 func (ra *ResourceAbstract) unmarshalResponse(resp *http.Response, v interface{}, filterLines bool) error {
 	contentType := resp.Header.Get("Content-Type")
 	responseHandler := NewResponseHandler(contentType, filterLines)
+	telemetry := ra.transport.getTelemetry()
+	reportType := reportTypeName(v)
+	attrs := []Attribute{{Key: "report.type", Value: reportType}, {Key: "http.content_type", Value: contentType}}
+	ctx, span := telemetry.StartSpan(responseContext(resp), TelemetrySpanReportUnmarshal, attrs...)
+	defer span.End()
 
+	//download whole body first, decompress and parse durations must not include network transfer
+	counter := &countingReadCloser{ReadCloser: resp.Body}
+	raw, err := ioutil.ReadAll(counter)
+	_ = resp.Body.Close()
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("ResourceAbstract.unmarshalResponse read body: %v", err)
+	}
+	telemetry.AddCounter(ctx, TelemetryMetricBytesDownloaded, counter.count, attrs...)
+	resp.Body = ioutil.NopCloser(bytes.NewReader(raw))
+	started := time.Now()
 	bodyBytes, err := responseHandler.ReadBody(resp)
 	if err != nil {
+		span.RecordError(err)
 		return fmt.Errorf("ResourceAbstract.unmarshalResponse read body: %v", err)
 	}
+	_, isReport := responseHandler.(*ResponseHandlerGzip)
+	if isReport {
+		telemetry.RecordHistogram(ctx, TelemetryMetricDecompressDuration, time.Since(started).Seconds(), attrs...)
+	}
 	//reset the response body to the original unread state
 	body, err := responseHandler.RestoreBody(bodyBytes)
 	if err != nil {
 		return fmt.Errorf("ResourceAbstract.unmarshalResponse read body: %v", err)
 	}
 	resp.Body = body
-	return responseHandler.UnmarshalBody(bodyBytes, v)
+	started = time.Now()
+	err = responseHandler.UnmarshalBody(bodyBytes, v)
+	if isReport {
+		telemetry.RecordHistogram(ctx, TelemetryMetricParseDuration, time.Since(started).Seconds(), attrs...)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if holder, ok := v.(errorsHolder); ok {
+		recordApiErrors(telemetry, ctx, holder.getErrors())
+	} else if isReport {
+		//rows are counted of reports only, JSON:API documents have no report rows
+		rows := reportRowsCount(v)
+		span.SetAttributes(Attribute{Key: "report.rows", Value: rows})
+		telemetry.AddCounter(ctx, TelemetryMetricRowsParsed, int64(rows), attrs...)
+	}
+	return nil
 }
 
 //newResourceAbstract create new resource abstract
@@ -31,8 +31,8 @@ func (suite *ResourceTestSuite) TestUnmarshalResponseGzip() {
 	assert.Equal(suite.T(), 1234567890, reports[0].AppleIdentifier.Value())
 	assert.Equal(suite.T(), "2020-10-05", reports[0].BeginDate.Value().Format(CustomDateFormatDefault))
 	assert.Equal(suite.T(), "2020-10-05", reports[0].EndDate.Value().Format(CustomDateFormatDefault))
-	assert.Equal(suite.T(), float64(299), reports[0].CustomerPrice.Value())
-	assert.Equal(suite.T(), 209.3000030517578, reports[0].DeveloperProceeds.Value())
+	assert.Equal(suite.T(), "299.00 RUB", reports[0].CustomerPrice.String())
+	assert.Equal(suite.T(), "209.30 RUB", reports[0].DeveloperProceeds.String())
 	assert.Equal(suite.T(), float64(12), reports[0].Units.Value())
 }
 
@@ -1,7 +1,6 @@
 package appstore
 
 import (
-	"fmt"
 	"time"
 )
 
@@ -207,13 +206,13 @@ func (f *SalesReportsBaseFilter) ToQueryParamsMap() map[string]interface{} {
 // IsValid Validate sales report filter params
 func (f *SalesReportsBaseFilter) IsValid() error {
 	if f.ReportType == "" {
-		return fmt.Errorf("SalesReportsBaseFilter.IsValid: %v", "ReportType is required")
+		return newFilterError("SalesReportsBaseFilter.IsValid", "filter[reportType]", "ReportType is required")
 	}
 	if f.ReportSubType == "" {
-		return fmt.Errorf("SalesReportsBaseFilter.IsValid: %v", "ReportSubType is required")
+		return newFilterError("SalesReportsBaseFilter.IsValid", "filter[reportSubType]", "ReportSubType is required")
 	}
 	if f.Frequency == "" {
-		return fmt.Errorf("SalesReportsBaseFilter.IsValid: %v", "Frequency is required")
+		return newFilterError("SalesReportsBaseFilter.IsValid", "filter[frequency]", "Frequency is required")
 	}
 	return nil
 }
@@ -236,13 +235,13 @@ func (f *SalesReportsFilter) IsValid() error {
 		return err
 	}
 	if f.ReportType != SalesReportTypeSales {
-		return fmt.Errorf("SalesReportsFilter.IsValid: %v", "ReportType is not valid")
+		return newFilterError("SalesReportsFilter.IsValid", "filter[reportType]", "ReportType is not valid")
 	}
 	if f.ReportSubType != SalesReportSubTypeSummary {
-		return fmt.Errorf("SalesReportsFilter.IsValid: %v", "ReportSubType is not valid")
+		return newFilterError("SalesReportsFilter.IsValid", "filter[reportSubType]", "ReportSubType is not valid")
 	}
 	if f.Version != SalesReportVersion11 && f.Version != SalesReportVersion10 {
-		return fmt.Errorf("SalesReportsFilter.IsValid: %v", "Version is not valid")
+		return newFilterError("SalesReportsFilter.IsValid", "filter[version]", "Version is not valid")
 	}
 	return nil
 }
@@ -259,16 +258,16 @@ func (f *SubscriptionsReportsFilter) IsValid() error {
 		return err
 	}
 	if f.ReportType != SalesReportTypeSubscription {
-		return fmt.Errorf("SubscriptionsReportsFilter.IsValid: %v", "ReportType is not valid")
+		return newFilterError("SubscriptionsReportsFilter.IsValid", "filter[reportType]", "ReportType is not valid")
 	}
 	if f.ReportSubType != SalesReportSubTypeSummary {
-		return fmt.Errorf("SubscriptionsReportsFilter.IsValid: %v", "ReportSubType is not valid")
+		return newFilterError("SubscriptionsReportsFilter.IsValid", "filter[reportSubType]", "ReportSubType is not valid")
 	}
 	if f.Frequency != SalesReportFrequencyDaily {
-		return fmt.Errorf("SubscriptionsReportsFilter.IsValid: %v", "Frequency is not valid")
+		return newFilterError("SubscriptionsReportsFilter.IsValid", "filter[frequency]", "Frequency is not valid")
 	}
 	if f.Version != SalesReportVersion12 && f.Version != SalesReportVersion13 {
-		return fmt.Errorf("SubscriptionsReportsFilter.IsValid: %v", "Version is not valid")
+		return newFilterError("SubscriptionsReportsFilter.IsValid", "filter[version]", "Version is not valid")
 	}
 	return nil
 }
@@ -285,16 +284,16 @@ func (f *SubscriptionsEventsReportsFilter) IsValid() error {
 		return err
 	}
 	if f.ReportType != SalesReportTypeSubscriptionEvent {
-		return fmt.Errorf("SubscriptionsEventsReportsFilter.IsValid: %v", "ReportType is not valid")
+		return newFilterError("SubscriptionsEventsReportsFilter.IsValid", "filter[reportType]", "ReportType is not valid")
 	}
 	if f.ReportSubType != SalesReportSubTypeSummary {
-		return fmt.Errorf("SubscriptionsEventsReportsFilter.IsValid: %v", "ReportSubType is not valid")
+		return newFilterError("SubscriptionsEventsReportsFilter.IsValid", "filter[reportSubType]", "ReportSubType is not valid")
 	}
 	if f.Frequency != SalesReportFrequencyDaily {
-		return fmt.Errorf("SubscriptionsEventsReportsFilter.IsValid: %v", "Frequency is not valid")
+		return newFilterError("SubscriptionsEventsReportsFilter.IsValid", "filter[frequency]", "Frequency is not valid")
 	}
 	if f.Version != SalesReportVersion12 && f.Version != SalesReportVersion13 {
-		return fmt.Errorf("SubscriptionsEventsReportsFilter.IsValid: %v", "Version is not valid")
+		return newFilterError("SubscriptionsEventsReportsFilter.IsValid", "filter[version]", "Version is not valid")
 	}
 	return nil
 }
@@ -311,16 +310,16 @@ func (f *SubscribersReportsFilter) IsValid() error {
 		return err
 	}
 	if f.ReportType != SalesReportTypeSubscriber {
-		return fmt.Errorf("SubscribersReportsFilter.IsValid: %v", "ReportType is not valid")
+		return newFilterError("SubscribersReportsFilter.IsValid", "filter[reportType]", "ReportType is not valid")
 	}
 	if f.ReportSubType != SalesReportSubTypeDetailed {
-		return fmt.Errorf("SubscribersReportsFilter.IsValid: %v", "ReportSubType is not valid")
+		return newFilterError("SubscribersReportsFilter.IsValid", "filter[reportSubType]", "ReportSubType is not valid")
 	}
 	if f.Frequency != SalesReportFrequencyDaily {
-		return fmt.Errorf("SubscribersReportsFilter.IsValid: %v", "Frequency is not valid")
+		return newFilterError("SubscribersReportsFilter.IsValid", "filter[frequency]", "Frequency is not valid")
 	}
 	if f.Version != SalesReportVersion12 && f.Version != SalesReportVersion13 {
-		return fmt.Errorf("SubscribersReportsFilter.IsValid: %v", "Version is not valid")
+		return newFilterError("SubscribersReportsFilter.IsValid", "filter[version]", "Version is not valid")
 	}
 	return nil
 }
@@ -337,16 +336,16 @@ func (f *SubscriptionsOffersCodesRedemptionReportsFilter) IsValid() error {
 		return err
 	}
 	if f.ReportType != SalesReportTypeSubscriptionOfferCodeRedemption {
-		return fmt.Errorf("SubscriptionsOffersCodesRedemptionReportsFilter.IsValid: %v", "ReportType is not valid")
+		return newFilterError("SubscriptionsOffersCodesRedemptionReportsFilter.IsValid", "filter[reportType]", "ReportType is not valid")
 	}
 	if f.ReportSubType != SalesReportSubTypeSummary {
-		return fmt.Errorf("SubscriptionsOffersCodesRedemptionReportsFilter.IsValid: %v", "ReportSubType is not valid")
+		return newFilterError("SubscriptionsOffersCodesRedemptionReportsFilter.IsValid", "filter[reportSubType]", "ReportSubType is not valid")
 	}
 	if f.Frequency != SalesReportFrequencyDaily {
-		return fmt.Errorf("SubscriptionsOffersCodesRedemptionReportsFilter.IsValid: %v", "Frequency is not valid")
+		return newFilterError("SubscriptionsOffersCodesRedemptionReportsFilter.IsValid", "filter[frequency]", "Frequency is not valid")
 	}
 	if f.Version != SalesReportVersion10 {
-		return fmt.Errorf("SubscriptionsOffersCodesRedemptionReportsFilter.IsValid: %v", "Version is not valid")
+		return newFilterError("SubscriptionsOffersCodesRedemptionReportsFilter.IsValid", "filter[version]", "Version is not valid")
 	}
 	return nil
 }
@@ -363,16 +362,16 @@ func (f *NewsstandReportsFilter) IsValid() error {
 		return err
 	}
 	if f.ReportType != SalesReportTypeNewsStand {
-		return fmt.Errorf("NewsstandReportsFilter.IsValid: %v", "ReportType is not valid")
+		return newFilterError("NewsstandReportsFilter.IsValid", "filter[reportType]", "ReportType is not valid")
 	}
 	if f.ReportSubType != SalesReportSubTypeDetailed {
-		return fmt.Errorf("NewsstandReportsFilter.IsValid: %v", "ReportSubType is not valid")
+		return newFilterError("NewsstandReportsFilter.IsValid", "filter[reportSubType]", "ReportSubType is not valid")
 	}
 	if f.Frequency != SalesReportFrequencyDaily && f.Frequency != SalesReportFrequencyWeekly {
-		return fmt.Errorf("NewsstandReportsFilter.IsValid: %v", "Frequency is not valid")
+		return newFilterError("NewsstandReportsFilter.IsValid", "filter[frequency]", "Frequency is not valid")
 	}
 	if f.Version != SalesReportVersion10 {
-		return fmt.Errorf("NewsstandReportsFilter.IsValid: %v", "Version is not valid")
+		return newFilterError("NewsstandReportsFilter.IsValid", "filter[version]", "Version is not valid")
 	}
 	return nil
 }
@@ -389,13 +388,13 @@ func (f *PreOrdersReportsFilter) IsValid() error {
 		return err
 	}
 	if f.ReportType != SalesReportTypePreorder {
-		return fmt.Errorf("PreOrdersReportsFilter.IsValid: %v", "ReportType is not valid")
+		return newFilterError("PreOrdersReportsFilter.IsValid", "filter[reportType]", "ReportType is not valid")
 	}
 	if f.ReportSubType != SalesReportSubTypeSummary {
-		return fmt.Errorf("PreOrdersReportsFilter.IsValid: %v", "ReportSubType is not valid")
+		return newFilterError("PreOrdersReportsFilter.IsValid", "filter[reportSubType]", "ReportSubType is not valid")
 	}
 	if f.Version != SalesReportVersion10 {
-		return fmt.Errorf("PreOrdersReportsFilter.IsValid: %v", "Version is not valid")
+		return newFilterError("PreOrdersReportsFilter.IsValid", "filter[version]", "Version is not valid")
 	}
 	return nil
 }
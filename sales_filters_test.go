@@ -1,6 +1,7 @@
 package appstore
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"testing"
@@ -157,6 +158,9 @@ func (suite *SalesReportsFilterTestSuite) TestIsInvalidVersion() {
 	err := suite.testable.IsValid()
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "SalesReportsFilter.IsValid: Version is not valid", err.Error())
+	var filterErr *FilterError
+	assert.True(suite.T(), errors.As(err, &filterErr))
+	assert.Equal(suite.T(), "filter[version]", filterErr.Parameter)
 }
 
 func TestSalesReportsFilterTestSuite(t *testing.T) {
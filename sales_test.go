@@ -71,17 +71,17 @@ func (suite *SalesReportsResourceTestSuite) TestGetSalesReportsSuccess() {
 	assert.Equal(suite.T(), " ", result.Data[0].Developer)
 	assert.Equal(suite.T(), "FooBarTitle", result.Data[0].Title)
 	assert.Equal(suite.T(), "", result.Data[0].Version)
-	assert.Equal(suite.T(), "IAY", result.Data[0].ProductTypeIdentifier)
+	assert.Equal(suite.T(), ProductTypeIdentifierInAppAutoRenewableSubscription, result.Data[0].ProductTypeIdentifier)
 	assert.Equal(suite.T(), float64(12), result.Data[0].Units.Value())
 	assert.Equal(suite.T(), 1234567890, result.Data[0].AppleIdentifier.Value())
-	assert.Equal(suite.T(), 209.3000030517578, result.Data[0].DeveloperProceeds.Value())
+	assert.Equal(suite.T(), "209.30 RUB", result.Data[0].DeveloperProceeds.String())
 	assert.Equal(suite.T(), "2020-10-05", result.Data[0].BeginDate.Value().Format(CustomDateFormatDefault))
 	assert.Equal(suite.T(), "2020-10-05", result.Data[0].EndDate.Value().Format(CustomDateFormatDefault))
 	assert.Equal(suite.T(), "RUB", result.Data[0].CustomerCurrency)
 	assert.Equal(suite.T(), "RU", result.Data[0].CountryCode)
 	assert.Equal(suite.T(), "RUB", result.Data[0].CurrencyOfProceeds)
 	assert.Equal(suite.T(), 1234567890, result.Data[0].AppleIdentifier.Value())
-	assert.Equal(suite.T(), float64(299), result.Data[0].CustomerPrice.Value())
+	assert.Equal(suite.T(), "299.00 RUB", result.Data[0].CustomerPrice.String())
 	assert.Equal(suite.T(), " ", result.Data[0].PromoCode)
 	assert.Equal(suite.T(), "foo.bar.baz", result.Data[0].ParentIdentifier)
 	assert.Equal(suite.T(), "Renewal", result.Data[0].Subscription)
@@ -158,9 +158,9 @@ func (suite *SalesReportsResourceTestSuite) TestGetSubscriptionsReportsSuccess()
 	assert.Equal(suite.T(), "1 Year", result.Data[0].StandardSubscriptionDuration)
 	assert.Equal(suite.T(), " ", result.Data[0].PromotionalOfferName)
 	assert.Equal(suite.T(), " ", result.Data[0].PromotionalOfferID)
-	assert.Equal(suite.T(), float64(2950), result.Data[0].CustomerPrice.Value())
+	assert.Equal(suite.T(), "2950.00 RUB", result.Data[0].CustomerPrice.String())
 	assert.Equal(suite.T(), "RUB", result.Data[0].CustomerCurrency)
-	assert.Equal(suite.T(), 2065.00, result.Data[0].DeveloperProceeds.Value())
+	assert.Equal(suite.T(), "2065.00 RUB", result.Data[0].DeveloperProceeds.String())
 	assert.Equal(suite.T(), "RUB", result.Data[0].ProceedsCurrency)
 	assert.Equal(suite.T(), "", result.Data[0].PreservedPricing)
 	assert.Equal(suite.T(), "", result.Data[0].ProceedsReason)
@@ -224,7 +224,7 @@ func (suite *SalesReportsResourceTestSuite) TestGetSubscriptionsEventsReportsSuc
 	assert.Empty(suite.T(), result.GetError())
 	assert.Empty(suite.T(), result.Errors)
 	assert.Equal(suite.T(), "2020-10-06", result.Data[0].EventDate.Value().Format(CustomDateFormatDefault))
-	assert.Equal(suite.T(), "Renew", result.Data[0].Event)
+	assert.Equal(suite.T(), SubscriptionEventRenew, result.Data[0].Event)
 	assert.Equal(suite.T(), "AppFooBar", result.Data[0].AppName)
 	assert.Equal(suite.T(), 1234567890, result.Data[0].AppAppleID.Value())
 	assert.Equal(suite.T(), "foo.bar.baz", result.Data[0].SubscriptionName)
@@ -309,9 +309,9 @@ func (suite *SalesReportsResourceTestSuite) TestGetSubscribersReportsSuccess() {
 	assert.Equal(suite.T(), "", result.Data[0].SubscriptionOfferType)
 	assert.Equal(suite.T(), "", result.Data[0].SubscriptionOfferDuration)
 	assert.Equal(suite.T(), "", result.Data[0].MarketingOptInDuration)
-	assert.Equal(suite.T(), 4.489999771118164, result.Data[0].CustomerPrice.Value())
+	assert.Equal(suite.T(), "4.49 USD", result.Data[0].CustomerPrice.String())
 	assert.Equal(suite.T(), "USD", result.Data[0].CustomerCurrency)
-	assert.Equal(suite.T(), 3.1500000953674316, result.Data[0].DeveloperProceeds.Value())
+	assert.Equal(suite.T(), "3.15 USD", result.Data[0].DeveloperProceeds.String())
 	assert.Equal(suite.T(), "USD", result.Data[0].ProceedsCurrency)
 	assert.Equal(suite.T(), " ", result.Data[0].PreservedPricing)
 	assert.Equal(suite.T(), " ", result.Data[0].ProceedsReason)
@@ -0,0 +1,99 @@
+package sqlstore
+
+import (
+	"fmt"
+	"github.com/matisiekpl/appstore-sdk-go/columnar"
+	"strings"
+)
+
+//Dialect SQL syntax of database backend
+type Dialect struct {
+	Name        string
+	Placeholder func(n int) string                   //bind parameter of n-th argument, starting from 1
+	ColumnType  func(column *columnar.Column) string //column type of table definition
+}
+
+//DialectSQLite SQLite dialect, reference backend. Money is stored as TEXT to keep exact decimal value,
+//dates as TEXT in YYYY-MM-DD format
+var DialectSQLite = &Dialect{
+	Name: "sqlite",
+	Placeholder: func(n int) string {
+		return "?"
+	},
+	ColumnType: func(column *columnar.Column) string {
+		switch column.Type {
+		case columnar.ColumnTypeInteger:
+			return "INTEGER"
+		case columnar.ColumnTypeFloat:
+			return "REAL"
+		case columnar.ColumnTypeDate:
+			return "DATE"
+		case columnar.ColumnTypeTimestamp:
+			return "TIMESTAMP"
+		case columnar.ColumnTypeBoolean:
+			return "BOOLEAN"
+		}
+		return "TEXT"
+	},
+}
+
+//DialectPostgres PostgreSQL dialect
+var DialectPostgres = &Dialect{
+	Name: "postgres",
+	Placeholder: func(n int) string {
+		return fmt.Sprintf("$%d", n)
+	},
+	ColumnType: func(column *columnar.Column) string {
+		switch column.Type {
+		case columnar.ColumnTypeInteger:
+			return "BIGINT"
+		case columnar.ColumnTypeFloat:
+			return "DOUBLE PRECISION"
+		case columnar.ColumnTypeDecimal:
+			return fmt.Sprintf("NUMERIC(%d,%d)", column.Precision, column.Scale)
+		case columnar.ColumnTypeDate:
+			return "DATE"
+		case columnar.ColumnTypeTimestamp:
+			return "TIMESTAMP"
+		case columnar.ColumnTypeBoolean:
+			return "BOOLEAN"
+		}
+		return "TEXT"
+	},
+}
+
+//quote Quote identifier
+func quote(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+//placeholders Get comma separated bind parameters of n arguments starting after offset
+func (d *Dialect) placeholders(offset int, n int) string {
+	params := make([]string, n)
+	for i := range params {
+		params[i] = d.Placeholder(offset + i + 1)
+	}
+	return strings.Join(params, ", ")
+}
+
+//upsert Build insert statement updating columns of existing row with same keys
+func (d *Dialect) upsert(table string, columns []string, keys []string) string {
+	quoted := make([]string, len(columns))
+	updates := make([]string, 0, len(columns))
+	isKey := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		isKey[key] = true
+	}
+	for i, column := range columns {
+		quoted[i] = quote(column)
+		if !isKey[column] {
+			updates = append(updates, fmt.Sprintf("%s = excluded.%s", quote(column), quote(column)))
+		}
+	}
+	quotedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		quotedKeys[i] = quote(key)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s",
+		quote(table), strings.Join(quoted, ", "), d.placeholders(0, len(columns)), strings.Join(quotedKeys, ", "), strings.Join(updates, ", "))
+}
@@ -0,0 +1,37 @@
+package sqlstore
+
+import (
+	"github.com/matisiekpl/appstore-sdk-go/columnar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type DialectTestSuite struct {
+	suite.Suite
+}
+
+func (suite *DialectTestSuite) TestColumnType() {
+	decimalColumn := &columnar.Column{Type: columnar.ColumnTypeDecimal, Precision: 18, Scale: 6}
+	assert.Equal(suite.T(), "TEXT", DialectSQLite.ColumnType(decimalColumn))
+	assert.Equal(suite.T(), "NUMERIC(18,6)", DialectPostgres.ColumnType(decimalColumn))
+	assert.Equal(suite.T(), "INTEGER", DialectSQLite.ColumnType(&columnar.Column{Type: columnar.ColumnTypeInteger}))
+	assert.Equal(suite.T(), "BIGINT", DialectPostgres.ColumnType(&columnar.Column{Type: columnar.ColumnTypeInteger}))
+	assert.Equal(suite.T(), "DOUBLE PRECISION", DialectPostgres.ColumnType(&columnar.Column{Type: columnar.ColumnTypeFloat}))
+	assert.Equal(suite.T(), "TEXT", DialectPostgres.ColumnType(&columnar.Column{Type: columnar.ColumnTypeString}))
+}
+
+func (suite *DialectTestSuite) TestUpsert() {
+	assert.Equal(suite.T(), `INSERT INTO "foo" ("a", "b", "c") VALUES (?, ?, ?) ON CONFLICT ("a") DO UPDATE SET "b" = excluded."b", "c" = excluded."c"`,
+		DialectSQLite.upsert("foo", []string{"a", "b", "c"}, []string{"a"}))
+	assert.Equal(suite.T(), `INSERT INTO "foo" ("a", "b") VALUES ($1, $2) ON CONFLICT ("a") DO UPDATE SET "b" = excluded."b"`,
+		DialectPostgres.upsert("foo", []string{"a", "b"}, []string{"a"}))
+}
+
+func (suite *DialectTestSuite) TestQuote() {
+	assert.Equal(suite.T(), `"foo ""bar"""`, quote(`foo "bar"`))
+}
+
+func TestDialectTestSuite(t *testing.T) {
+	suite.Run(t, new(DialectTestSuite))
+}
@@ -0,0 +1,219 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"reflect"
+	"time"
+)
+
+//IngestionsTable name of ingestion log table
+const IngestionsTable = "ingestions"
+
+//Load identity of downloaded report file
+type Load struct {
+	VendorNumber string
+	ReportType   string //sales report type or finances report type
+	Frequency    string //report frequency, MONTHLY for finances reports
+	Version      string //report version, empty for finances reports
+	RegionCode   string //region code of finances reports
+	ReportDate   time.Time
+}
+
+//NewSalesLoad Create load of sales report downloaded by filter
+func NewSalesLoad(vendorNumber string, filter *appstore.SalesReportsBaseFilter) *Load {
+	return &Load{
+		VendorNumber: vendorNumber,
+		ReportType:   string(filter.ReportType),
+		Frequency:    string(filter.Frequency),
+		Version:      string(filter.Version),
+		ReportDate:   filter.ReportDate,
+	}
+}
+
+//NewFinancesLoad Create load of finances report downloaded by filter
+func NewFinancesLoad(vendorNumber string, filter *appstore.FinancesReportsFilter) *Load {
+	return &Load{
+		VendorNumber: vendorNumber,
+		ReportType:   string(filter.ReportType),
+		Frequency:    string(appstore.SalesReportFrequencyMonthly),
+		RegionCode:   filter.RegionCode,
+		ReportDate:   filter.ReportDate,
+	}
+}
+
+//Ingestion ingestion log entry of loaded report
+type Ingestion struct {
+	Load
+	Rows     int
+	LoadedAt time.Time
+}
+
+//Store SQL sink of reports. Rows are upserted by natural key, so loading same report again does not duplicate data
+type Store struct {
+	DB      *sql.DB
+	Dialect *Dialect
+	Tables  map[string]*Table //tables by report type
+	now     func() time.Time
+}
+
+//NewStore Create new store of database with default report tables
+func NewStore(db *sql.DB, dialect *Dialect) *Store {
+	return &Store{DB: db, Dialect: dialect, Tables: DefaultTables(), now: time.Now}
+}
+
+//Migrate Create report tables and ingestion log table when they do not exist
+func (s *Store) Migrate(ctx context.Context) error {
+	statements := []string{fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	"vendor_number" TEXT NOT NULL,
+	"report_type" TEXT NOT NULL,
+	"frequency" TEXT NOT NULL,
+	"version" TEXT NOT NULL,
+	"region_code" TEXT NOT NULL,
+	"report_date" DATE NOT NULL,
+	"rows" INTEGER NOT NULL,
+	"loaded_at" TIMESTAMP NOT NULL,
+	PRIMARY KEY ("vendor_number", "report_type", "frequency", "version", "region_code", "report_date")
+)`, quote(IngestionsTable))}
+	created := make(map[*Table]bool)
+	for _, table := range s.Tables {
+		if !created[table] {
+			created[table] = true
+			statements = append(statements, table.create(s.Dialect))
+		}
+	}
+	for _, statement := range statements {
+		if _, err := s.DB.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("Store.Migrate: %v", err)
+		}
+	}
+	return nil
+}
+
+//Upsert Insert or update report rows of load and record it in ingestion log in single transaction.
+//Rows is slice of report row structs or pointers to them, returns number of rows
+func (s *Store) Upsert(ctx context.Context, load *Load, rows interface{}) (int, error) {
+	table, ok := s.Tables[load.ReportType]
+	if !ok {
+		return 0, fmt.Errorf("Store.Upsert: no table of report type %s", load.ReportType)
+	}
+	rv := reflect.ValueOf(rows)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return 0, fmt.Errorf("Store.Upsert: slice of rows expected, got %v", rv.Kind())
+	}
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("Store.Upsert: %v", err)
+	}
+	count, err := s.upsert(ctx, tx, table, load, rv)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("Store.Upsert: %v", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("Store.Upsert: %v", err)
+	}
+	return count, nil
+}
+
+//upsert Upsert rows and ingestion log entry in transaction
+func (s *Store) upsert(ctx context.Context, tx *sql.Tx, table *Table, load *Load, rows reflect.Value) (int, error) {
+	loadedAt := s.now().UTC()
+	stmt, err := tx.PrepareContext(ctx, s.Dialect.upsert(table.Name, table.columns(), primaryKey))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	for i := 0; i < rows.Len(); i++ {
+		values, err := table.values(load, loadedAt, rows.Index(i).Interface())
+		if err != nil {
+			return 0, fmt.Errorf("row %d: %v", i, err)
+		}
+		if _, err = stmt.ExecContext(ctx, values...); err != nil {
+			return 0, fmt.Errorf("row %d: %v", i, err)
+		}
+	}
+	_, err = tx.ExecContext(ctx, s.Dialect.upsert(IngestionsTable,
+		[]string{"vendor_number", "report_type", "frequency", "version", "region_code", "report_date", "rows", "loaded_at"},
+		[]string{"vendor_number", "report_type", "frequency", "version", "region_code", "report_date"}),
+		load.VendorNumber, load.ReportType, load.Frequency, load.Version, load.RegionCode, formatDate(load.ReportDate), rows.Len(), loadedAt)
+	if err != nil {
+		return 0, err
+	}
+	return rows.Len(), nil
+}
+
+//Loaded Check whether report of load was already loaded
+func (s *Store) Loaded(ctx context.Context, load *Load) (bool, error) {
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE "vendor_number" = %s AND "report_type" = %s AND "frequency" = %s AND "version" = %s AND "region_code" = %s AND "report_date" = %s`,
+		quote(IngestionsTable), s.Dialect.Placeholder(1), s.Dialect.Placeholder(2), s.Dialect.Placeholder(3),
+		s.Dialect.Placeholder(4), s.Dialect.Placeholder(5), s.Dialect.Placeholder(6))
+	err := s.DB.QueryRowContext(ctx, query, load.VendorNumber, load.ReportType, load.Frequency, load.Version, load.RegionCode, formatDate(load.ReportDate)).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("Store.Loaded: %v", err)
+	}
+	return count > 0, nil
+}
+
+//Ingestions Get ingestion log entries of vendor ordered by report date
+func (s *Store) Ingestions(ctx context.Context, vendorNumber string) ([]*Ingestion, error) {
+	query := fmt.Sprintf(`SELECT "report_type", "frequency", "version", "region_code", "report_date", "rows", "loaded_at" FROM %s WHERE "vendor_number" = %s ORDER BY "report_date", "report_type", "frequency", "version", "region_code"`,
+		quote(IngestionsTable), s.Dialect.Placeholder(1))
+	rows, err := s.DB.QueryContext(ctx, query, vendorNumber)
+	if err != nil {
+		return nil, fmt.Errorf("Store.Ingestions: %v", err)
+	}
+	defer rows.Close()
+	ingestions := make([]*Ingestion, 0)
+	for rows.Next() {
+		ingestion := &Ingestion{Load: Load{VendorNumber: vendorNumber}}
+		var reportDate, loadedAt timeValue
+		err = rows.Scan(&ingestion.ReportType, &ingestion.Frequency, &ingestion.Version, &ingestion.RegionCode, &reportDate, &ingestion.Rows, &loadedAt)
+		if err != nil {
+			return nil, fmt.Errorf("Store.Ingestions: %v", err)
+		}
+		ingestion.ReportDate = reportDate.Time
+		ingestion.LoadedAt = loadedAt.Time
+		ingestions = append(ingestions, ingestion)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("Store.Ingestions: %v", err)
+	}
+	return ingestions, nil
+}
+
+//timeValue scanner of DATE and TIMESTAMP columns, drivers return either time.Time or text
+type timeValue struct {
+	Time time.Time
+}
+
+//Scan Scan time or text value
+func (t *timeValue) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		t.Time = v
+		return nil
+	case string:
+		return t.parse(v)
+	case []byte:
+		return t.parse(string(v))
+	}
+	return fmt.Errorf("unsupported time value %T", src)
+}
+
+//parse Parse date or timestamp text
+func (t *timeValue) parse(text string) error {
+	for _, layout := range []string{"2006-01-02", time.RFC3339Nano, "2006-01-02 15:04:05.999999999-07:00", "2006-01-02 15:04:05.999999999"} {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported time value %s", text)
+}
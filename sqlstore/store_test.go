@@ -0,0 +1,217 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type StoreTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	db       *sql.DB
+	testable *Store
+	load     *Load
+}
+
+func (suite *StoreTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(suite.T(), err)
+	//every connection opens separate in-memory database
+	db.SetMaxOpenConns(1)
+	suite.ctx = context.Background()
+	suite.db = db
+	suite.testable = NewStore(db, DialectSQLite)
+	suite.testable.now = func() time.Time {
+		return time.Date(2020, 5, 6, 10, 0, 0, 0, time.UTC)
+	}
+	suite.load = &Load{VendorNumber: "123", ReportType: "SALES", Frequency: "DAILY", Version: "1_0", ReportDate: time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC)}
+	assert.NoError(suite.T(), suite.testable.Migrate(suite.ctx))
+}
+
+func (suite *StoreTestSuite) TearDownTest() {
+	_ = suite.db.Close()
+}
+
+func (suite *StoreTestSuite) salesReports(units float64) []*appstore.SalesReport {
+	return []*appstore.SalesReport{
+		{
+			SKU:               "foo",
+			CountryCode:       "US",
+			Version:           "1.0",
+			Units:             appstore.CustomFloat64{Float64: units},
+			DeveloperProceeds: appstore.NewMoney(decimal.RequireFromString("0.70"), "USD"),
+			BeginDate:         appstore.CustomDate{Date: time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			SKU:               "foo",
+			CountryCode:       "PL",
+			Version:           "1.0",
+			Units:             appstore.CustomFloat64{Float64: 1},
+			DeveloperProceeds: appstore.Money{Null: true},
+		},
+	}
+}
+
+func (suite *StoreTestSuite) count(table string) int {
+	var count int
+	assert.NoError(suite.T(), suite.db.QueryRow("SELECT COUNT(*) FROM "+quote(table)).Scan(&count))
+	return count
+}
+
+func (suite *StoreTestSuite) TestMigrate() {
+	assert.NoError(suite.T(), suite.testable.Migrate(suite.ctx))
+	for _, table := range []string{IngestionsTable, "sales_reports", "pre_orders_reports", "subscriptions_reports", "subscriptions_events_reports",
+		"subscribers_reports", "subscriptions_offers_redemption_reports", "financial_reports"} {
+		assert.Equal(suite.T(), 0, suite.count(table))
+	}
+}
+
+func (suite *StoreTestSuite) TestUpsert() {
+	count, err := suite.testable.Upsert(suite.ctx, suite.load, suite.salesReports(2))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, count)
+	assert.Equal(suite.T(), 2, suite.count("sales_reports"))
+	var units float64
+	var proceeds, beginDate, vendorNumber string
+	err = suite.db.QueryRow(`SELECT "units", "developer_proceeds", "begin_date", "vendor_number" FROM "sales_reports" WHERE "country_code" = 'US'`).
+		Scan(&units, &proceeds, &beginDate, &vendorNumber)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), float64(2), units)
+	assert.Equal(suite.T(), "0.700000", proceeds)
+	assert.Equal(suite.T(), "2020-05-05T00:00:00Z", beginDate)
+	assert.Equal(suite.T(), "123", vendorNumber)
+	var nullProceeds sql.NullString
+	assert.NoError(suite.T(), suite.db.QueryRow(`SELECT "developer_proceeds" FROM "sales_reports" WHERE "country_code" = 'PL'`).Scan(&nullProceeds))
+	assert.False(suite.T(), nullProceeds.Valid)
+}
+
+func (suite *StoreTestSuite) TestUpsertIdempotent() {
+	_, err := suite.testable.Upsert(suite.ctx, suite.load, suite.salesReports(2))
+	assert.NoError(suite.T(), err)
+	_, err = suite.testable.Upsert(suite.ctx, suite.load, suite.salesReports(5))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, suite.count("sales_reports"))
+	assert.Equal(suite.T(), 1, suite.count(IngestionsTable))
+	var units float64
+	assert.NoError(suite.T(), suite.db.QueryRow(`SELECT "units" FROM "sales_reports" WHERE "country_code" = 'US'`).Scan(&units))
+	assert.Equal(suite.T(), float64(5), units)
+
+	next := *suite.load
+	next.ReportDate = next.ReportDate.AddDate(0, 0, 1)
+	_, err = suite.testable.Upsert(suite.ctx, &next, suite.salesReports(2))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 4, suite.count("sales_reports"))
+	assert.Equal(suite.T(), 2, suite.count(IngestionsTable))
+}
+
+func (suite *StoreTestSuite) TestUpsertFinances() {
+	load := NewFinancesLoad("123", &appstore.FinancesReportsFilter{
+		ReportDate: time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
+		ReportType: appstore.FinancesReportTypeFinancial,
+		RegionCode: "US",
+	})
+	rows := []appstore.FinancialReport{
+		{CountryOfSale: "US", Quantity: appstore.CustomInteger{Integer: 3}, PartnerShare: appstore.NewMoney(decimal.RequireFromString("0.7"), "USD")},
+	}
+	count, err := suite.testable.Upsert(suite.ctx, load, rows)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, count)
+	assert.Equal(suite.T(), 1, suite.count("financial_reports"))
+	loaded, err := suite.testable.Loaded(suite.ctx, load)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), loaded)
+}
+
+func (suite *StoreTestSuite) TestUpsertFinancesKeyedByLoad() {
+	rows := []appstore.FinancialReport{
+		{CountryOfSale: "US", Quantity: appstore.CustomInteger{Integer: 3}, PartnerShare: appstore.NewMoney(decimal.RequireFromString("0.7"), "USD")},
+	}
+	date := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+	filters := []*appstore.FinancesReportsFilter{
+		{ReportDate: date, ReportType: appstore.FinancesReportTypeFinancial, RegionCode: "US"},
+		{ReportDate: date, ReportType: appstore.FinancesReportTypeFinancial, RegionCode: "EU"},
+		{ReportDate: date, ReportType: appstore.FinancesReportTypeFinanceDetail, RegionCode: "Z1"},
+	}
+	for _, filter := range filters {
+		_, err := suite.testable.Upsert(suite.ctx, NewFinancesLoad("123", filter), rows)
+		assert.NoError(suite.T(), err)
+	}
+	//same rows of different report type or region are kept apart, loading them again does not duplicate them
+	assert.Equal(suite.T(), 3, suite.count("financial_reports"))
+	_, err := suite.testable.Upsert(suite.ctx, NewFinancesLoad("123", filters[0]), rows)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, suite.count("financial_reports"))
+
+	//same sales rows of different report version are kept apart
+	_, err = suite.testable.Upsert(suite.ctx, suite.load, suite.salesReports(1))
+	assert.NoError(suite.T(), err)
+	load := *suite.load
+	load.Version = "1_1"
+	_, err = suite.testable.Upsert(suite.ctx, &load, suite.salesReports(1))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 4, suite.count("sales_reports"))
+}
+
+func (suite *StoreTestSuite) TestUpsertInvalid() {
+	_, err := suite.testable.Upsert(suite.ctx, &Load{ReportType: "NEWSSTAND"}, suite.salesReports(1))
+	assert.Error(suite.T(), err)
+	_, err = suite.testable.Upsert(suite.ctx, suite.load, suite.salesReports(1)[0])
+	assert.Error(suite.T(), err)
+	_, err = suite.testable.Upsert(suite.ctx, suite.load, []*appstore.SalesReport{{SKU: "foo"}, nil})
+	assert.Error(suite.T(), err)
+	_, err = suite.testable.Upsert(suite.ctx, suite.load, []*appstore.PreOrdersReport{{SKU: "foo"}})
+	assert.Error(suite.T(), err)
+	//failed load is rolled back
+	assert.Equal(suite.T(), 0, suite.count("sales_reports"))
+	assert.Equal(suite.T(), 0, suite.count(IngestionsTable))
+}
+
+func (suite *StoreTestSuite) TestIngestions() {
+	loaded, err := suite.testable.Loaded(suite.ctx, suite.load)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), loaded)
+	_, err = suite.testable.Upsert(suite.ctx, suite.load, suite.salesReports(1))
+	assert.NoError(suite.T(), err)
+	loaded, err = suite.testable.Loaded(suite.ctx, suite.load)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), loaded)
+	other := *suite.load
+	other.Version = "1_1"
+	loaded, _ = suite.testable.Loaded(suite.ctx, &other)
+	assert.False(suite.T(), loaded)
+
+	ingestions, err := suite.testable.Ingestions(suite.ctx, "123")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), ingestions, 1)
+	assert.Equal(suite.T(), "SALES", ingestions[0].ReportType)
+	assert.Equal(suite.T(), "DAILY", ingestions[0].Frequency)
+	assert.Equal(suite.T(), "1_0", ingestions[0].Version)
+	assert.Equal(suite.T(), 2, ingestions[0].Rows)
+	assert.True(suite.T(), suite.load.ReportDate.Equal(ingestions[0].ReportDate))
+	assert.True(suite.T(), time.Date(2020, 5, 6, 10, 0, 0, 0, time.UTC).Equal(ingestions[0].LoadedAt))
+	ingestions, err = suite.testable.Ingestions(suite.ctx, "456")
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), ingestions)
+}
+
+func (suite *StoreTestSuite) TestNewSalesLoad() {
+	filter := appstore.NewSubscriptionsReportsFilter()
+	filter.SetReportDate(time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC))
+	load := NewSalesLoad("123", &filter.SalesReportsBaseFilter)
+	assert.Equal(suite.T(), "123", load.VendorNumber)
+	assert.Equal(suite.T(), string(filter.ReportType), load.ReportType)
+	assert.Equal(suite.T(), string(filter.Frequency), load.Frequency)
+	assert.Equal(suite.T(), string(filter.Version), load.Version)
+	assert.Equal(suite.T(), filter.ReportDate, load.ReportDate)
+}
+
+func TestStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(StoreTestSuite))
+}
@@ -0,0 +1,214 @@
+package sqlstore
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/matisiekpl/appstore-sdk-go/columnar"
+	"github.com/shopspring/decimal"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//Metadata columns of report tables, row of report is identified by its load and row key
+const (
+	ColumnVendorNumber  = "vendor_number"
+	ColumnReportType    = "report_type"
+	ColumnFrequency     = "frequency"
+	ColumnReportVersion = "report_version" //version column of sales reports is app version
+	ColumnRegionCode    = "region_code"    //region of finances reports, blank for sales reports
+	ColumnReportDate    = "report_date"
+	ColumnRowKey        = "row_key" //SHA-1 of natural key values, blank values of keys are not unique in SQL
+	ColumnLoadedAt      = "loaded_at"
+)
+
+//primaryKey Primary key columns of report tables, reports of different type, region or version sharing table do not overwrite each other
+var primaryKey = []string{ColumnVendorNumber, ColumnReportType, ColumnFrequency, ColumnReportVersion, ColumnRegionCode, ColumnReportDate, ColumnRowKey}
+
+//rowKeyIndex Index of row key in insert values
+const rowKeyIndex = 6
+
+//metadataColumns Get names of metadata columns in insert order
+func metadataColumns() []string {
+	return append(append([]string{}, primaryKey...), ColumnLoadedAt)
+}
+
+//Table report table, columns are created from report row struct tags
+type Table struct {
+	Name     string
+	Schema   *columnar.Schema
+	Measures []string //columns of counts and amounts, row is keyed by other columns
+}
+
+//NewTable Create table of report row struct, rows are keyed by all columns except measures
+func NewTable(name string, row interface{}, measures ...string) (*Table, error) {
+	schema, err := columnar.NewSchema(row)
+	if err != nil {
+		return nil, fmt.Errorf("NewTable: %v", err)
+	}
+	for _, measure := range measures {
+		if _, ok := schema.Column(measure); !ok {
+			return nil, fmt.Errorf("NewTable: unknown measure column %s", measure)
+		}
+	}
+	for _, name := range metadataColumns() {
+		if _, ok := schema.Column(name); ok {
+			return nil, fmt.Errorf("NewTable: column %s conflicts with metadata column", name)
+		}
+	}
+	return &Table{Name: name, Schema: schema, Measures: measures}, nil
+}
+
+//Keys Get natural key columns of row
+func (t *Table) Keys() []*columnar.Column {
+	measures := make(map[string]bool, len(t.Measures))
+	for _, measure := range t.Measures {
+		measures[measure] = true
+	}
+	keys := make([]*columnar.Column, 0, len(t.Schema.Columns))
+	for _, column := range t.Schema.Columns {
+		if !measures[column.Name] {
+			keys = append(keys, column)
+		}
+	}
+	return keys
+}
+
+//columns Get names of metadata and report columns in insert order
+func (t *Table) columns() []string {
+	columns := metadataColumns()
+	for _, column := range t.Schema.Columns {
+		columns = append(columns, column.Name)
+	}
+	return columns
+}
+
+//create Build create table statement
+func (t *Table) create(dialect *Dialect) string {
+	definitions := []string{
+		quote(ColumnVendorNumber) + " TEXT NOT NULL",
+		quote(ColumnReportType) + " TEXT NOT NULL",
+		quote(ColumnFrequency) + " TEXT NOT NULL",
+		quote(ColumnReportVersion) + " TEXT NOT NULL",
+		quote(ColumnRegionCode) + " TEXT NOT NULL",
+		quote(ColumnReportDate) + " DATE NOT NULL",
+		quote(ColumnRowKey) + " TEXT NOT NULL",
+		quote(ColumnLoadedAt) + " TIMESTAMP NOT NULL",
+	}
+	for _, column := range t.Schema.Columns {
+		definitions = append(definitions, quote(column.Name)+" "+dialect.ColumnType(column))
+	}
+	keys := make([]string, len(primaryKey))
+	for i, key := range primaryKey {
+		keys[i] = quote(key)
+	}
+	definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(keys, ", ")))
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n\t%s\n)", quote(t.Name), strings.Join(definitions, ",\n\t"))
+}
+
+//values Get insert values of report row
+func (t *Table) values(load *Load, loadedAt time.Time, row interface{}) ([]interface{}, error) {
+	rv := reflect.ValueOf(row)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("nil row")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Type() != t.Schema.RowType() {
+		return nil, fmt.Errorf("row of type %v expected, got %v", t.Schema.RowType(), rv.Type())
+	}
+	values := make([]interface{}, 0, len(t.Schema.Columns)+len(primaryKey)+1)
+	values = append(values, load.VendorNumber, load.ReportType, load.Frequency, load.Version, load.RegionCode,
+		formatDate(load.ReportDate), "", loadedAt)
+	hash := sha1.New()
+	isKey := make(map[*columnar.Column]bool)
+	for _, column := range t.Keys() {
+		isKey[column] = true
+	}
+	for _, column := range t.Schema.Columns {
+		v, err := columnValue(column, column.Field(rv))
+		if err != nil {
+			return nil, err
+		}
+		if isKey[column] {
+			//unit separator between values and distinct marker of null keep keys unambiguous
+			if v == nil {
+				hash.Write([]byte{0})
+			} else {
+				fmt.Fprintf(hash, "%v", v)
+			}
+			hash.Write([]byte{0x1f})
+		}
+		values = append(values, v)
+	}
+	values[rowKeyIndex] = hex.EncodeToString(hash.Sum(nil))
+	return values, nil
+}
+
+//columnValue Get database value of field, blank and null report values are NULL
+func columnValue(column *columnar.Column, field reflect.Value) (interface{}, error) {
+	v, err := columnar.DriverValue(field)
+	if err != nil {
+		return nil, fmt.Errorf("column %s: %v", column.Name, err)
+	}
+	if v == nil {
+		return nil, nil
+	}
+	switch column.Type {
+	case columnar.ColumnTypeDecimal:
+		amount, err := decimal.NewFromString(fmt.Sprint(v))
+		if err != nil {
+			return nil, fmt.Errorf("column %s value %v is not decimal: %v", column.Name, v, err)
+		}
+		return amount.StringFixed(int32(column.Scale)), nil
+	case columnar.ColumnTypeDate:
+		if date, ok := v.(time.Time); ok {
+			return formatDate(date), nil
+		}
+	case columnar.ColumnTypeTimestamp:
+		if timestamp, ok := v.(time.Time); ok {
+			return timestamp.UTC(), nil
+		}
+	}
+	return v, nil
+}
+
+//formatDate Format calendar date as YYYY-MM-DD
+func formatDate(date time.Time) string {
+	return date.Format("2006-01-02")
+}
+
+//mustTable Create table of built-in report struct
+func mustTable(name string, row interface{}, measures ...string) *Table {
+	table, err := NewTable(name, row, measures...)
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+//DefaultTables Create tables of report types supported by SDK, keyed by report type
+func DefaultTables() map[string]*Table {
+	financial := mustTable("financial_reports", appstore.FinancialReport{}, "quantity", "extended_partner_share")
+	return map[string]*Table{
+		string(appstore.SalesReportTypeSales): mustTable("sales_reports", appstore.SalesReport{}, "units"),
+		string(appstore.SalesReportTypePreorder): mustTable("pre_orders_reports", appstore.PreOrdersReport{},
+			"ordered", "canceled", "cumulative_ordered", "cumulative_canceled"),
+		string(appstore.SalesReportTypeSubscription): mustTable("subscriptions_reports", appstore.SubscriptionsReport{},
+			"active_standard_price_subscriptions", "active_free_trial_introductory_offer_subscriptions",
+			"active_pay_up_front_introductory_offer_subscriptions", "active_pay_as_you_go_introductory_offer_subscriptions",
+			"free_trial_promotional_offer_subscriptions", "pay_up_front_promotional_offer_subscriptions",
+			"pay_as_you_go_promotional_offer_subscriptions", "marketing_opt_ins", "billing_retry", "grace_period",
+			"free_trial_offer_code_subscriptions", "pay_up_front_offer_code_subscriptions",
+			"pay_as_you_go_offer_code_subscriptions", "subscribers"),
+		string(appstore.SalesReportTypeSubscriptionEvent): mustTable("subscriptions_events_reports", appstore.SubscriptionsEventsReport{}, "quantity"),
+		string(appstore.SalesReportTypeSubscriber):        mustTable("subscribers_reports", appstore.SubscribersReport{}, "units"),
+		string(appstore.SalesReportTypeSubscriptionOfferCodeRedemption): mustTable("subscriptions_offers_redemption_reports",
+			appstore.SubscriptionsOffersRedemptionReport{}, "redemptions"),
+		string(appstore.FinancesReportTypeFinancial):     financial,
+		string(appstore.FinancesReportTypeFinanceDetail): financial,
+	}
+}
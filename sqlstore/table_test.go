@@ -0,0 +1,112 @@
+package sqlstore
+
+import (
+	appstore "github.com/matisiekpl/appstore-sdk-go"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type TableTestSuite struct {
+	suite.Suite
+	testable *Table
+	load     *Load
+}
+
+func (suite *TableTestSuite) SetupTest() {
+	table, err := NewTable("redemptions", appstore.SubscriptionsOffersRedemptionReport{}, "redemptions")
+	assert.NoError(suite.T(), err)
+	suite.testable = table
+	suite.load = &Load{VendorNumber: "123", ReportType: "SUBSCRIPTION_OFFER_CODE_REDEMPTION", Frequency: "DAILY", Version: "1_0", ReportDate: time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC)}
+}
+
+func (suite *TableTestSuite) TestNewTable() {
+	_, err := NewTable("foo", appstore.SalesReport{}, "foo")
+	assert.Error(suite.T(), err)
+	_, err = NewTable("foo", nil)
+	assert.Error(suite.T(), err)
+	type row struct {
+		RegionCode string `json:"region_code"`
+	}
+	_, err = NewTable("foo", row{})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *TableTestSuite) TestKeys() {
+	keys := suite.testable.Keys()
+	assert.Len(suite.T(), keys, 8)
+	for _, key := range keys {
+		assert.NotEqual(suite.T(), "redemptions", key.Name)
+	}
+}
+
+func (suite *TableTestSuite) TestCreate() {
+	statement := suite.testable.create(DialectSQLite)
+	assert.True(suite.T(), strings.HasPrefix(statement, `CREATE TABLE IF NOT EXISTS "redemptions" (`))
+	assert.Contains(suite.T(), statement, `"date" DATE`)
+	assert.Contains(suite.T(), statement, `"app_apple_id" INTEGER`)
+	assert.Contains(suite.T(), statement, `"territory" TEXT`)
+	assert.Contains(suite.T(), statement, `PRIMARY KEY ("vendor_number", "report_type", "frequency", "report_version", "region_code", "report_date", "row_key")`)
+}
+
+func (suite *TableTestSuite) TestValues() {
+	loadedAt := time.Date(2020, 5, 6, 0, 0, 0, 0, time.UTC)
+	row := &appstore.SubscriptionsOffersRedemptionReport{
+		Date:        appstore.CustomDate{Date: time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC)},
+		AppAppleID:  appstore.CustomInteger{Integer: 42},
+		OfferCode:   " ",
+		Territory:   "US",
+		Redemptions: appstore.CustomInteger{Integer: 3},
+	}
+	values, err := suite.testable.values(suite.load, loadedAt, row)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), values, 17)
+	assert.Equal(suite.T(), []interface{}{"123", "SUBSCRIPTION_OFFER_CODE_REDEMPTION", "DAILY", "1_0", "", "2020-05-05"}, values[:6])
+	assert.Equal(suite.T(), loadedAt, values[7])
+	assert.Equal(suite.T(), "2020-05-05", values[8])
+	assert.Equal(suite.T(), int64(42), values[10])
+	assert.Nil(suite.T(), values[14])
+	assert.Equal(suite.T(), int64(3), values[16])
+
+	//measures do not change row key, key columns do
+	row.Redemptions.Integer = 5
+	same, _ := suite.testable.values(suite.load, loadedAt, *row)
+	assert.Equal(suite.T(), values[rowKeyIndex], same[rowKeyIndex])
+	row.Territory = "PL"
+	other, _ := suite.testable.values(suite.load, loadedAt, row)
+	assert.NotEqual(suite.T(), values[rowKeyIndex], other[rowKeyIndex])
+}
+
+func (suite *TableTestSuite) TestValuesInvalid() {
+	_, err := suite.testable.values(suite.load, time.Now(), &appstore.SalesReport{})
+	assert.Error(suite.T(), err)
+	_, err = suite.testable.values(suite.load, time.Now(), (*appstore.SubscriptionsOffersRedemptionReport)(nil))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *TableTestSuite) TestColumnValue() {
+	table, _ := NewTable("sales", appstore.SalesReport{})
+	column, _ := table.Schema.Column("developer_proceeds")
+	row := &appstore.SalesReport{DeveloperProceeds: appstore.NewMoney(decimal.RequireFromString("-1.5"), "USD")}
+	value, err := columnValue(column, column.Field(reflect.ValueOf(row).Elem()))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "-1.500000", value)
+	column, _ = table.Schema.Column("units")
+	value, _ = columnValue(column, column.Field(reflect.ValueOf(appstore.SalesReport{Units: appstore.CustomFloat64{Null: true}})))
+	assert.Nil(suite.T(), value)
+}
+
+func (suite *TableTestSuite) TestDefaultTables() {
+	tables := DefaultTables()
+	assert.Len(suite.T(), tables, 8)
+	assert.Same(suite.T(), tables["FINANCIAL"], tables["FINANCE_DETAIL"])
+	assert.Len(suite.T(), tables["SALES"].Keys(), len(tables["SALES"].Schema.Columns)-1)
+}
+
+func TestTableTestSuite(t *testing.T) {
+	suite.Run(t, new(TableTestSuite))
+}
@@ -83,3 +83,15 @@ func buildStubFinancesReportsResource() *FinancesReportsResource {
 	transport := buildStubHttpTransport()
 	return &FinancesReportsResource{newResourceAbstract(transport, config)}
 }
+
+func buildStubAnalyticsReportsResource() *AnalyticsReportsResource {
+	config := buildStubConfig()
+	transport := buildStubHttpTransport()
+	return &AnalyticsReportsResource{newResourceAbstract(transport, config)}
+}
+
+func buildStubAppsResource() *AppsResource {
+	config := buildStubConfig()
+	transport := buildStubHttpTransport()
+	return &AppsResource{newResourceAbstract(transport, config)}
+}
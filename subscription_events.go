@@ -0,0 +1,310 @@
+package appstore
+
+import (
+	"strings"
+)
+
+//SubscriptionEvent Type of event of subscriptions events report rows.
+// .see https://help.apple.com/app-store-connect/#/itc5dcdf6693
+type SubscriptionEvent string
+
+const (
+	//SubscriptionEventSubscribe const
+	SubscriptionEventSubscribe SubscriptionEvent = "Subscribe"
+	//SubscriptionEventStartFreeTrial const
+	SubscriptionEventStartFreeTrial SubscriptionEvent = "Start Free Trial"
+	//SubscriptionEventStartIntroductoryOffer const
+	SubscriptionEventStartIntroductoryOffer SubscriptionEvent = "Start Introductory Offer"
+	//SubscriptionEventStartPromotionalOffer const
+	SubscriptionEventStartPromotionalOffer SubscriptionEvent = "Start Promotional Offer"
+	//SubscriptionEventStartOfferCode const
+	SubscriptionEventStartOfferCode SubscriptionEvent = "Start Offer Code"
+	//SubscriptionEventRenew const
+	SubscriptionEventRenew SubscriptionEvent = "Renew"
+	//SubscriptionEventRenewalFromBillingRetry const
+	SubscriptionEventRenewalFromBillingRetry SubscriptionEvent = "Renewal from Billing Retry"
+	//SubscriptionEventPaidSubscriptionFromFreeTrial const
+	SubscriptionEventPaidSubscriptionFromFreeTrial SubscriptionEvent = "Paid Subscription from Free Trial"
+	//SubscriptionEventPaidSubscriptionFromIntroductoryOffer const
+	SubscriptionEventPaidSubscriptionFromIntroductoryOffer SubscriptionEvent = "Paid Subscription from Introductory Offer"
+	//SubscriptionEventPaidSubscriptionFromPromotionalOffer const
+	SubscriptionEventPaidSubscriptionFromPromotionalOffer SubscriptionEvent = "Paid Subscription from Promotional Offer"
+	//SubscriptionEventPaidSubscriptionFromOfferCode const
+	SubscriptionEventPaidSubscriptionFromOfferCode SubscriptionEvent = "Paid Subscription from Offer Code"
+	//SubscriptionEventPaidSubscriptionFromBillingRetry const
+	SubscriptionEventPaidSubscriptionFromBillingRetry SubscriptionEvent = "Paid Subscription from Billing Retry"
+	//SubscriptionEventFreeTrialFromBillingRetry const
+	SubscriptionEventFreeTrialFromBillingRetry SubscriptionEvent = "Free Trial from Billing Retry"
+	//SubscriptionEventIntroductoryOfferFromBillingRetry const
+	SubscriptionEventIntroductoryOfferFromBillingRetry SubscriptionEvent = "Introductory Offer from Billing Retry"
+	//SubscriptionEventPromotionalOfferFromBillingRetry const
+	SubscriptionEventPromotionalOfferFromBillingRetry SubscriptionEvent = "Promotional Offer from Billing Retry"
+	//SubscriptionEventPromotionalOfferFromPaidSubscription const
+	SubscriptionEventPromotionalOfferFromPaidSubscription SubscriptionEvent = "Promotional Offer from Paid Subscription"
+	//SubscriptionEventBillingRetryFromFreeTrial const
+	SubscriptionEventBillingRetryFromFreeTrial SubscriptionEvent = "Billing Retry from Free Trial"
+	//SubscriptionEventBillingRetryFromIntroductoryOffer const
+	SubscriptionEventBillingRetryFromIntroductoryOffer SubscriptionEvent = "Billing Retry from Introductory Offer"
+	//SubscriptionEventBillingRetryFromPromotionalOffer const
+	SubscriptionEventBillingRetryFromPromotionalOffer SubscriptionEvent = "Billing Retry from Promotional Offer"
+	//SubscriptionEventBillingRetryFromPaidSubscription const
+	SubscriptionEventBillingRetryFromPaidSubscription SubscriptionEvent = "Billing Retry from Paid Subscription"
+	//SubscriptionEventBillingRetryFromOfferCode const
+	SubscriptionEventBillingRetryFromOfferCode SubscriptionEvent = "Billing Retry from Offer Code"
+	//SubscriptionEventGracePeriodFromFreeTrial const
+	SubscriptionEventGracePeriodFromFreeTrial SubscriptionEvent = "Grace Period from Free Trial"
+	//SubscriptionEventGracePeriodFromIntroductoryOffer const
+	SubscriptionEventGracePeriodFromIntroductoryOffer SubscriptionEvent = "Grace Period from Introductory Offer"
+	//SubscriptionEventGracePeriodFromPromotionalOffer const
+	SubscriptionEventGracePeriodFromPromotionalOffer SubscriptionEvent = "Grace Period from Promotional Offer"
+	//SubscriptionEventGracePeriodFromPaidSubscription const
+	SubscriptionEventGracePeriodFromPaidSubscription SubscriptionEvent = "Grace Period from Paid Subscription"
+	//SubscriptionEventCancel const
+	SubscriptionEventCancel SubscriptionEvent = "Cancel"
+	//SubscriptionEventCanceledFromBillingRetry const
+	SubscriptionEventCanceledFromBillingRetry SubscriptionEvent = "Canceled from Billing Retry"
+	//SubscriptionEventRefund const
+	SubscriptionEventRefund SubscriptionEvent = "Refund"
+	//SubscriptionEventReactivate const
+	SubscriptionEventReactivate SubscriptionEvent = "Reactivate"
+	//SubscriptionEventReactivateWithUpgrade const
+	SubscriptionEventReactivateWithUpgrade SubscriptionEvent = "Reactivate with Upgrade"
+	//SubscriptionEventReactivateWithDowngrade const
+	SubscriptionEventReactivateWithDowngrade SubscriptionEvent = "Reactivate with Downgrade"
+	//SubscriptionEventReactivateWithCrossgrade const
+	SubscriptionEventReactivateWithCrossgrade SubscriptionEvent = "Reactivate with Crossgrade"
+	//SubscriptionEventReactivateToIntroductoryOffer const
+	SubscriptionEventReactivateToIntroductoryOffer SubscriptionEvent = "Reactivate to Introductory Offer"
+	//SubscriptionEventReactivateToPromotionalOffer const
+	SubscriptionEventReactivateToPromotionalOffer SubscriptionEvent = "Reactivate to Promotional Offer"
+	//SubscriptionEventUpgrade const
+	SubscriptionEventUpgrade SubscriptionEvent = "Upgrade"
+	//SubscriptionEventUpgradeFromBillingRetry const
+	SubscriptionEventUpgradeFromBillingRetry SubscriptionEvent = "Upgrade from Billing Retry"
+	//SubscriptionEventUpgradeFromFreeTrial const
+	SubscriptionEventUpgradeFromFreeTrial SubscriptionEvent = "Upgrade from Free Trial"
+	//SubscriptionEventUpgradeFromIntroductoryOffer const
+	SubscriptionEventUpgradeFromIntroductoryOffer SubscriptionEvent = "Upgrade from Introductory Offer"
+	//SubscriptionEventUpgradeFromPromotionalOffer const
+	SubscriptionEventUpgradeFromPromotionalOffer SubscriptionEvent = "Upgrade from Promotional Offer"
+	//SubscriptionEventUpgradeFromPaidSubscription const
+	SubscriptionEventUpgradeFromPaidSubscription SubscriptionEvent = "Upgrade from Paid Subscription"
+	//SubscriptionEventDowngrade const
+	SubscriptionEventDowngrade SubscriptionEvent = "Downgrade"
+	//SubscriptionEventDowngradeFromBillingRetry const
+	SubscriptionEventDowngradeFromBillingRetry SubscriptionEvent = "Downgrade from Billing Retry"
+	//SubscriptionEventDowngradeFromFreeTrial const
+	SubscriptionEventDowngradeFromFreeTrial SubscriptionEvent = "Downgrade from Free Trial"
+	//SubscriptionEventDowngradeFromIntroductoryOffer const
+	SubscriptionEventDowngradeFromIntroductoryOffer SubscriptionEvent = "Downgrade from Introductory Offer"
+	//SubscriptionEventDowngradeFromPromotionalOffer const
+	SubscriptionEventDowngradeFromPromotionalOffer SubscriptionEvent = "Downgrade from Promotional Offer"
+	//SubscriptionEventDowngradeFromPaidSubscription const
+	SubscriptionEventDowngradeFromPaidSubscription SubscriptionEvent = "Downgrade from Paid Subscription"
+	//SubscriptionEventCrossgrade const
+	SubscriptionEventCrossgrade SubscriptionEvent = "Crossgrade"
+	//SubscriptionEventCrossgradeFromBillingRetry const
+	SubscriptionEventCrossgradeFromBillingRetry SubscriptionEvent = "Crossgrade from Billing Retry"
+	//SubscriptionEventCrossgradeFromFreeTrial const
+	SubscriptionEventCrossgradeFromFreeTrial SubscriptionEvent = "Crossgrade from Free Trial"
+	//SubscriptionEventCrossgradeFromIntroductoryOffer const
+	SubscriptionEventCrossgradeFromIntroductoryOffer SubscriptionEvent = "Crossgrade from Introductory Offer"
+	//SubscriptionEventCrossgradeFromPromotionalOffer const
+	SubscriptionEventCrossgradeFromPromotionalOffer SubscriptionEvent = "Crossgrade from Promotional Offer"
+	//SubscriptionEventCrossgradeFromPaidSubscription const
+	SubscriptionEventCrossgradeFromPaidSubscription SubscriptionEvent = "Crossgrade from Paid Subscription"
+)
+
+//subscriptionEventClass classification flags of subscription event
+type subscriptionEventClass int
+
+//eventNone event without classification
+const eventNone subscriptionEventClass = 0
+
+const (
+	eventStart subscriptionEventClass = 1 << iota
+	eventRenewal
+	eventConversion
+	eventBillingRetry
+	eventChurn
+	eventRefund
+	eventReactivation
+	eventPlanChange
+)
+
+//subscriptionEvents Apple's subscription events table
+var subscriptionEvents = map[SubscriptionEvent]subscriptionEventClass{
+	SubscriptionEventSubscribe:                             eventStart,
+	SubscriptionEventStartFreeTrial:                        eventStart,
+	SubscriptionEventStartIntroductoryOffer:                eventStart,
+	SubscriptionEventStartPromotionalOffer:                 eventStart,
+	SubscriptionEventStartOfferCode:                        eventStart,
+	SubscriptionEventRenew:                                 eventRenewal,
+	SubscriptionEventRenewalFromBillingRetry:               eventRenewal | eventBillingRetry,
+	SubscriptionEventPaidSubscriptionFromFreeTrial:         eventConversion,
+	SubscriptionEventPaidSubscriptionFromIntroductoryOffer: eventConversion,
+	SubscriptionEventPaidSubscriptionFromPromotionalOffer:  eventConversion,
+	SubscriptionEventPaidSubscriptionFromOfferCode:         eventConversion,
+	SubscriptionEventPaidSubscriptionFromBillingRetry:      eventRenewal | eventBillingRetry,
+	SubscriptionEventFreeTrialFromBillingRetry:             eventBillingRetry,
+	SubscriptionEventIntroductoryOfferFromBillingRetry:     eventBillingRetry,
+	SubscriptionEventPromotionalOfferFromBillingRetry:      eventBillingRetry,
+	SubscriptionEventPromotionalOfferFromPaidSubscription:  eventNone,
+	SubscriptionEventBillingRetryFromFreeTrial:             eventBillingRetry,
+	SubscriptionEventBillingRetryFromIntroductoryOffer:     eventBillingRetry,
+	SubscriptionEventBillingRetryFromPromotionalOffer:      eventBillingRetry,
+	SubscriptionEventBillingRetryFromPaidSubscription:      eventBillingRetry,
+	SubscriptionEventBillingRetryFromOfferCode:             eventBillingRetry,
+	SubscriptionEventGracePeriodFromFreeTrial:              eventBillingRetry,
+	SubscriptionEventGracePeriodFromIntroductoryOffer:      eventBillingRetry,
+	SubscriptionEventGracePeriodFromPromotionalOffer:       eventBillingRetry,
+	SubscriptionEventGracePeriodFromPaidSubscription:       eventBillingRetry,
+	SubscriptionEventCancel:                                eventChurn,
+	SubscriptionEventCanceledFromBillingRetry:              eventChurn | eventBillingRetry,
+	SubscriptionEventRefund:                                eventRefund,
+	SubscriptionEventReactivate:                            eventReactivation,
+	SubscriptionEventReactivateWithUpgrade:                 eventReactivation | eventPlanChange,
+	SubscriptionEventReactivateWithDowngrade:               eventReactivation | eventPlanChange,
+	SubscriptionEventReactivateWithCrossgrade:              eventReactivation | eventPlanChange,
+	SubscriptionEventReactivateToIntroductoryOffer:         eventReactivation,
+	SubscriptionEventReactivateToPromotionalOffer:          eventReactivation,
+	SubscriptionEventUpgrade:                               eventPlanChange,
+	SubscriptionEventUpgradeFromBillingRetry:               eventPlanChange | eventBillingRetry,
+	SubscriptionEventUpgradeFromFreeTrial:                  eventPlanChange,
+	SubscriptionEventUpgradeFromIntroductoryOffer:          eventPlanChange,
+	SubscriptionEventUpgradeFromPromotionalOffer:           eventPlanChange,
+	SubscriptionEventUpgradeFromPaidSubscription:           eventPlanChange,
+	SubscriptionEventDowngrade:                             eventPlanChange,
+	SubscriptionEventDowngradeFromBillingRetry:             eventPlanChange | eventBillingRetry,
+	SubscriptionEventDowngradeFromFreeTrial:                eventPlanChange,
+	SubscriptionEventDowngradeFromIntroductoryOffer:        eventPlanChange,
+	SubscriptionEventDowngradeFromPromotionalOffer:         eventPlanChange,
+	SubscriptionEventDowngradeFromPaidSubscription:         eventPlanChange,
+	SubscriptionEventCrossgrade:                            eventPlanChange,
+	SubscriptionEventCrossgradeFromBillingRetry:            eventPlanChange | eventBillingRetry,
+	SubscriptionEventCrossgradeFromFreeTrial:               eventPlanChange,
+	SubscriptionEventCrossgradeFromIntroductoryOffer:       eventPlanChange,
+	SubscriptionEventCrossgradeFromPromotionalOffer:        eventPlanChange,
+	SubscriptionEventCrossgradeFromPaidSubscription:        eventPlanChange,
+}
+
+//class Get classification of subscription event, case insensitive
+func (e SubscriptionEvent) class() (subscriptionEventClass, bool) {
+	if class, ok := subscriptionEvents[e]; ok {
+		return class, true
+	}
+	name := strings.TrimSpace(string(e))
+	for event, class := range subscriptionEvents {
+		if strings.EqualFold(string(event), name) {
+			return class, true
+		}
+	}
+	return eventNone, false
+}
+
+//is Check subscription event has classification flag
+func (e SubscriptionEvent) is(flag subscriptionEventClass) bool {
+	class, _ := e.class()
+	return class&flag != 0
+}
+
+//IsKnown Subscription event is in Apple's table
+func (e SubscriptionEvent) IsKnown() bool {
+	_, ok := e.class()
+	return ok
+}
+
+//IsStart Subscriber started subscription, free trial or offer
+func (e SubscriptionEvent) IsStart() bool {
+	return e.is(eventStart)
+}
+
+//IsChurn Subscriber canceled subscription, voluntary or after billing retry
+func (e SubscriptionEvent) IsChurn() bool {
+	return e.is(eventChurn)
+}
+
+//IsConversion Subscriber converted from free trial or offer to paid subscription
+func (e SubscriptionEvent) IsConversion() bool {
+	return e.is(eventConversion)
+}
+
+//IsRefund Subscription was refunded
+func (e SubscriptionEvent) IsRefund() bool {
+	return e.is(eventRefund)
+}
+
+//IsRenewal Subscription renewed, including paid subscription recovered from billing retry.
+//Recovery is a renewal of paid period rather than conversion, whichever event name Apple reports it with
+func (e SubscriptionEvent) IsRenewal() bool {
+	return e.is(eventRenewal)
+}
+
+//IsBillingRetry Subscription entered, recovered from or was canceled from billing retry or grace period
+func (e SubscriptionEvent) IsBillingRetry() bool {
+	return e.is(eventBillingRetry)
+}
+
+//IsReactivation Subscriber reactivated canceled subscription
+func (e SubscriptionEvent) IsReactivation() bool {
+	return e.is(eventReactivation)
+}
+
+//IsPlanChange Subscriber upgraded, downgraded or crossgraded subscription
+func (e SubscriptionEvent) IsPlanChange() bool {
+	return e.is(eventPlanChange)
+}
+
+//SubscriptionEventsFunnel quantities of subscriptions events report rows by classification
+type SubscriptionEventsFunnel struct {
+	Starts        int
+	Conversions   int
+	Renewals      int
+	BillingRetry  int
+	Churn         int
+	Refunds       int
+	Reactivations int
+	PlanChanges   int
+	Unknown       int
+}
+
+//ConversionRate Get conversions per start, zero without starts
+func (f *SubscriptionEventsFunnel) ConversionRate() float64 {
+	if f.Starts == 0 {
+		return 0
+	}
+	return float64(f.Conversions) / float64(f.Starts)
+}
+
+//BuildSubscriptionEventsFunnel Sum quantities of subscriptions events report rows by classification,
+//event with several classifications is counted in each of them
+func BuildSubscriptionEventsFunnel(rows []*SubscriptionsEventsReport) *SubscriptionEventsFunnel {
+	funnel := &SubscriptionEventsFunnel{}
+	counters := map[subscriptionEventClass]*int{
+		eventStart:        &funnel.Starts,
+		eventConversion:   &funnel.Conversions,
+		eventRenewal:      &funnel.Renewals,
+		eventBillingRetry: &funnel.BillingRetry,
+		eventChurn:        &funnel.Churn,
+		eventRefund:       &funnel.Refunds,
+		eventReactivation: &funnel.Reactivations,
+		eventPlanChange:   &funnel.PlanChanges,
+	}
+	for _, row := range rows {
+		if row == nil {
+			continue
+		}
+		quantity := row.Quantity.Value()
+		class, ok := row.Event.class()
+		if !ok {
+			funnel.Unknown += quantity
+			continue
+		}
+		for flag, counter := range counters {
+			if class&flag != 0 {
+				*counter += quantity
+			}
+		}
+	}
+	return funnel
+}
@@ -0,0 +1,79 @@
+package appstore
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"testing"
+)
+
+type SubscriptionEventTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SubscriptionEventTestSuite) TestClassification() {
+	assert.True(suite.T(), SubscriptionEventSubscribe.IsStart())
+	assert.True(suite.T(), SubscriptionEventStartFreeTrial.IsStart())
+	assert.True(suite.T(), SubscriptionEventCancel.IsChurn())
+	assert.True(suite.T(), SubscriptionEventCanceledFromBillingRetry.IsChurn())
+	assert.True(suite.T(), SubscriptionEventCanceledFromBillingRetry.IsBillingRetry())
+	assert.True(suite.T(), SubscriptionEventPaidSubscriptionFromFreeTrial.IsConversion())
+	assert.False(suite.T(), SubscriptionEventPaidSubscriptionFromBillingRetry.IsConversion())
+	assert.True(suite.T(), SubscriptionEventRefund.IsRefund())
+	assert.True(suite.T(), SubscriptionEventRenew.IsRenewal())
+	assert.True(suite.T(), SubscriptionEventRenewalFromBillingRetry.IsRenewal())
+	assert.True(suite.T(), SubscriptionEventRenewalFromBillingRetry.IsBillingRetry())
+	assert.True(suite.T(), SubscriptionEventPaidSubscriptionFromBillingRetry.IsRenewal())
+	assert.True(suite.T(), SubscriptionEventPaidSubscriptionFromBillingRetry.IsBillingRetry())
+	assert.True(suite.T(), SubscriptionEventBillingRetryFromPaidSubscription.IsBillingRetry())
+	assert.False(suite.T(), SubscriptionEventBillingRetryFromPaidSubscription.IsChurn())
+	assert.True(suite.T(), SubscriptionEventReactivateWithUpgrade.IsReactivation())
+	assert.True(suite.T(), SubscriptionEventReactivateWithUpgrade.IsPlanChange())
+	assert.True(suite.T(), SubscriptionEventCrossgradeFromBillingRetry.IsPlanChange())
+	assert.False(suite.T(), SubscriptionEventRenew.IsChurn())
+}
+
+func (suite *SubscriptionEventTestSuite) TestIsKnown() {
+	assert.True(suite.T(), SubscriptionEventDowngradeFromIntroductoryOffer.IsKnown())
+	assert.True(suite.T(), SubscriptionEvent(" reactivate with crossgrade ").IsKnown())
+	assert.True(suite.T(), SubscriptionEvent("cancel").IsChurn())
+	assert.False(suite.T(), SubscriptionEvent("Foo").IsKnown())
+	assert.False(suite.T(), SubscriptionEvent("Foo").IsChurn())
+}
+
+func (suite *SubscriptionEventTestSuite) TestStubEventsAreKnown() {
+	data, _ := ioutil.ReadFile("stubs/reports/sales/subscriptions-events.tsv")
+	reports := []*SubscriptionsEventsReport{}
+	assert.NoError(suite.T(), UnmarshalCSV(data, &reports))
+	assert.NotEmpty(suite.T(), reports)
+	for _, report := range reports {
+		assert.True(suite.T(), report.Event.IsKnown(), string(report.Event))
+	}
+}
+
+func (suite *SubscriptionEventTestSuite) TestBuildSubscriptionEventsFunnel() {
+	rows := []*SubscriptionsEventsReport{
+		{Event: SubscriptionEventStartFreeTrial, Quantity: CustomInteger{Integer: 10}},
+		{Event: SubscriptionEventPaidSubscriptionFromFreeTrial, Quantity: CustomInteger{Integer: 4}},
+		{Event: SubscriptionEventRenew, Quantity: CustomInteger{Integer: 7}},
+		{Event: SubscriptionEventCanceledFromBillingRetry, Quantity: CustomInteger{Integer: 2}},
+		{Event: SubscriptionEventCancel, Quantity: CustomInteger{Integer: 1}},
+		{Event: SubscriptionEventRefund, Quantity: CustomInteger{Integer: 1}},
+		{Event: "Foo", Quantity: CustomInteger{Integer: 3}},
+		nil,
+	}
+	funnel := BuildSubscriptionEventsFunnel(rows)
+	assert.Equal(suite.T(), 10, funnel.Starts)
+	assert.Equal(suite.T(), 4, funnel.Conversions)
+	assert.Equal(suite.T(), 7, funnel.Renewals)
+	assert.Equal(suite.T(), 3, funnel.Churn)
+	assert.Equal(suite.T(), 2, funnel.BillingRetry)
+	assert.Equal(suite.T(), 1, funnel.Refunds)
+	assert.Equal(suite.T(), 3, funnel.Unknown)
+	assert.Equal(suite.T(), 0.4, funnel.ConversionRate())
+	assert.Equal(suite.T(), 0.0, (&SubscriptionEventsFunnel{}).ConversionRate())
+}
+
+func TestSubscriptionEventTestSuite(t *testing.T) {
+	suite.Run(t, new(SubscriptionEventTestSuite))
+}
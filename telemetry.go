@@ -0,0 +1,160 @@
+package appstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const (
+	//TelemetrySpanHttpRequest const
+	TelemetrySpanHttpRequest = "appstore.http.request"
+	//TelemetrySpanReportUnmarshal const
+	TelemetrySpanReportUnmarshal = "appstore.report.unmarshal"
+	//TelemetryMetricRequestDuration const (seconds)
+	TelemetryMetricRequestDuration = "appstore.http.request.duration"
+	//TelemetryMetricRequestErrors const
+	TelemetryMetricRequestErrors = "appstore.http.request.errors"
+	//TelemetryMetricBytesDownloaded const
+	TelemetryMetricBytesDownloaded = "appstore.report.bytes_downloaded"
+	//TelemetryMetricDecompressDuration const (seconds), gunzip of downloaded report
+	TelemetryMetricDecompressDuration = "appstore.report.decompress.duration"
+	//TelemetryMetricParseDuration const (seconds), parsing of decompressed report rows
+	TelemetryMetricParseDuration = "appstore.report.parse.duration"
+	//TelemetryMetricRowsParsed const
+	TelemetryMetricRowsParsed = "appstore.report.rows_parsed"
+	//TelemetryMetricApiErrors const
+	TelemetryMetricApiErrors = "appstore.api.errors"
+)
+
+//Attribute telemetry attribute
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+//Span telemetry span
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+//Telemetry tracing and metrics hooks, implement it to bridge any telemetry library
+type Telemetry interface {
+	StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+	AddCounter(ctx context.Context, name string, value int64, attrs ...Attribute)
+	RecordHistogram(ctx context.Context, name string, value float64, attrs ...Attribute)
+}
+
+//NopTelemetry telemetry which discards everything
+type NopTelemetry struct {
+}
+
+//StartSpan method
+func (t *NopTelemetry) StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return ctx, &NopSpan{}
+}
+
+//AddCounter method
+func (t *NopTelemetry) AddCounter(ctx context.Context, name string, value int64, attrs ...Attribute) {
+}
+
+//RecordHistogram method
+func (t *NopTelemetry) RecordHistogram(ctx context.Context, name string, value float64, attrs ...Attribute) {
+}
+
+//NopSpan span which discards everything
+type NopSpan struct {
+}
+
+//SetAttributes method
+func (s *NopSpan) SetAttributes(attrs ...Attribute) {
+}
+
+//RecordError method
+func (s *NopSpan) RecordError(err error) {
+}
+
+//End method
+func (s *NopSpan) End() {
+}
+
+//countingReadCloser counts bytes read from the underlying reader
+type countingReadCloser struct {
+	io.ReadCloser
+	count int64
+}
+
+//Read method
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.count += int64(n)
+	return n, err
+}
+
+//reportTypeName Get report row type name from unmarshal destination, e.g. SalesReport for *[]*SalesReport
+func reportTypeName(v interface{}) string {
+	t := reflect.TypeOf(v)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name()
+}
+
+//reportRowsCount Get parsed rows count from unmarshal destination
+func reportRowsCount(v interface{}) int {
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() && rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.IsValid() && rv.Kind() == reflect.Slice {
+		return rv.Len()
+	}
+	return 0
+}
+
+//responseContext Get request context from response
+func responseContext(resp *http.Response) context.Context {
+	if resp.Request != nil {
+		return resp.Request.Context()
+	}
+	return context.Background()
+}
+
+//instrumentRequest Send request with span and latency metrics
+func instrumentRequest(telemetry Telemetry, req *http.Request, handler RequestHandlerFunc) (*http.Response, error) {
+	attrs := []Attribute{{Key: "http.method", Value: req.Method}, {Key: "http.path", Value: req.URL.Path}}
+	ctx, span := telemetry.StartSpan(req.Context(), TelemetrySpanHttpRequest, attrs...)
+	defer span.End()
+	started := time.Now()
+	resp, err := handler(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		telemetry.AddCounter(ctx, TelemetryMetricRequestErrors, 1, attrs...)
+		//failed requests are part of latency too
+		telemetry.RecordHistogram(ctx, TelemetryMetricRequestDuration, time.Since(started).Seconds(), attrs...)
+		return resp, err
+	}
+	attrs = append(attrs, Attribute{Key: "http.status_code", Value: resp.StatusCode})
+	span.SetAttributes(Attribute{Key: "http.status_code", Value: resp.StatusCode})
+	telemetry.RecordHistogram(ctx, TelemetryMetricRequestDuration, time.Since(started).Seconds(), attrs...)
+	return resp, nil
+}
+
+//recordApiErrors Count api errors by code
+func recordApiErrors(telemetry Telemetry, ctx context.Context, errs []*Error) {
+	for _, e := range errs {
+		status, _ := strconv.Atoi(e.Status)
+		telemetry.AddCounter(ctx, TelemetryMetricApiErrors, 1,
+			Attribute{Key: "error.code", Value: e.Code},
+			Attribute{Key: "http.status_code", Value: status},
+		)
+	}
+}
@@ -0,0 +1,188 @@
+package appstore
+
+import (
+	"context"
+	"errors"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type stubSpan struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+func (s *stubSpan) SetAttributes(attrs ...Attribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *stubSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *stubSpan) End() {
+	s.ended = true
+}
+
+type slowReadCloser struct {
+	io.ReadCloser
+	delay time.Duration
+}
+
+func (r *slowReadCloser) Read(p []byte) (int, error) {
+	time.Sleep(r.delay)
+	return r.ReadCloser.Read(p)
+}
+
+type stubMetric struct {
+	value float64
+	attrs map[string]interface{}
+}
+
+type stubTelemetry struct {
+	spans   []*stubSpan
+	metrics map[string][]*stubMetric
+}
+
+func (t *stubTelemetry) StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &stubSpan{name: name, attrs: make(map[string]interface{})}
+	span.SetAttributes(attrs...)
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func (t *stubTelemetry) AddCounter(ctx context.Context, name string, value int64, attrs ...Attribute) {
+	t.RecordHistogram(ctx, name, float64(value), attrs...)
+}
+
+func (t *stubTelemetry) RecordHistogram(ctx context.Context, name string, value float64, attrs ...Attribute) {
+	metric := &stubMetric{value: value, attrs: make(map[string]interface{})}
+	for _, attr := range attrs {
+		metric.attrs[attr.Key] = attr.Value
+	}
+	t.metrics[name] = append(t.metrics[name], metric)
+}
+
+type TelemetryTestSuite struct {
+	suite.Suite
+	cfg       *Config
+	ctx       context.Context
+	telemetry *stubTelemetry
+	testable  *SalesReportsResource
+}
+
+func (suite *TelemetryTestSuite) SetupTest() {
+	suite.cfg = buildStubConfig()
+	suite.ctx = context.Background()
+	suite.telemetry = &stubTelemetry{metrics: make(map[string][]*stubMetric)}
+	suite.testable = buildStubSalesReportsResource()
+	suite.testable.transport.SetTelemetry(suite.telemetry)
+	httpmock.Activate()
+}
+
+func (suite *TelemetryTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+}
+
+func (suite *TelemetryTestSuite) TestGetSalesReportsSuccess() {
+	rsp := buildStubResponseFromGzip(http.StatusOK, "stubs/reports/sales/sales.tsv")
+	rsp.Header.Set("Content-Type", ResponseContentTypeGzip)
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/v1/salesReports", httpmock.ResponderFromResponse(rsp))
+
+	filter := NewSalesReportsFilter()
+	filter.SubTypeSummary().Version11().Daily()
+	result, _, err := suite.testable.GetSalesReports(suite.ctx, filter)
+	assert.NoError(suite.T(), err)
+
+	assert.Len(suite.T(), suite.telemetry.spans, 2)
+	assert.Equal(suite.T(), TelemetrySpanHttpRequest, suite.telemetry.spans[0].name)
+	assert.Equal(suite.T(), http.StatusOK, suite.telemetry.spans[0].attrs["http.status_code"])
+	assert.True(suite.T(), suite.telemetry.spans[0].ended)
+	assert.Equal(suite.T(), TelemetrySpanReportUnmarshal, suite.telemetry.spans[1].name)
+	assert.Equal(suite.T(), len(result.Data), suite.telemetry.spans[1].attrs["report.rows"])
+	assert.True(suite.T(), suite.telemetry.spans[1].ended)
+
+	assert.Len(suite.T(), suite.telemetry.metrics[TelemetryMetricRequestDuration], 1)
+	assert.Len(suite.T(), suite.telemetry.metrics[TelemetryMetricDecompressDuration], 1)
+	assert.Len(suite.T(), suite.telemetry.metrics[TelemetryMetricParseDuration], 1)
+	data, _ := loadStubResponseDataGzipped("stubs/reports/sales/sales.tsv")
+	assert.Equal(suite.T(), float64(len(data)), suite.telemetry.metrics[TelemetryMetricBytesDownloaded][0].value)
+	rows := suite.telemetry.metrics[TelemetryMetricRowsParsed][0]
+	assert.Equal(suite.T(), float64(len(result.Data)), rows.value)
+	assert.Equal(suite.T(), "SalesReport", rows.attrs["report.type"])
+}
+
+func (suite *TelemetryTestSuite) TestGetSalesReportsApiError() {
+	rsp := buildStubResponseFromFile(http.StatusBadRequest, "stubs/errors/invalid.parameter.json")
+	rsp.Header.Set("Content-Type", ResponseContentTypeJson)
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/v1/salesReports", httpmock.ResponderFromResponse(rsp))
+
+	filter := NewSalesReportsFilter()
+	filter.SubTypeSummary().Version11().Daily()
+	_, _, err := suite.testable.GetSalesReports(suite.ctx, filter)
+	assert.Error(suite.T(), err)
+	assert.Len(suite.T(), suite.telemetry.metrics[TelemetryMetricApiErrors], 1)
+	metric := suite.telemetry.metrics[TelemetryMetricApiErrors][0]
+	assert.Equal(suite.T(), "PARAMETER_ERROR.INVALID", metric.attrs["error.code"])
+	assert.Equal(suite.T(), http.StatusBadRequest, metric.attrs["http.status_code"])
+	assert.Empty(suite.T(), suite.telemetry.metrics[TelemetryMetricRowsParsed])
+}
+
+func (suite *TelemetryTestSuite) TestRequestError() {
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/v1/salesReports", httpmock.NewErrorResponder(errors.New("foo")))
+
+	filter := NewSalesReportsFilter()
+	filter.SubTypeSummary().Version11().Daily()
+	_, _, err := suite.testable.GetSalesReports(suite.ctx, filter)
+	assert.Error(suite.T(), err)
+	assert.Error(suite.T(), suite.telemetry.spans[0].err)
+	assert.Len(suite.T(), suite.telemetry.metrics[TelemetryMetricRequestErrors], 1)
+	assert.Len(suite.T(), suite.telemetry.metrics[TelemetryMetricRequestDuration], 1)
+}
+
+func (suite *TelemetryTestSuite) TestDecompressDurationExcludesTransfer() {
+	rsp := buildStubResponseFromGzip(http.StatusOK, "stubs/reports/sales/sales.tsv")
+	rsp.Header.Set("Content-Type", ResponseContentTypeGzip)
+	rsp.Body = &slowReadCloser{ReadCloser: rsp.Body, delay: 100 * time.Millisecond}
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/v1/salesReports", httpmock.ResponderFromResponse(rsp))
+
+	filter := NewSalesReportsFilter()
+	filter.SubTypeSummary().Version11().Daily()
+	_, _, err := suite.testable.GetSalesReports(suite.ctx, filter)
+	assert.NoError(suite.T(), err)
+	assert.Less(suite.T(), suite.telemetry.metrics[TelemetryMetricDecompressDuration][0].value, 0.1)
+	assert.Less(suite.T(), suite.telemetry.metrics[TelemetryMetricParseDuration][0].value, 0.1)
+}
+
+func (suite *TelemetryTestSuite) TestJsonApiResponseHasNoRows() {
+	rsp := buildStubResponseFromFile(http.StatusOK, "stubs/analytics/report_requests.json")
+	rsp.Header.Set("Content-Type", ResponseContentTypeJson)
+	httpmock.RegisterResponder("GET", suite.cfg.Uri+"/v1/apps/1234567890/analyticsReportRequests", httpmock.ResponderFromResponse(rsp))
+
+	resource := &AnalyticsReportsResource{newResourceAbstract(suite.testable.transport, suite.cfg)}
+	_, _, err := resource.GetReportRequests(suite.ctx, "1234567890", nil)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.telemetry.metrics[TelemetryMetricBytesDownloaded], 1)
+	assert.Empty(suite.T(), suite.telemetry.metrics[TelemetryMetricDecompressDuration])
+	assert.Empty(suite.T(), suite.telemetry.metrics[TelemetryMetricParseDuration])
+	assert.Empty(suite.T(), suite.telemetry.metrics[TelemetryMetricRowsParsed])
+}
+
+func (suite *TelemetryTestSuite) TestReportTypeName() {
+	assert.Equal(suite.T(), "FinancialReport", reportTypeName(&[]*FinancialReport{}))
+	assert.Equal(suite.T(), "ResponseBody", reportTypeName(&ResponseBody{}))
+	assert.Equal(suite.T(), "", reportTypeName(nil))
+}
+
+func TestTelemetryTestSuite(t *testing.T) {
+	suite.Run(t, new(TelemetryTestSuite))
+}
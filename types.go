@@ -1,6 +1,8 @@
 package appstore
 
 import (
+	"bytes"
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,9 +20,43 @@ const CustomDateFormatDefault = "2006-01-02"
 //CustomDateFormatSlash const
 const CustomDateFormatSlash = "01/02/2006"
 
+//jsonNull JSON null literal
+var jsonNull = []byte(`null`)
+
+//SQLValuer Report field providing database value. Custom types and Money keep Value method as typed getter,
+//so they do not implement driver.Valuer themselves, pass Valuer() of field to database/sql instead
+type SQLValuer interface {
+	Valuer() driver.Valuer
+}
+
+//driverValuerFunc adapter of func to driver.Valuer
+type driverValuerFunc func() (driver.Value, error)
+
+//Value implementation of driver.Valuer
+func (f driverValuerFunc) Value() (driver.Value, error) {
+	return f()
+}
+
+//isJSONNull Check raw JSON value is null
+func isJSONNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), jsonNull)
+}
+
+//scanString Convert database value to string
+func scanString(src interface{}) (string, bool) {
+	switch v := src.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	}
+	return "", false
+}
+
 //CustomInteger custom integer type
 type CustomInteger struct {
 	Integer int
+	Null    bool //true when value was blank in CSV, null in JSON or NULL in database
 }
 
 //Value Custom integer get value
@@ -28,8 +64,16 @@ func (ci *CustomInteger) Value() int {
 	return ci.Integer
 }
 
+//IsPresent Custom integer value is present
+func (ci *CustomInteger) IsPresent() bool {
+	return !ci.Null
+}
+
 //MarshalJSON Custom integer MarshalJSON
 func (ci *CustomInteger) MarshalJSON() ([]byte, error) {
+	if ci.Null {
+		return jsonNull, nil
+	}
 	jsonData, err := json.Marshal(ci.Integer)
 	if err != nil {
 		return nil, errors.New("CustomInteger.MarshalJSON: " + err.Error())
@@ -37,9 +81,23 @@ func (ci *CustomInteger) MarshalJSON() ([]byte, error) {
 	return jsonData, err
 }
 
+//UnmarshalJSON Custom integer UnmarshalJSON
+func (ci *CustomInteger) UnmarshalJSON(data []byte) error {
+	*ci = CustomInteger{Null: isJSONNull(data)}
+	if ci.Null {
+		return nil
+	}
+	err := json.Unmarshal(data, &ci.Integer)
+	if err != nil {
+		return errors.New("CustomInteger.UnmarshalJSON: " + err.Error())
+	}
+	return nil
+}
+
 //UnmarshalCSV Custom integer UnmarshalCSV
 func (ci *CustomInteger) UnmarshalCSV(csv string) error {
 	csv = strings.Trim(csv, " ")
+	ci.Null = csv == ""
 	if csv != "" {
 		var err error
 		ci.Integer, err = strconv.Atoi(csv)
@@ -50,9 +108,48 @@ func (ci *CustomInteger) UnmarshalCSV(csv string) error {
 	return nil
 }
 
+//MarshalCSV Custom integer MarshalCSV
+func (ci *CustomInteger) MarshalCSV() (string, error) {
+	if ci.Null {
+		return "", nil
+	}
+	return strconv.Itoa(ci.Integer), nil
+}
+
+//Scan Custom integer implementation of sql.Scanner
+func (ci *CustomInteger) Scan(src interface{}) error {
+	*ci = CustomInteger{Null: src == nil}
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case int64:
+		ci.Integer = int(v)
+		return nil
+	case float64:
+		ci.Integer = int(v)
+		return nil
+	}
+	str, ok := scanString(src)
+	if !ok {
+		return fmt.Errorf("CustomInteger.Scan: unsupported type %T", src)
+	}
+	return ci.UnmarshalCSV(str)
+}
+
+//Valuer Custom integer driver.Valuer, Value method is reserved for typed value getter
+func (ci *CustomInteger) Valuer() driver.Valuer {
+	return driverValuerFunc(func() (driver.Value, error) {
+		if ci.Null {
+			return nil, nil
+		}
+		return int64(ci.Integer), nil
+	})
+}
+
 //CustomFloat64 custom float type
 type CustomFloat64 struct {
 	Float64 float64
+	Null    bool //true when value was blank in CSV, null in JSON or NULL in database
 }
 
 //Value Custom float get value
@@ -60,9 +157,15 @@ func (cf *CustomFloat64) Value() float64 {
 	return cf.Float64
 }
 
+//IsPresent Custom float value is present
+func (cf *CustomFloat64) IsPresent() bool {
+	return !cf.Null
+}
+
 //UnmarshalCSV Custom float UnmarshalCSV
 func (cf *CustomFloat64) UnmarshalCSV(csv string) error {
 	csv = strings.Trim(csv, " ")
+	cf.Null = csv == ""
 	if csv != "" {
 		var err error
 		cf.Float64, err = strconv.ParseFloat(csv, 32)
@@ -73,8 +176,19 @@ func (cf *CustomFloat64) UnmarshalCSV(csv string) error {
 	return nil
 }
 
+//MarshalCSV Custom float MarshalCSV, value is formatted with float32 precision it was parsed with
+func (cf *CustomFloat64) MarshalCSV() (string, error) {
+	if cf.Null {
+		return "", nil
+	}
+	return strconv.FormatFloat(cf.Float64, 'f', -1, 32), nil
+}
+
 //MarshalJSON Custom float MarshalJSON
 func (cf *CustomFloat64) MarshalJSON() ([]byte, error) {
+	if cf.Null {
+		return jsonNull, nil
+	}
 	jsonData, err := json.Marshal(cf.Float64)
 	if err != nil {
 		return nil, errors.New("CustomFloat64.MarshalJSON: " + err.Error())
@@ -82,9 +196,63 @@ func (cf *CustomFloat64) MarshalJSON() ([]byte, error) {
 	return jsonData, err
 }
 
+//UnmarshalJSON Custom float UnmarshalJSON
+func (cf *CustomFloat64) UnmarshalJSON(data []byte) error {
+	*cf = CustomFloat64{Null: isJSONNull(data)}
+	if cf.Null {
+		return nil
+	}
+	err := json.Unmarshal(data, &cf.Float64)
+	if err != nil {
+		return errors.New("CustomFloat64.UnmarshalJSON: " + err.Error())
+	}
+	return nil
+}
+
+//Scan Custom float implementation of sql.Scanner
+func (cf *CustomFloat64) Scan(src interface{}) error {
+	*cf = CustomFloat64{Null: src == nil}
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case float64:
+		cf.Float64 = v
+		return nil
+	case int64:
+		cf.Float64 = float64(v)
+		return nil
+	}
+	str, ok := scanString(src)
+	if !ok {
+		return fmt.Errorf("CustomFloat64.Scan: unsupported type %T", src)
+	}
+	str = strings.Trim(str, " ")
+	cf.Null = str == ""
+	if cf.Null {
+		return nil
+	}
+	var err error
+	cf.Float64, err = strconv.ParseFloat(str, 64)
+	if err != nil {
+		return fmt.Errorf("CustomFloat64.Scan Parse float: %v", err)
+	}
+	return nil
+}
+
+//Valuer Custom float driver.Valuer, Value method is reserved for typed value getter
+func (cf *CustomFloat64) Valuer() driver.Valuer {
+	return driverValuerFunc(func() (driver.Value, error) {
+		if cf.Null {
+			return nil, nil
+		}
+		return cf.Float64, nil
+	})
+}
+
 //CustomTimestamp custom timestamp type
 type CustomTimestamp struct {
 	Timestamp time.Time
+	Null      bool //true when value was blank in CSV, null in JSON or NULL in database
 }
 
 //Value Custom timestamp get value
@@ -92,9 +260,15 @@ func (ct *CustomTimestamp) Value() time.Time {
 	return ct.Timestamp
 }
 
+//IsPresent Custom timestamp value is present
+func (ct *CustomTimestamp) IsPresent() bool {
+	return !ct.Null
+}
+
 //UnmarshalCSV Custom timestamp UnmarshalCSV
 func (ct *CustomTimestamp) UnmarshalCSV(csv string) error {
 	csv = strings.Trim(csv, " ")
+	ct.Null = csv == ""
 	if csv != "" {
 		var err error
 		ct.Timestamp, err = time.Parse(CustomTimestampFormatDefault, csv)
@@ -105,8 +279,19 @@ func (ct *CustomTimestamp) UnmarshalCSV(csv string) error {
 	return nil
 }
 
+//MarshalCSV Custom timestamp MarshalCSV
+func (ct *CustomTimestamp) MarshalCSV() (string, error) {
+	if ct.Null || ct.Timestamp.IsZero() {
+		return "", nil
+	}
+	return ct.Timestamp.Format(CustomTimestampFormatDefault), nil
+}
+
 //MarshalJSON Custom timestamp MarshalJSON
 func (ct *CustomTimestamp) MarshalJSON() ([]byte, error) {
+	if ct.Null {
+		return jsonNull, nil
+	}
 	if ct.Timestamp.IsZero() {
 		return []byte(`""`), nil
 	}
@@ -118,9 +303,58 @@ func (ct *CustomTimestamp) MarshalJSON() ([]byte, error) {
 	return jsonData, err
 }
 
+//UnmarshalJSON Custom timestamp UnmarshalJSON
+func (ct *CustomTimestamp) UnmarshalJSON(data []byte) error {
+	*ct = CustomTimestamp{Null: isJSONNull(data)}
+	if ct.Null {
+		return nil
+	}
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return errors.New("CustomTimestamp.UnmarshalJSON: " + err.Error())
+	}
+	if str == "" {
+		return nil
+	}
+	ct.Timestamp, err = time.Parse(CustomTimestampFormatDefault, str)
+	if err != nil {
+		return fmt.Errorf("CustomTimestamp.UnmarshalJSON ParseTime: %v", err)
+	}
+	return nil
+}
+
+//Scan Custom timestamp implementation of sql.Scanner
+func (ct *CustomTimestamp) Scan(src interface{}) error {
+	*ct = CustomTimestamp{Null: src == nil}
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		ct.Timestamp = v
+		return nil
+	}
+	str, ok := scanString(src)
+	if !ok {
+		return fmt.Errorf("CustomTimestamp.Scan: unsupported type %T", src)
+	}
+	return ct.UnmarshalCSV(str)
+}
+
+//Valuer Custom timestamp driver.Valuer, Value method is reserved for typed value getter
+func (ct *CustomTimestamp) Valuer() driver.Valuer {
+	return driverValuerFunc(func() (driver.Value, error) {
+		if ct.Null || ct.Timestamp.IsZero() {
+			return nil, nil
+		}
+		return ct.Timestamp, nil
+	})
+}
+
 //CustomDate Custom date type
 type CustomDate struct {
 	Date time.Time
+	Null bool //true when value was blank in CSV, null in JSON or NULL in database
 }
 
 //Value Custom date get value
@@ -128,9 +362,15 @@ func (ct *CustomDate) Value() time.Time {
 	return ct.Date
 }
 
+//IsPresent Custom date value is present
+func (ct *CustomDate) IsPresent() bool {
+	return !ct.Null
+}
+
 //UnmarshalCSV Custom date UnmarshalCSV
 func (ct *CustomDate) UnmarshalCSV(csv string) error {
 	csv = strings.Trim(csv, " ")
+	ct.Null = csv == ""
 	if csv == "" {
 		return nil
 	}
@@ -149,8 +389,24 @@ func (ct *CustomDate) UnmarshalCSV(csv string) error {
 	return nil
 }
 
+//MarshalCSV Custom date MarshalCSV
+func (ct *CustomDate) MarshalCSV() (string, error) {
+	return ct.MarshalCSVWithFormat(CustomDateFormatDefault)
+}
+
+//MarshalCSVWithFormat Custom date MarshalCSV with date format
+func (ct *CustomDate) MarshalCSVWithFormat(format string) (string, error) {
+	if ct.Null || ct.Date.IsZero() {
+		return "", nil
+	}
+	return ct.Date.Format(format), nil
+}
+
 //MarshalJSON Custom date MarshalJSON
 func (ct *CustomDate) MarshalJSON() ([]byte, error) {
+	if ct.Null {
+		return jsonNull, nil
+	}
 	if ct.Date.IsZero() {
 		return []byte(`""`), nil
 	}
@@ -161,9 +417,62 @@ func (ct *CustomDate) MarshalJSON() ([]byte, error) {
 	return jsonData, err
 }
 
+//UnmarshalJSON Custom date UnmarshalJSON
+func (ct *CustomDate) UnmarshalJSON(data []byte) error {
+	*ct = CustomDate{Null: isJSONNull(data)}
+	if ct.Null {
+		return nil
+	}
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return errors.New("CustomDate.UnmarshalJSON: " + err.Error())
+	}
+	if str == "" {
+		return nil
+	}
+	ct.Date, err = time.Parse(CustomDateFormatDefault, str)
+	if err != nil {
+		return fmt.Errorf("CustomDate.UnmarshalJSON ParseTime: %v", err)
+	}
+	return nil
+}
+
+//Scan Custom date implementation of sql.Scanner
+func (ct *CustomDate) Scan(src interface{}) error {
+	*ct = CustomDate{Null: src == nil}
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		ct.Date = v
+		return nil
+	}
+	str, ok := scanString(src)
+	if !ok {
+		return fmt.Errorf("CustomDate.Scan: unsupported type %T", src)
+	}
+	if len(str) > len(CustomDateFormatDefault) && !strings.Contains(str, `/`) {
+		//drivers may return date columns as full timestamps
+		str = str[:len(CustomDateFormatDefault)]
+	}
+	return ct.UnmarshalCSV(str)
+}
+
+//Valuer Custom date driver.Valuer, Value method is reserved for typed value getter
+func (ct *CustomDate) Valuer() driver.Valuer {
+	return driverValuerFunc(func() (driver.Value, error) {
+		if ct.Null || ct.Date.IsZero() {
+			return nil, nil
+		}
+		return ct.Date, nil
+	})
+}
+
 //CustomBoolean Custom boolean type
 type CustomBoolean struct {
 	Boolean bool
+	Null    bool //true when value was blank in CSV, null in JSON or NULL in database
 }
 
 //Value Custom boolean get value
@@ -171,8 +480,14 @@ func (cb *CustomBoolean) Value() bool {
 	return cb.Boolean
 }
 
+//IsPresent Custom boolean value is present
+func (cb *CustomBoolean) IsPresent() bool {
+	return !cb.Null
+}
+
 //UnmarshalCSV Custom boolean UnmarshalCSV
 func (cb *CustomBoolean) UnmarshalCSV(csv string) error {
+	cb.Null = strings.Trim(csv, " ") == ""
 	switch strings.ToLower(csv) {
 	case "false":
 		cb.Boolean = false
@@ -182,11 +497,69 @@ func (cb *CustomBoolean) UnmarshalCSV(csv string) error {
 	return nil
 }
 
+//MarshalCSV Custom boolean MarshalCSV
+func (cb *CustomBoolean) MarshalCSV() (string, error) {
+	if cb.Null {
+		return "", nil
+	}
+	return strconv.FormatBool(cb.Boolean), nil
+}
+
 //MarshalJSON Custom boolean MarshalJSON
 func (cb *CustomBoolean) MarshalJSON() ([]byte, error) {
+	if cb.Null {
+		return jsonNull, nil
+	}
 	jsonData, err := json.Marshal(cb.Boolean)
 	if err != nil {
 		return nil, errors.New("CustomBoolean.MarshalJSON: " + err.Error())
 	}
 	return jsonData, err
 }
+
+//UnmarshalJSON Custom boolean UnmarshalJSON
+func (cb *CustomBoolean) UnmarshalJSON(data []byte) error {
+	*cb = CustomBoolean{Null: isJSONNull(data)}
+	if cb.Null {
+		return nil
+	}
+	err := json.Unmarshal(data, &cb.Boolean)
+	if err != nil {
+		return errors.New("CustomBoolean.UnmarshalJSON: " + err.Error())
+	}
+	return nil
+}
+
+//Scan Custom boolean implementation of sql.Scanner
+func (cb *CustomBoolean) Scan(src interface{}) error {
+	*cb = CustomBoolean{Null: src == nil}
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case bool:
+		cb.Boolean = v
+		return nil
+	case int64:
+		cb.Boolean = v != 0
+		return nil
+	}
+	str, ok := scanString(src)
+	if !ok {
+		return fmt.Errorf("CustomBoolean.Scan: unsupported type %T", src)
+	}
+	if str == "1" || str == "0" {
+		cb.Boolean = str == "1"
+		return nil
+	}
+	return cb.UnmarshalCSV(str)
+}
+
+//Valuer Custom boolean driver.Valuer, Value method is reserved for typed value getter
+func (cb *CustomBoolean) Valuer() driver.Valuer {
+	return driverValuerFunc(func() (driver.Value, error) {
+		if cb.Null {
+			return nil, nil
+		}
+		return cb.Boolean, nil
+	})
+}
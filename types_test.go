@@ -52,6 +52,47 @@ func (suite *TypesCustomIntegerTestSuite) TestUnmarshalCSVError() {
 	assert.Equal(suite.T(), `CustomInteger.UnmarshalCSV Parse int: strconv.Atoi: parsing "foo": invalid syntax`, err.Error())
 }
 
+func (suite *TypesCustomIntegerTestSuite) TestMarshalCSV() {
+	suite.testable.Integer = -10
+	result, err := suite.testable.MarshalCSV()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "-10", result)
+}
+
+func (suite *TypesCustomIntegerTestSuite) TestUnmarshalJSON() {
+	err := suite.testable.UnmarshalJSON([]byte(`10`))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 10, suite.testable.Value())
+	assert.True(suite.T(), suite.testable.IsPresent())
+	err = suite.testable.UnmarshalJSON([]byte(`null`))
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), suite.testable.IsPresent())
+	err = suite.testable.UnmarshalJSON([]byte(`"foo"`))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *TypesCustomIntegerTestSuite) TestNull() {
+	_ = suite.testable.UnmarshalCSV(" ")
+	assert.False(suite.T(), suite.testable.IsPresent())
+	result, _ := suite.testable.MarshalJSON()
+	assert.Equal(suite.T(), []byte(`null`), result)
+	csv, _ := suite.testable.MarshalCSV()
+	assert.Equal(suite.T(), "", csv)
+	value, _ := suite.testable.Valuer().Value()
+	assert.Nil(suite.T(), value)
+}
+
+func (suite *TypesCustomIntegerTestSuite) TestScan() {
+	assert.NoError(suite.T(), suite.testable.Scan(int64(12)))
+	value, _ := suite.testable.Valuer().Value()
+	assert.Equal(suite.T(), int64(12), value)
+	assert.NoError(suite.T(), suite.testable.Scan([]byte("13")))
+	assert.Equal(suite.T(), 13, suite.testable.Value())
+	assert.NoError(suite.T(), suite.testable.Scan(nil))
+	assert.False(suite.T(), suite.testable.IsPresent())
+	assert.Error(suite.T(), suite.testable.Scan(true))
+}
+
 type TypesCustomFloat64TestSuite struct {
 	suite.Suite
 	testable *CustomFloat64
@@ -97,6 +138,44 @@ func (suite *TypesCustomFloat64TestSuite) TestUnmarshalCSVError() {
 	assert.Equal(suite.T(), `CustomFloat64.UnmarshalCSV Parse float: strconv.ParseFloat: parsing "foo": invalid syntax`, err.Error())
 }
 
+func (suite *TypesCustomFloat64TestSuite) TestMarshalCSV() {
+	_ = suite.testable.UnmarshalCSV("209.30")
+	result, err := suite.testable.MarshalCSV()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "209.3", result)
+}
+
+func (suite *TypesCustomFloat64TestSuite) TestUnmarshalJSON() {
+	err := suite.testable.UnmarshalJSON([]byte(`10.5`))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 10.5, suite.testable.Value())
+	err = suite.testable.UnmarshalJSON([]byte(`null`))
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), suite.testable.IsPresent())
+	err = suite.testable.UnmarshalJSON([]byte(`"foo"`))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *TypesCustomFloat64TestSuite) TestNull() {
+	_ = suite.testable.UnmarshalCSV("")
+	result, _ := suite.testable.MarshalJSON()
+	assert.Equal(suite.T(), []byte(`null`), result)
+	csv, _ := suite.testable.MarshalCSV()
+	assert.Equal(suite.T(), "", csv)
+}
+
+func (suite *TypesCustomFloat64TestSuite) TestScan() {
+	assert.NoError(suite.T(), suite.testable.Scan(1.25))
+	value, _ := suite.testable.Valuer().Value()
+	assert.Equal(suite.T(), 1.25, value)
+	assert.NoError(suite.T(), suite.testable.Scan("2.5"))
+	assert.Equal(suite.T(), 2.5, suite.testable.Value())
+	assert.NoError(suite.T(), suite.testable.Scan(nil))
+	value, _ = suite.testable.Valuer().Value()
+	assert.Nil(suite.T(), value)
+	assert.Error(suite.T(), suite.testable.Scan("foo"))
+}
+
 type TypesCustomTimestampTestSuite struct {
 	suite.Suite
 	testable *CustomTimestamp
@@ -140,6 +219,56 @@ func (suite *TypesCustomTimestampTestSuite) TestMarshalJSONEmpty() {
 	assert.Nil(suite.T(), err)
 }
 
+func (suite *TypesCustomTimestampTestSuite) TestMarshalCSV() {
+	result, err := suite.testable.MarshalCSV()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "", result)
+	_ = suite.testable.UnmarshalCSV("2020-10-05 01:02:03")
+	result, err = suite.testable.MarshalCSV()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2020-10-05 01:02:03", result)
+}
+
+func (suite *TypesCustomTimestampTestSuite) TestUnmarshalJSON() {
+	err := suite.testable.UnmarshalJSON([]byte(`"2020-10-05 01:02:03"`))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2020-10-05 01:02:03", suite.testable.Value().Format(CustomTimestampFormatDefault))
+	err = suite.testable.UnmarshalJSON([]byte(`""`))
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), suite.testable.IsPresent())
+	assert.True(suite.T(), suite.testable.Value().IsZero())
+	err = suite.testable.UnmarshalJSON([]byte(`null`))
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), suite.testable.IsPresent())
+	err = suite.testable.UnmarshalJSON([]byte(`"foo"`))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *TypesCustomTimestampTestSuite) TestNull() {
+	_ = suite.testable.UnmarshalCSV("")
+	result, _ := suite.testable.MarshalJSON()
+	assert.Equal(suite.T(), []byte(`null`), result)
+}
+
+func (suite *TypesCustomTimestampTestSuite) TestScan() {
+	ts := time.Date(2020, time.Month(10), 5, 1, 2, 3, 0, time.UTC)
+	assert.NoError(suite.T(), suite.testable.Scan(ts))
+	value, _ := suite.testable.Valuer().Value()
+	assert.Equal(suite.T(), ts, value)
+	assert.NoError(suite.T(), suite.testable.Scan("2020-10-05 01:02:03"))
+	assert.Equal(suite.T(), ts, suite.testable.Value())
+	assert.NoError(suite.T(), suite.testable.Scan(nil))
+	assert.False(suite.T(), suite.testable.IsPresent())
+	assert.Error(suite.T(), suite.testable.Scan(int64(1)))
+}
+
+func (suite *TypesCustomTimestampTestSuite) TestValuerZero() {
+	var valuer SQLValuer = &CustomTimestamp{}
+	value, err := valuer.Valuer().Value()
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), value)
+}
+
 type TypesCustomDateTestSuite struct {
 	suite.Suite
 	testable *CustomDate
@@ -190,6 +319,49 @@ func (suite *TypesCustomDateTestSuite) TestMarshalJSONEmpty() {
 	assert.Nil(suite.T(), err)
 }
 
+func (suite *TypesCustomDateTestSuite) TestMarshalCSV() {
+	result, err := suite.testable.MarshalCSV()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "", result)
+	_ = suite.testable.UnmarshalCSV("10/05/2020")
+	result, err = suite.testable.MarshalCSV()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2020-10-05", result)
+	result, err = suite.testable.MarshalCSVWithFormat(CustomDateFormatSlash)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "10/05/2020", result)
+}
+
+func (suite *TypesCustomDateTestSuite) TestUnmarshalJSON() {
+	err := suite.testable.UnmarshalJSON([]byte(`"2020-10-05"`))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2020-10-05", suite.testable.Value().Format(CustomDateFormatDefault))
+	err = suite.testable.UnmarshalJSON([]byte(`null`))
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), suite.testable.IsPresent())
+	err = suite.testable.UnmarshalJSON([]byte(`"10/05/2020"`))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *TypesCustomDateTestSuite) TestNull() {
+	_ = suite.testable.UnmarshalCSV("")
+	result, _ := suite.testable.MarshalJSON()
+	assert.Equal(suite.T(), []byte(`null`), result)
+	csv, _ := suite.testable.MarshalCSVWithFormat(CustomDateFormatSlash)
+	assert.Equal(suite.T(), "", csv)
+}
+
+func (suite *TypesCustomDateTestSuite) TestScan() {
+	date := time.Date(2020, time.Month(10), 5, 0, 0, 0, 0, time.UTC)
+	assert.NoError(suite.T(), suite.testable.Scan(date))
+	value, _ := suite.testable.Valuer().Value()
+	assert.Equal(suite.T(), date, value)
+	assert.NoError(suite.T(), suite.testable.Scan([]byte("2020-10-05T00:00:00Z")))
+	assert.Equal(suite.T(), date, suite.testable.Value())
+	assert.NoError(suite.T(), suite.testable.Scan(nil))
+	assert.False(suite.T(), suite.testable.IsPresent())
+}
+
 type TypesCustomBooleanTestSuite struct {
 	suite.Suite
 	testable *CustomBoolean
@@ -240,6 +412,41 @@ func (suite *TypesCustomBooleanTestSuite) TestMarshalJSONEmpty() {
 	assert.Nil(suite.T(), err)
 }
 
+func (suite *TypesCustomBooleanTestSuite) TestMarshalCSV() {
+	suite.testable.Boolean = true
+	result, err := suite.testable.MarshalCSV()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "true", result)
+}
+
+func (suite *TypesCustomBooleanTestSuite) TestUnmarshalJSON() {
+	err := suite.testable.UnmarshalJSON([]byte(`true`))
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), suite.testable.Value())
+	err = suite.testable.UnmarshalJSON([]byte(`null`))
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), suite.testable.IsPresent())
+	err = suite.testable.UnmarshalJSON([]byte(`1`))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *TypesCustomBooleanTestSuite) TestNull() {
+	_ = suite.testable.UnmarshalCSV("")
+	result, _ := suite.testable.MarshalJSON()
+	assert.Equal(suite.T(), []byte(`null`), result)
+}
+
+func (suite *TypesCustomBooleanTestSuite) TestScan() {
+	assert.NoError(suite.T(), suite.testable.Scan(int64(1)))
+	value, _ := suite.testable.Valuer().Value()
+	assert.Equal(suite.T(), true, value)
+	assert.NoError(suite.T(), suite.testable.Scan("false"))
+	assert.False(suite.T(), suite.testable.Value())
+	assert.NoError(suite.T(), suite.testable.Scan(nil))
+	value, _ = suite.testable.Valuer().Value()
+	assert.Nil(suite.T(), value)
+}
+
 func TestTypesCustomIntegerTestSuite(t *testing.T) {
 	suite.Run(t, new(TypesCustomIntegerTestSuite))
 }